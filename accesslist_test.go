@@ -0,0 +1,147 @@
+package wsClient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// accessListNode answers eth_createAccessList with list, or with listErr as the
+// execution error, and eth_estimateGas with without or with depending on whether
+// the call carries an access list; an empty estimate is answered with a revert
+type accessListNode struct {
+	list      types.AccessList
+	listErr   string
+	without   string
+	with      string
+	estimates int32
+
+	mu    sync.Mutex
+	types []string // type of the calls estimated with an access list
+}
+
+func (n *accessListNode) dial(t *testing.T) *Client {
+	return newStubNode(t, func(request *Request, write func(string)) {
+		var params []json.RawMessage
+		raw, _ := json.Marshal(request.Params)
+		json.Unmarshal(raw, &params)
+		var msg CallMsg
+		json.Unmarshal(params[0], &msg)
+		if msg.AccessList != nil && request.Method == "eth_createAccessList" {
+			t.Errorf("eth_createAccessList with an access list")
+		}
+
+		switch request.Method {
+		case "eth_createAccessList":
+			list, _ := json.Marshal(AccessListResult{AccessList: n.list, GasUsed: 50000, Error: n.listErr})
+			write(result(request.ID, string(list)))
+			return
+		case "eth_estimateGas":
+			atomic.AddInt32(&n.estimates, 1)
+			gas := n.without
+			if msg.AccessList != nil {
+				gas = n.with
+				txType := "<nil>"
+				if msg.Type != nil {
+					txType = msg.Type.String()
+				}
+				n.mu.Lock()
+				n.types = append(n.types, txType)
+				n.mu.Unlock()
+			}
+			if gas != "" {
+				write(result(request.ID, `"`+gas+`"`))
+				return
+			}
+		}
+		write(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"error":{"code":3,"message":"execution reverted: ERR_NO_PROFIT"}}`, request.ID))
+	}).dial(t)
+}
+
+func TestOptimizeAccessList(t *testing.T) {
+	pair := common.HexToAddress("0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc")
+	list := types.AccessList{{Address: pair, StorageKeys: []common.Hash{common.HexToHash("0x08"), common.HexToHash("0x0c")}}}
+	legacy := hexutil.Uint64(types.LegacyTxType)
+	dynamic := hexutil.Uint64(types.DynamicFeeTxType)
+
+	tests := []struct {
+		name      string
+		node      *accessListNode
+		txType    *hexutil.Uint64
+		keep      bool
+		gas       uint64
+		estimates int32
+		sentType  string // type of the estimate with the access list, "" if none was sent
+		failure   bool
+		err       bool
+	}{
+		{"cheaper", &accessListNode{list: list, without: "0x30d40", with: "0x2ee00"}, nil, true, 192000, 2, "<nil>", false, false},
+		{"more expensive", &accessListNode{list: list, without: "0x2ee00", with: "0x30d40"}, nil, false, 192000, 2, "<nil>", false, false},
+		{"same cost", &accessListNode{list: list, without: "0x30d40", with: "0x30d40"}, nil, false, 200000, 2, "<nil>", false, false},
+		{"legacy upgraded", &accessListNode{list: list, without: "0x30d40", with: "0x2ee00"}, &legacy, true, 192000, 2, "0x1", false, false},
+		{"dynamic kept", &accessListNode{list: list, without: "0x30d40", with: "0x2ee00"}, &dynamic, true, 192000, 2, "0x2", false, false},
+		{"empty list", &accessListNode{without: "0x30d40"}, nil, false, 200000, 1, "", false, false},
+		{"estimate with list fails", &accessListNode{list: list, without: "0x30d40"}, nil, false, 200000, 2, "<nil>", true, false},
+		{"estimate fails", &accessListNode{list: list, with: "0x2ee00"}, nil, false, 0, 2, "<nil>", false, true},
+		{"call reverts", &accessListNode{list: list, listErr: "execution reverted: ERR_NO_PROFIT"}, nil, false, 0, 0, "", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := tt.node
+			client := node.dial(t)
+			router := common.HexToAddress("0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f")
+			callMsg := CallMsg{To: &router, Data: hexutil.Bytes{0x88, 0x03, 0xdb, 0xee}, Type: tt.txType}
+			// a list already set is replaced by the generated one
+			callMsg.AccessList = &types.AccessList{{Address: router}}
+
+			choice, err := client.OptimizeAccessList(context.Background(), callMsg, BlockRefFromTag(BlockLatest))
+			if (err != nil) != tt.err {
+				t.Fatalf("err = %v, wantErr %v", err, tt.err)
+			}
+			if n := atomic.LoadInt32(&node.estimates); n != tt.estimates {
+				t.Errorf("sent %d estimates, want %d", n, tt.estimates)
+			}
+			if err != nil {
+				if revertErr, ok := DecodeRevert(err); tt.node.listErr != "" && (!ok || !errors.Is(revertErr, ErrNoProfit)) {
+					t.Errorf("err = %v, want the revert of the call", err)
+				}
+				return
+			}
+			sent := ""
+			node.mu.Lock()
+			if len(node.types) > 0 {
+				sent = node.types[0]
+			}
+			node.mu.Unlock()
+			if sent != tt.sentType {
+				t.Errorf("estimated with the access list as type %s, want %s", sent, tt.sentType)
+			}
+			if choice.UseAccessList != tt.keep || choice.Gas != tt.gas || (choice.AccessListFailure != nil) != tt.failure {
+				t.Errorf("choice = %+v", choice)
+			}
+			if tt.keep {
+				if choice.CallMsg.AccessList == nil || len(*choice.CallMsg.AccessList) != 1 || (*choice.CallMsg.AccessList)[0].Address != pair {
+					t.Errorf("kept access list = %v", choice.CallMsg.AccessList)
+				}
+				if choice.Saving() != choice.GasWithout-choice.GasWith || choice.Saving() == 0 {
+					t.Errorf("Saving = %d", choice.Saving())
+				}
+			} else {
+				if choice.CallMsg.AccessList != nil {
+					t.Errorf("dropped choice carries the access list %v", choice.CallMsg.AccessList)
+				}
+				if choice.Saving() != 0 {
+					t.Errorf("Saving = %d without the access list", choice.Saving())
+				}
+			}
+		})
+	}
+}
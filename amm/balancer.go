@@ -0,0 +1,198 @@
+package amm
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BalancerKind is the family of a Balancer V2 pool
+type BalancerKind uint8
+
+const (
+	// KindWeighted pools price with WeightedMath and getNormalizedWeights
+	KindWeighted BalancerKind = iota
+	// KindComposableStable pools price with StableMath, their own BPT is one of the
+	// vault tokens and is left out of the invariant
+	KindComposableStable
+)
+
+func (k BalancerKind) String() string {
+	switch k {
+	case KindWeighted:
+		return "weighted"
+	case KindComposableStable:
+		return "composablestable"
+	}
+	return fmt.Sprintf("BalancerKind(%d)", uint8(k))
+}
+
+// BalancerPool is the state of a Balancer V2 pool, as the vault and the pool report it.
+// Swaps with the BPT of a composable stable pool are joins and exits, not supported.
+type BalancerPool struct {
+	Kind           BalancerKind
+	Address        common.Address
+	PoolID         common.Hash
+	Tokens         []common.Address
+	Balances       []*big.Int
+	ScalingFactors []*big.Int // 18 decimals fixed point, times the rate of rate provider tokens
+	SwapFee        *big.Int   // 18 decimals fixed point
+	Weights        []*big.Int // normalized weights of weighted pools
+	Amp            *big.Int   // amplification of stable pools in balancerAmpPrecision units
+	BptIndex       int        // index of the BPT in Tokens, -1 for weighted pools
+}
+
+// Clone returns a copy of p that can be updated independently
+func (p *BalancerPool) Clone() *BalancerPool {
+	clone := *p
+	clone.Balances = make([]*big.Int, len(p.Balances))
+	copy(clone.Balances, p.Balances)
+	return &clone
+}
+
+// Index returns the index of token in the vault tokens of the pool
+func (p *BalancerPool) Index(token common.Address) (int, error) {
+	for i, t := range p.Tokens {
+		if t == token {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrTokenNotInPool, token.Hex())
+}
+
+// indexes returns the indexes of a swap, checking the state is complete
+func (p *BalancerPool) indexes(tokenIn, tokenOut common.Address) (int, int, error) {
+	n := len(p.Tokens)
+	if len(p.Balances) != n || len(p.ScalingFactors) != n || (p.Kind == KindWeighted && len(p.Weights) != n) {
+		return 0, 0, fmt.Errorf("pool %s: %d tokens, %d balances, %d scaling factors and %d weights", p.Address.Hex(), n, len(p.Balances), len(p.ScalingFactors), len(p.Weights))
+	}
+	i, err := p.Index(tokenIn)
+	if err != nil {
+		return 0, 0, err
+	}
+	j, err := p.Index(tokenOut)
+	if err != nil {
+		return 0, 0, err
+	}
+	if i == j {
+		return 0, 0, fmt.Errorf("pool %s: swap of %s for itself", p.Address.Hex(), tokenIn.Hex())
+	}
+	if p.Kind == KindComposableStable && (i == p.BptIndex || j == p.BptIndex) {
+		return 0, 0, fmt.Errorf("pool %s: swaps with the BPT are not supported", p.Address.Hex())
+	}
+	return i, j, nil
+}
+
+// upscaled returns the balances in 18 decimals
+func (p *BalancerPool) upscaled() []*big.Int {
+	balances := make([]*big.Int, len(p.Balances))
+	for i, balance := range p.Balances {
+		balances[i] = mulDown(balance, p.ScalingFactors[i])
+	}
+	return balances
+}
+
+// AmountOut quotes swapping amountIn of tokenIn for tokenOut, as onSwap GIVEN_IN: the
+// fee is taken from amountIn before scaling
+func (p *BalancerPool) AmountOut(tokenIn, tokenOut common.Address, amountIn *big.Int) (*big.Int, error) {
+	if amountIn == nil || amountIn.Sign() <= 0 {
+		return nil, ErrInsufficientInputAmount
+	}
+	i, j, err := p.indexes(tokenIn, tokenOut)
+	if err != nil {
+		return nil, err
+	}
+	amount := new(big.Int).Sub(amountIn, mulUp(amountIn, p.SwapFee))
+	amount = mulDown(amount, p.ScalingFactors[i])
+	balances := p.upscaled()
+
+	var amountOut *big.Int
+	switch p.Kind {
+	case KindWeighted:
+		amountOut, err = weightedOutGivenIn(balances[i], p.Weights[i], balances[j], p.Weights[j], amount)
+	case KindComposableStable:
+		tokens, in, out := p.dropBpt(balances, i, j)
+		var invariant *big.Int
+		if invariant, err = stableInvariant(p.Amp, tokens); err == nil {
+			amountOut, err = stableOutGivenIn(p.Amp, tokens, in, out, amount, invariant)
+		}
+	default:
+		err = fmt.Errorf("unsupported pool kind %s", p.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return divDown(amountOut, p.ScalingFactors[j])
+}
+
+// AmountIn quotes the input of tokenIn needed to receive amountOut of tokenOut, as
+// onSwap GIVEN_OUT: the fee is added to the downscaled input
+func (p *BalancerPool) AmountIn(tokenIn, tokenOut common.Address, amountOut *big.Int) (*big.Int, error) {
+	if amountOut == nil || amountOut.Sign() <= 0 {
+		return nil, ErrInsufficientOutputAmount
+	}
+	i, j, err := p.indexes(tokenIn, tokenOut)
+	if err != nil {
+		return nil, err
+	}
+	amount := mulDown(amountOut, p.ScalingFactors[j])
+	balances := p.upscaled()
+
+	var amountIn *big.Int
+	switch p.Kind {
+	case KindWeighted:
+		amountIn, err = weightedInGivenOut(balances[i], p.Weights[i], balances[j], p.Weights[j], amount)
+	case KindComposableStable:
+		tokens, in, out := p.dropBpt(balances, i, j)
+		var invariant *big.Int
+		if invariant, err = stableInvariant(p.Amp, tokens); err == nil {
+			amountIn, err = stableInGivenOut(p.Amp, tokens, in, out, amount, invariant)
+		}
+	default:
+		err = fmt.Errorf("unsupported pool kind %s", p.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if amountIn, err = divUp(amountIn, p.ScalingFactors[i]); err != nil {
+		return nil, err
+	}
+	return divUp(amountIn, complement(p.SwapFee))
+}
+
+// dropBpt removes the BPT from balances and shifts the indexes past it
+func (p *BalancerPool) dropBpt(balances []*big.Int, i, j int) ([]*big.Int, int, int) {
+	if p.BptIndex < 0 || p.BptIndex >= len(balances) {
+		return balances, i, j
+	}
+	dropped := make([]*big.Int, 0, len(balances)-1)
+	dropped = append(dropped, balances[:p.BptIndex]...)
+	dropped = append(dropped, balances[p.BptIndex+1:]...)
+	if i > p.BptIndex {
+		i--
+	}
+	if j > p.BptIndex {
+		j--
+	}
+	return dropped, i, j
+}
+
+// ApplySwap updates the balances with a vault Swap of amountIn of tokenIn for amountOut
+// of tokenOut
+func (p *BalancerPool) ApplySwap(tokenIn, tokenOut common.Address, amountIn, amountOut *big.Int) error {
+	i, err := p.Index(tokenIn)
+	if err != nil {
+		return err
+	}
+	j, err := p.Index(tokenOut)
+	if err != nil {
+		return err
+	}
+	p.Balances[i] = new(big.Int).Add(p.Balances[i], amountIn)
+	p.Balances[j] = new(big.Int).Sub(p.Balances[j], amountOut)
+	if p.Balances[j].Sign() < 0 {
+		return fmt.Errorf("pool %s: %w", p.Address.Hex(), ErrInsufficientLiquidity)
+	}
+	return nil
+}
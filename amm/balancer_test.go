@@ -0,0 +1,109 @@
+package amm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	tokenBAL  = common.HexToAddress("0xba1")
+	tokenWETH = common.HexToAddress("0xc02a")
+	tokenUSDC = common.HexToAddress("0x05dc")
+	tokenDAI  = common.HexToAddress("0xda1")
+	tokenBPT  = common.HexToAddress("0xb9")
+)
+
+func TestBalancerQuotes(t *testing.T) {
+	scale6 := mustBig("1000000000000000000000000000000")
+	weighted8020 := &BalancerPool{
+		Kind:           KindWeighted,
+		Tokens:         []common.Address{tokenBAL, tokenWETH},
+		Balances:       []*big.Int{ether(10_000_000), ether(5_000)},
+		ScalingFactors: []*big.Int{fpOne, fpOne},
+		SwapFee:        big.NewInt(25e14),
+		Weights:        []*big.Int{big.NewInt(8e17), big.NewInt(2e17)},
+		BptIndex:       -1,
+	}
+	weighted5050 := &BalancerPool{
+		Kind:           KindWeighted,
+		Tokens:         []common.Address{tokenUSDC, tokenWETH},
+		Balances:       []*big.Int{big.NewInt(20_000_000e6), ether(10_000)},
+		ScalingFactors: []*big.Int{scale6, fpOne},
+		SwapFee:        big.NewInt(3e15),
+		Weights:        []*big.Int{big.NewInt(5e17), big.NewInt(5e17)},
+		BptIndex:       -1,
+	}
+	stable := &BalancerPool{
+		Kind:           KindComposableStable,
+		Tokens:         []common.Address{tokenBPT, tokenUSDC, tokenDAI},
+		Balances:       []*big.Int{new(big.Int).Lsh(common1, 111), big.NewInt(5_000_000e6), ether(4_000_000)},
+		ScalingFactors: []*big.Int{fpOne, scale6, fpOne},
+		SwapFee:        big.NewInt(1e14),
+		Amp:            big.NewInt(200 * 1000),
+		BptIndex:       0,
+	}
+
+	// expected from WeightedMath and StableMath with the onSwap scaling and fees
+	tests := []struct {
+		name     string
+		pool     *BalancerPool
+		tokenIn  common.Address
+		tokenOut common.Address
+		exactIn  bool
+		amount   *big.Int
+		want     string
+	}{
+		{"80/20 BAL to WETH", weighted8020, tokenBAL, tokenWETH, true, ether(1000), "1994502596109530000"},
+		{"80/20 WETH for 1000 BAL", weighted8020, tokenWETH, tokenBAL, false, ether(1000), "2005513884729358396"},
+		{"50/50 USDC to WETH", weighted5050, tokenUSDC, tokenWETH, true, big.NewInt(10_000e6), "4982516215666490000"},
+		{"50/50 USDC for 1 WETH", weighted5050, tokenUSDC, tokenWETH, false, ether(1), "2006218678"},
+		{"stable USDC to DAI", stable, tokenUSDC, tokenDAI, true, big.NewInt(100_000e6), "99864771206965292455364"},
+		{"stable USDC for 100k DAI", stable, tokenUSDC, tokenDAI, false, ether(100_000), "100135428362"},
+	}
+	for _, tt := range tests {
+		var got *big.Int
+		var err error
+		if tt.exactIn {
+			got, err = tt.pool.AmountOut(tt.tokenIn, tt.tokenOut, tt.amount)
+		} else {
+			got, err = tt.pool.AmountIn(tt.tokenIn, tt.tokenOut, tt.amount)
+		}
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got.String() != tt.want {
+			t.Errorf("%s = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := weighted8020.AmountOut(tokenBAL, tokenWETH, ether(4_000_000)); err == nil {
+		t.Error("a swap above the max in ratio was quoted")
+	}
+	if _, err := stable.AmountOut(tokenBPT, tokenDAI, ether(1)); err == nil {
+		t.Error("a swap of the BPT was quoted")
+	}
+}
+
+func TestLogExpPow(t *testing.T) {
+	// LogExpMath.pow is accurate to 1e-14 relative
+	tests := []struct {
+		x, y *big.Int
+		want string
+	}{
+		{fpTwo, big.NewInt(5e17), "1414213562373095048"},            // sqrt(2)
+		{big.NewInt(5e17), big.NewInt(25e16), "840896415253714543"}, // 0.5^0.25
+		{ether(10), fpTwo, "100000000000000000000"},
+	}
+	tolerance := big.NewInt(1e5)
+	for _, tt := range tests {
+		got, err := logExpPow(tt.x, tt.y)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := new(big.Int).Sub(got, mustBig(tt.want)); diff.CmpAbs(tolerance) > 0 {
+			t.Errorf("pow(%s, %s) = %s, want %s", tt.x, tt.y, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,429 @@
+package amm
+
+import (
+	"errors"
+	"math/big"
+)
+
+// Balancer V2 math: FixedPoint, LogExpMath, WeightedMath and StableMath with the
+// rounding of the pool contracts. Amounts are upscaled to 18 decimals.
+
+var (
+	ErrMaxInRatio            = errors.New("max in ratio")
+	ErrMaxOutRatio           = errors.New("max out ratio")
+	ErrExponentOutOfBounds   = errors.New("exponent out of bounds")
+	ErrBalancerZeroDivision  = errors.New("zero division")
+	ErrBalancerSubUnderflow  = errors.New("sub overflow")
+	errLogExpBaseOutOfBounds = errors.New("x out of bounds")
+)
+
+var (
+	fpOne   = big.NewInt(1e18)
+	fpTwo   = big.NewInt(2e18)
+	fpFour  = big.NewInt(4e18)
+	one20   = mustBig("100000000000000000000")
+	one36   = mustBig("1000000000000000000000000000000000000")
+	big100  = big.NewInt(100)
+	big1000 = big.NewInt(1000)
+
+	// WeightedMath._MAX_IN_RATIO and _MAX_OUT_RATIO
+	maxInRatio  = big.NewInt(3e17)
+	maxOutRatio = big.NewInt(3e17)
+	// FixedPoint.MAX_POW_RELATIVE_ERROR
+	maxPowRelativeError = big.NewInt(10000)
+	// StableMath._AMP_PRECISION
+	balancerAmpPrecision = big1000
+)
+
+func mulDown(a, b *big.Int) *big.Int {
+	product := new(big.Int).Mul(a, b)
+	return product.Quo(product, fpOne)
+}
+
+func mulUp(a, b *big.Int) *big.Int {
+	product := new(big.Int).Mul(a, b)
+	if product.Sign() == 0 {
+		return product
+	}
+	product.Sub(product, common1).Quo(product, fpOne)
+	return product.Add(product, common1)
+}
+
+func divDown(a, b *big.Int) (*big.Int, error) {
+	if b.Sign() == 0 {
+		return nil, ErrBalancerZeroDivision
+	}
+	inflated := new(big.Int).Mul(a, fpOne)
+	return inflated.Quo(inflated, b), nil
+}
+
+func divUp(a, b *big.Int) (*big.Int, error) {
+	if b.Sign() == 0 {
+		return nil, ErrBalancerZeroDivision
+	}
+	if a.Sign() == 0 {
+		return new(big.Int), nil
+	}
+	inflated := new(big.Int).Mul(a, fpOne)
+	inflated.Sub(inflated, common1).Quo(inflated, b)
+	return inflated.Add(inflated, common1), nil
+}
+
+// complement returns 1 - x, or 0 for x above 1
+func complement(x *big.Int) *big.Int {
+	if x.Cmp(fpOne) >= 0 {
+		return new(big.Int)
+	}
+	return new(big.Int).Sub(fpOne, x)
+}
+
+// powUp returns x^y rounded up, as FixedPoint.powUp
+func powUp(x, y *big.Int) (*big.Int, error) {
+	switch {
+	case y.Cmp(fpOne) == 0:
+		return new(big.Int).Set(x), nil
+	case y.Cmp(fpTwo) == 0:
+		return mulUp(x, x), nil
+	case y.Cmp(fpFour) == 0:
+		square := mulUp(x, x)
+		return mulUp(square, square), nil
+	}
+	raw, err := logExpPow(x, y)
+	if err != nil {
+		return nil, err
+	}
+	maxError := mulUp(raw, maxPowRelativeError)
+	maxError.Add(maxError, common1)
+	return raw.Add(raw, maxError), nil
+}
+
+// LogExpMath constants, x_n are 2^(7-n) and a_n are e^(x_n)
+var (
+	logExpX0 = mustBig("128000000000000000000")
+	logExpA0 = mustBig("38877084059945950922200000000000000000000000000000000000")
+	logExpX1 = mustBig("64000000000000000000")
+	logExpA1 = mustBig("6235149080811616882910000000")
+
+	// 20 decimals
+	logExpX = []*big.Int{
+		mustBig("3200000000000000000000"),
+		mustBig("1600000000000000000000"),
+		mustBig("800000000000000000000"),
+		mustBig("400000000000000000000"),
+		mustBig("200000000000000000000"),
+		mustBig("100000000000000000000"),
+		mustBig("50000000000000000000"),
+		mustBig("25000000000000000000"),
+		mustBig("12500000000000000000"),
+		mustBig("6250000000000000000"),
+	}
+	logExpA = []*big.Int{
+		mustBig("7896296018268069516100000000000000"),
+		mustBig("888611052050787263676000000"),
+		mustBig("298095798704172827474000"),
+		mustBig("5459815003314423907810"),
+		mustBig("738905609893065022723"),
+		mustBig("271828182845904523536"),
+		mustBig("164872127070012814685"),
+		mustBig("128402541668774148407"),
+		mustBig("113314845306682631683"),
+		mustBig("106449445891785942956"),
+	}
+
+	maxNaturalExponent = mustBig("130000000000000000000")
+	minNaturalExponent = mustBig("-41000000000000000000")
+	ln36LowerBound     = big.NewInt(1e18 - 1e17)
+	ln36UpperBound     = big.NewInt(1e18 + 1e17)
+	mildExponentBound  = new(big.Int).Quo(new(big.Int).Lsh(common1, 254), one20)
+	maxInt256          = new(big.Int).Sub(new(big.Int).Lsh(common1, 255), common1)
+)
+
+// logExpPow returns x^y in 18 decimals, as LogExpMath.pow
+func logExpPow(x, y *big.Int) (*big.Int, error) {
+	if y.Sign() == 0 {
+		return new(big.Int).Set(fpOne), nil
+	}
+	if x.Sign() == 0 {
+		return new(big.Int), nil
+	}
+	if x.Cmp(maxInt256) > 0 {
+		return nil, errLogExpBaseOutOfBounds
+	}
+	if y.Cmp(mildExponentBound) >= 0 {
+		return nil, ErrExponentOutOfBounds
+	}
+	var logxTimesY *big.Int
+	if x.Cmp(ln36LowerBound) > 0 && x.Cmp(ln36UpperBound) < 0 {
+		ln36x := ln36(x)
+		// ln36x has 36 decimals, split it to multiply by y with 18 decimals of precision
+		quo, rem := new(big.Int).QuoRem(ln36x, fpOne, new(big.Int))
+		logxTimesY = quo.Mul(quo, y)
+		rem.Mul(rem, y).Quo(rem, fpOne)
+		logxTimesY.Add(logxTimesY, rem)
+	} else {
+		logxTimesY = ln(x)
+		logxTimesY.Mul(logxTimesY, y)
+	}
+	logxTimesY.Quo(logxTimesY, fpOne)
+	if logxTimesY.Cmp(minNaturalExponent) < 0 || logxTimesY.Cmp(maxNaturalExponent) > 0 {
+		return nil, ErrExponentOutOfBounds
+	}
+	return exp(logxTimesY), nil
+}
+
+// exp returns e^x in 18 decimals, x within the natural exponent bounds
+func exp(x *big.Int) *big.Int {
+	if x.Sign() < 0 {
+		result := new(big.Int).Mul(fpOne, fpOne)
+		return result.Quo(result, exp(new(big.Int).Neg(x)))
+	}
+	x = new(big.Int).Set(x)
+	firstAN := common1
+	if x.Cmp(logExpX0) >= 0 {
+		x.Sub(x, logExpX0)
+		firstAN = logExpA0
+	} else if x.Cmp(logExpX1) >= 0 {
+		x.Sub(x, logExpX1)
+		firstAN = logExpA1
+	}
+	x.Mul(x, big100)
+
+	product := new(big.Int).Set(one20)
+	// only a2 to a9 are needed, x is below x1 once a0 or a1 is taken out
+	for n := 0; n < 8; n++ {
+		if x.Cmp(logExpX[n]) >= 0 {
+			x.Sub(x, logExpX[n])
+			product.Mul(product, logExpA[n]).Quo(product, one20)
+		}
+	}
+
+	// Taylor series up to the 12th term
+	seriesSum := new(big.Int).Set(one20)
+	term := new(big.Int).Set(x)
+	seriesSum.Add(seriesSum, term)
+	for n := int64(2); n <= 12; n++ {
+		term = new(big.Int).Mul(term, x)
+		term.Quo(term, one20).Quo(term, big.NewInt(n))
+		seriesSum.Add(seriesSum, term)
+	}
+	result := product.Mul(product, seriesSum)
+	result.Quo(result, one20).Mul(result, firstAN)
+	return result.Quo(result, big100)
+}
+
+// ln returns the natural logarithm of a in 18 decimals
+func ln(a *big.Int) *big.Int {
+	if a.Cmp(fpOne) < 0 {
+		inverse := new(big.Int).Mul(fpOne, fpOne)
+		inverse.Quo(inverse, a)
+		return new(big.Int).Neg(ln(inverse))
+	}
+	a = new(big.Int).Set(a)
+	sum := new(big.Int)
+	if a.Cmp(new(big.Int).Mul(logExpA0, fpOne)) >= 0 {
+		a.Quo(a, logExpA0)
+		sum.Add(sum, logExpX0)
+	}
+	if a.Cmp(new(big.Int).Mul(logExpA1, fpOne)) >= 0 {
+		a.Quo(a, logExpA1)
+		sum.Add(sum, logExpX1)
+	}
+	sum.Mul(sum, big100)
+	a.Mul(a, big100)
+	for n := range logExpA {
+		if a.Cmp(logExpA[n]) >= 0 {
+			a.Mul(a, one20).Quo(a, logExpA[n])
+			sum.Add(sum, logExpX[n])
+		}
+	}
+
+	// ln(a) = 2 * atanh((a - 1) / (a + 1)), series up to the 11th power
+	z := new(big.Int).Sub(a, one20)
+	z.Mul(z, one20).Quo(z, new(big.Int).Add(a, one20))
+	zSquared := new(big.Int).Mul(z, z)
+	zSquared.Quo(zSquared, one20)
+	num := new(big.Int).Set(z)
+	seriesSum := new(big.Int).Set(num)
+	for n := int64(3); n <= 11; n += 2 {
+		num.Mul(num, zSquared).Quo(num, one20)
+		seriesSum.Add(seriesSum, new(big.Int).Quo(num, big.NewInt(n)))
+	}
+	seriesSum.Lsh(seriesSum, 1)
+	sum.Add(sum, seriesSum)
+	return sum.Quo(sum, big100)
+}
+
+// ln36 returns the natural logarithm of x close to 1 in 36 decimals
+func ln36(x *big.Int) *big.Int {
+	x = new(big.Int).Mul(x, fpOne)
+	z := new(big.Int).Sub(x, one36)
+	z.Mul(z, one36).Quo(z, new(big.Int).Add(x, one36))
+	zSquared := new(big.Int).Mul(z, z)
+	zSquared.Quo(zSquared, one36)
+	num := new(big.Int).Set(z)
+	seriesSum := new(big.Int).Set(num)
+	for n := int64(3); n <= 15; n += 2 {
+		num.Mul(num, zSquared).Quo(num, one36)
+		seriesSum.Add(seriesSum, new(big.Int).Quo(num, big.NewInt(n)))
+	}
+	return seriesSum.Lsh(seriesSum, 1)
+}
+
+// weightedOutGivenIn is WeightedMath._calcOutGivenIn
+func weightedOutGivenIn(balanceIn, weightIn, balanceOut, weightOut, amountIn *big.Int) (*big.Int, error) {
+	if amountIn.Cmp(mulDown(balanceIn, maxInRatio)) > 0 {
+		return nil, ErrMaxInRatio
+	}
+	base, err := divUp(balanceIn, new(big.Int).Add(balanceIn, amountIn))
+	if err != nil {
+		return nil, err
+	}
+	exponent, err := divDown(weightIn, weightOut)
+	if err != nil {
+		return nil, err
+	}
+	power, err := powUp(base, exponent)
+	if err != nil {
+		return nil, err
+	}
+	return mulDown(balanceOut, complement(power)), nil
+}
+
+// weightedInGivenOut is WeightedMath._calcInGivenOut
+func weightedInGivenOut(balanceIn, weightIn, balanceOut, weightOut, amountOut *big.Int) (*big.Int, error) {
+	if amountOut.Cmp(mulDown(balanceOut, maxOutRatio)) > 0 {
+		return nil, ErrMaxOutRatio
+	}
+	base, err := divUp(balanceOut, new(big.Int).Sub(balanceOut, amountOut))
+	if err != nil {
+		return nil, err
+	}
+	exponent, err := divUp(weightOut, weightIn)
+	if err != nil {
+		return nil, err
+	}
+	power, err := powUp(base, exponent)
+	if err != nil {
+		return nil, err
+	}
+	ratio := power.Sub(power, fpOne)
+	if ratio.Sign() < 0 {
+		return nil, ErrBalancerSubUnderflow
+	}
+	return mulUp(balanceIn, ratio), nil
+}
+
+// mathDivUp is Math.divUp, on raw integers
+func mathDivUp(a, b *big.Int) *big.Int {
+	if a.Sign() == 0 {
+		return new(big.Int)
+	}
+	result := new(big.Int).Sub(a, common1)
+	result.Quo(result, b)
+	return result.Add(result, common1)
+}
+
+// stableInvariant is StableMath._calculateInvariant, amp in balancerAmpPrecision units
+func stableInvariant(amp *big.Int, balances []*big.Int) (*big.Int, error) {
+	n := big.NewInt(int64(len(balances)))
+	sum := new(big.Int)
+	for _, balance := range balances {
+		if balance.Sign() <= 0 {
+			return nil, ErrInsufficientLiquidity
+		}
+		sum.Add(sum, balance)
+	}
+	invariant := new(big.Int).Set(sum)
+	ampTimesTotal := new(big.Int).Mul(amp, n)
+	ampSum := new(big.Int).Mul(ampTimesTotal, sum)
+	ampSum.Quo(ampSum, balancerAmpPrecision)
+	ampMinusOne := new(big.Int).Sub(ampTimesTotal, balancerAmpPrecision)
+	nPlusOne := big.NewInt(int64(len(balances) + 1))
+	for i := 0; i < 255; i++ {
+		dP := new(big.Int).Set(invariant)
+		for _, balance := range balances {
+			dP.Mul(dP, invariant).Quo(dP, new(big.Int).Mul(balance, n))
+		}
+		prev := invariant
+		numerator := new(big.Int).Mul(dP, n)
+		numerator.Add(numerator, ampSum).Mul(numerator, invariant)
+		denominator := new(big.Int).Mul(ampMinusOne, invariant)
+		denominator.Quo(denominator, balancerAmpPrecision).Add(denominator, new(big.Int).Mul(nPlusOne, dP))
+		invariant = numerator.Quo(numerator, denominator)
+		if new(big.Int).Sub(invariant, prev).CmpAbs(common1) <= 0 {
+			return invariant, nil
+		}
+	}
+	return nil, ErrInvariantDidNotConverge
+}
+
+// stableBalance is StableMath._getTokenBalanceGivenInvariantAndAllOtherBalances
+func stableBalance(amp *big.Int, balances []*big.Int, invariant *big.Int, index int) (*big.Int, error) {
+	n := big.NewInt(int64(len(balances)))
+	ampTimesTotal := new(big.Int).Mul(amp, n)
+	sum := new(big.Int).Set(balances[0])
+	pD := new(big.Int).Mul(balances[0], n)
+	for j := 1; j < len(balances); j++ {
+		pD.Mul(pD, balances[j]).Mul(pD, n).Quo(pD, invariant)
+		sum.Add(sum, balances[j])
+	}
+	sum.Sub(sum, balances[index])
+	inv2 := new(big.Int).Mul(invariant, invariant)
+	c := mathDivUp(inv2, new(big.Int).Mul(ampTimesTotal, pD))
+	c.Mul(c, balancerAmpPrecision).Mul(c, balances[index])
+	b := new(big.Int).Quo(invariant, ampTimesTotal)
+	b.Mul(b, balancerAmpPrecision).Add(b, sum)
+
+	balance := mathDivUp(new(big.Int).Add(inv2, c), new(big.Int).Add(invariant, b))
+	for i := 0; i < 255; i++ {
+		prev := balance
+		numerator := new(big.Int).Mul(balance, balance)
+		numerator.Add(numerator, c)
+		denominator := new(big.Int).Lsh(balance, 1)
+		denominator.Add(denominator, b).Sub(denominator, invariant)
+		if denominator.Sign() <= 0 {
+			return nil, ErrInvariantDidNotConverge
+		}
+		balance = mathDivUp(numerator, denominator)
+		if new(big.Int).Sub(balance, prev).CmpAbs(common1) <= 0 {
+			return balance, nil
+		}
+	}
+	return nil, ErrInvariantDidNotConverge
+}
+
+// stableOutGivenIn is StableMath._calcOutGivenIn
+func stableOutGivenIn(amp *big.Int, balances []*big.Int, indexIn, indexOut int, amountIn, invariant *big.Int) (*big.Int, error) {
+	updated := make([]*big.Int, len(balances))
+	copy(updated, balances)
+	updated[indexIn] = new(big.Int).Add(balances[indexIn], amountIn)
+	finalBalanceOut, err := stableBalance(amp, updated, invariant, indexOut)
+	if err != nil {
+		return nil, err
+	}
+	amountOut := new(big.Int).Sub(balances[indexOut], finalBalanceOut)
+	amountOut.Sub(amountOut, common1)
+	if amountOut.Sign() < 0 {
+		return nil, ErrBalancerSubUnderflow
+	}
+	return amountOut, nil
+}
+
+// stableInGivenOut is StableMath._calcInGivenOut
+func stableInGivenOut(amp *big.Int, balances []*big.Int, indexIn, indexOut int, amountOut, invariant *big.Int) (*big.Int, error) {
+	if amountOut.Cmp(balances[indexOut]) >= 0 {
+		return nil, ErrInsufficientLiquidity
+	}
+	updated := make([]*big.Int, len(balances))
+	copy(updated, balances)
+	updated[indexOut] = new(big.Int).Sub(balances[indexOut], amountOut)
+	finalBalanceIn, err := stableBalance(amp, updated, invariant, indexIn)
+	if err != nil {
+		return nil, err
+	}
+	amountIn := new(big.Int).Sub(finalBalanceIn, balances[indexIn])
+	if amountIn.Sign() < 0 {
+		return nil, ErrBalancerSubUnderflow
+	}
+	return amountIn.Add(amountIn, common1), nil
+}
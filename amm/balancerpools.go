@@ -0,0 +1,162 @@
+package amm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	wsClient "github.com/0xKhennati/wsclient"
+	"github.com/0xKhennati/wsclient/helper"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BalancerVault is the Balancer V2 vault, at the same address on every chain
+var BalancerVault = common.HexToAddress("0xBA12222222228d8Ba445958a75a0704d566BF2C8")
+
+// SetBalancer adds or replaces a Balancer pool, the cache keeps pool
+func (p *Pools) SetBalancer(pool *BalancerPool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.balancerPools[pool.Address] = pool
+}
+
+// BalancerPool returns a copy of the cached Balancer pool at address
+func (p *Pools) BalancerPool(address common.Address) (*BalancerPool, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	pool, ok := p.balancerPools[address]
+	if !ok {
+		return nil, false
+	}
+	return pool.Clone(), true
+}
+
+// updateBalancer applies update to a cached Balancer pool, a pool the update fails on
+// is dropped since its state is no longer known
+func (p *Pools) updateBalancer(address common.Address, update func(*BalancerPool) error) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pool, ok := p.balancerPools[address]
+	if !ok {
+		return false
+	}
+	pool = pool.Clone()
+	if err := update(pool); err != nil {
+		delete(p.balancerPools, address)
+		return false
+	}
+	p.balancerPools[address] = pool
+	return true
+}
+
+// PrimeBalancer reads the state of the Balancer pools of pairs at block and caches them:
+// the pool id, swap fee, scaling factors and weights or amplification from the pools,
+// then the tokens and balances from the vault. The pair address is the pool address.
+func (p *Pools) PrimeBalancer(ctx context.Context, c *wsClient.Client, block wsClient.BlockRef, kind BalancerKind, pairs ...helper.PairInfoInterface) error {
+	addresses := uniquePairs(pairs)
+	if len(addresses) == 0 {
+		return nil
+	}
+	packers := []func() (hexutil.Bytes, error){helper.Pack_balancer_getPoolId, helper.Pack_balancer_getSwapFeePercentage, helper.Pack_balancer_getScalingFactors}
+	switch kind {
+	case KindWeighted:
+		packers = append(packers, helper.Pack_balancer_getNormalizedWeights)
+	case KindComposableStable:
+		packers = append(packers, helper.Pack_balancer_getAmplificationParameter, helper.Pack_balancer_getBptIndex)
+	default:
+		return fmt.Errorf("unsupported pool kind %s", kind)
+	}
+	var calls []call
+	for _, address := range addresses {
+		for _, pack := range packers {
+			data, err := pack()
+			if err != nil {
+				return err
+			}
+			calls = append(calls, call{address, data})
+		}
+	}
+	results, err := callBatch(ctx, c, block, calls)
+	if err != nil {
+		return err
+	}
+
+	pools := make([]*BalancerPool, len(addresses))
+	calls = calls[:0]
+	for i, address := range addresses {
+		pool := &BalancerPool{Kind: kind, Address: address, BptIndex: -1}
+		if err := pool.decodeParameters(results[i*len(packers) : (i+1)*len(packers)]); err != nil {
+			return fmt.Errorf("failed to get the parameters of %s: %w", address.Hex(), err)
+		}
+		data, err := helper.Pack_balancer_getPoolTokens(pool.PoolID)
+		if err != nil {
+			return err
+		}
+		calls = append(calls, call{BalancerVault, data})
+		pools[i] = pool
+	}
+	if results, err = callBatch(ctx, c, block, calls); err != nil {
+		return err
+	}
+
+	for i, result := range results {
+		pool := pools[i]
+		if result.err != nil {
+			return fmt.Errorf("failed to get the tokens of %s: %w", pool.Address.Hex(), result.err)
+		}
+		tokens, err := helper.Unpack_balancer_getPoolTokens(result.data)
+		if err != nil {
+			return fmt.Errorf("failed to get the tokens of %s: %w", pool.Address.Hex(), err)
+		}
+		pool.Tokens, pool.Balances = tokens.Tokens, tokens.Balances
+		if len(pool.Tokens) != len(pool.ScalingFactors) {
+			return fmt.Errorf("pool %s: %d tokens and %d scaling factors", pool.Address.Hex(), len(pool.Tokens), len(pool.ScalingFactors))
+		}
+	}
+	for _, pool := range pools {
+		p.SetBalancer(pool)
+	}
+	return nil
+}
+
+// decodeParameters sets the parameters from the results of the pool calls of
+// PrimeBalancer
+func (p *BalancerPool) decodeParameters(results []callResult) error {
+	for _, result := range results {
+		if result.err != nil {
+			return result.err
+		}
+	}
+	poolID, err := helper.Unpack_balancer_getPoolId(results[0].data)
+	if err != nil {
+		return err
+	}
+	if p.SwapFee, err = helper.Unpack_balancer_getSwapFeePercentage(results[1].data); err != nil {
+		return err
+	}
+	if p.ScalingFactors, err = helper.Unpack_balancer_getScalingFactors(results[2].data); err != nil {
+		return err
+	}
+	p.PoolID = common.Hash(poolID)
+
+	if p.Kind == KindWeighted {
+		p.Weights, err = helper.Unpack_balancer_getNormalizedWeights(results[3].data)
+		return err
+	}
+	amp, err := helper.Unpack_balancer_getAmplificationParameter(results[3].data)
+	if err != nil {
+		return err
+	}
+	if amp.Precision.Sign() == 0 {
+		return fmt.Errorf("amplification precision is zero")
+	}
+	p.Amp = new(big.Int).Mul(amp.Value, balancerAmpPrecision)
+	p.Amp.Quo(p.Amp, amp.Precision)
+	bptIndex, err := helper.Unpack_balancer_getBptIndex(results[4].data)
+	if err != nil {
+		return err
+	}
+	p.BptIndex = int(bptIndex.Int64())
+	return nil
+}
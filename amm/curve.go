@@ -0,0 +1,335 @@
+package amm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CurveFeeDenominator is the unit of Curve fees, 4000000 is 0.04%
+const CurveFeeDenominator = 10_000_000_000
+
+// curveAPrecision is the A_PRECISION of StableSwap pools with A_precise
+const curveAPrecision = 100
+
+// ErrInvariantDidNotConverge is returned when the Newton iterations of a stable invariant
+// do not converge, as the pools revert
+var ErrInvariantDidNotConverge = errors.New("invariant did not converge")
+
+var (
+	bigCurveFeeDenominator = big.NewInt(CurveFeeDenominator)
+	bigCurveAPrecision     = big.NewInt(curveAPrecision)
+	curvePrecision         = big.NewInt(1e18)
+)
+
+// CurvePool is the state of a Curve StableSwap plain pool. A is ramped linearly from
+// InitialA at InitialATime to FutureA at FutureATime, as the pool's _A().
+type CurvePool struct {
+	Address      common.Address
+	Coins        []common.Address
+	Balances     []*big.Int
+	Rates        []*big.Int // 10^(36-decimals), the pool's RATES
+	Fee          *big.Int   // in CurveFeeDenominator units
+	AdminFee     *big.Int   // share of Fee kept out of the balances, in CurveFeeDenominator units
+	InitialA     *big.Int   // in APrecision units
+	FutureA      *big.Int
+	InitialATime uint64
+	FutureATime  uint64
+	APrecision   int64 // 100 for pools with A_precise, 1 for the older ones
+}
+
+// Clone returns a copy of p that can be updated independently
+func (p *CurvePool) Clone() *CurvePool {
+	clone := *p
+	clone.Balances = make([]*big.Int, len(p.Balances))
+	copy(clone.Balances, p.Balances)
+	return &clone
+}
+
+// Index returns the index of token in the pool's coins
+func (p *CurvePool) Index(token common.Address) (int, error) {
+	for i, coin := range p.Coins {
+		if coin == token {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrTokenNotInPool, token.Hex())
+}
+
+// A returns the amplification at timestamp in curveAPrecision units, like A_precise()
+func (p *CurvePool) A(timestamp uint64) *big.Int {
+	a := new(big.Int).Set(p.FutureA)
+	if timestamp < p.FutureATime && p.FutureATime > p.InitialATime {
+		elapsed := big.NewInt(0)
+		if timestamp > p.InitialATime {
+			elapsed.SetUint64(timestamp - p.InitialATime)
+		}
+		duration := new(big.Int).SetUint64(p.FutureATime - p.InitialATime)
+		delta := new(big.Int).Sub(p.FutureA, p.InitialA)
+		// Vyper rounds the unsigned ramp towards zero both ways
+		delta.Mul(delta.Abs(delta), elapsed).Quo(delta, duration)
+		if p.FutureA.Cmp(p.InitialA) > 0 {
+			a.Add(p.InitialA, delta)
+		} else {
+			a.Sub(p.InitialA, delta)
+		}
+	}
+	if p.APrecision > 0 && p.APrecision < curveAPrecision {
+		a.Mul(a, big.NewInt(curveAPrecision/p.APrecision))
+	}
+	return a
+}
+
+// xp returns the balances in 18 decimals
+func (p *CurvePool) xp() ([]*big.Int, error) {
+	if len(p.Balances) != len(p.Coins) || len(p.Rates) != len(p.Coins) {
+		return nil, fmt.Errorf("pool %s: %d coins, %d balances and %d rates", p.Address.Hex(), len(p.Coins), len(p.Balances), len(p.Rates))
+	}
+	xp := make([]*big.Int, len(p.Balances))
+	for i, balance := range p.Balances {
+		if balance == nil || balance.Sign() <= 0 {
+			return nil, ErrInsufficientLiquidity
+		}
+		xp[i] = new(big.Int).Mul(balance, p.Rates[i])
+		xp[i].Quo(xp[i], curvePrecision)
+	}
+	return xp, nil
+}
+
+// curveD returns the StableSwap invariant of xp, as get_D
+func curveD(xp []*big.Int, amp *big.Int) (*big.Int, error) {
+	n := big.NewInt(int64(len(xp)))
+	s := new(big.Int)
+	for _, x := range xp {
+		s.Add(s, x)
+	}
+	if s.Sign() == 0 {
+		return s, nil
+	}
+	d := new(big.Int).Set(s)
+	ann := new(big.Int).Mul(amp, n)
+	annS := new(big.Int).Mul(ann, s)
+	annS.Quo(annS, bigCurveAPrecision)
+	annMinusOne := new(big.Int).Sub(ann, bigCurveAPrecision)
+	nPlusOne := big.NewInt(int64(len(xp) + 1))
+	for i := 0; i < 255; i++ {
+		dP := new(big.Int).Set(d)
+		for _, x := range xp {
+			dP.Mul(dP, d).Quo(dP, new(big.Int).Mul(x, n))
+		}
+		prev := d
+		numerator := new(big.Int).Mul(dP, n)
+		numerator.Add(numerator, annS).Mul(numerator, d)
+		denominator := new(big.Int).Mul(annMinusOne, d)
+		denominator.Quo(denominator, bigCurveAPrecision).Add(denominator, new(big.Int).Mul(nPlusOne, dP))
+		d = numerator.Quo(numerator, denominator)
+		if new(big.Int).Sub(d, prev).CmpAbs(common.Big1) <= 0 {
+			return d, nil
+		}
+	}
+	return nil, ErrInvariantDidNotConverge
+}
+
+// curveY returns the balance of coin j once coin i has balance x, keeping the invariant
+// d, as get_y
+func curveY(i, j int, x *big.Int, xp []*big.Int, amp, d *big.Int) (*big.Int, error) {
+	n := big.NewInt(int64(len(xp)))
+	ann := new(big.Int).Mul(amp, n)
+	c := new(big.Int).Set(d)
+	s := new(big.Int)
+	for k := range xp {
+		var xk *big.Int
+		switch k {
+		case i:
+			xk = x
+		case j:
+			continue
+		default:
+			xk = xp[k]
+		}
+		s.Add(s, xk)
+		c.Mul(c, d).Quo(c, new(big.Int).Mul(xk, n))
+	}
+	c.Mul(c, d).Mul(c, bigCurveAPrecision).Quo(c, new(big.Int).Mul(ann, n))
+	b := new(big.Int).Mul(d, bigCurveAPrecision)
+	b.Quo(b, ann).Add(b, s)
+	y := new(big.Int).Set(d)
+	for k := 0; k < 255; k++ {
+		prev := y
+		numerator := new(big.Int).Mul(y, y)
+		numerator.Add(numerator, c)
+		denominator := new(big.Int).Lsh(y, 1)
+		denominator.Add(denominator, b).Sub(denominator, d)
+		if denominator.Sign() <= 0 {
+			return nil, ErrInvariantDidNotConverge
+		}
+		y = numerator.Quo(numerator, denominator)
+		if new(big.Int).Sub(y, prev).CmpAbs(common.Big1) <= 0 {
+			return y, nil
+		}
+	}
+	return nil, ErrInvariantDidNotConverge
+}
+
+// exchange returns the output of swapping dx of coin i for coin j and the admin fee taken
+// out of the balance of j, as exchange
+func (p *CurvePool) exchange(i, j int, dx *big.Int, timestamp uint64) (*big.Int, *big.Int, error) {
+	if dx == nil || dx.Sign() <= 0 {
+		return nil, nil, ErrInsufficientInputAmount
+	}
+	if i == j || i < 0 || j < 0 || i >= len(p.Coins) || j >= len(p.Coins) {
+		return nil, nil, fmt.Errorf("pool %s: invalid coins %d and %d", p.Address.Hex(), i, j)
+	}
+	xp, err := p.xp()
+	if err != nil {
+		return nil, nil, err
+	}
+	amp := p.A(timestamp)
+	d, err := curveD(xp, amp)
+	if err != nil {
+		return nil, nil, err
+	}
+	x := new(big.Int).Mul(dx, p.Rates[i])
+	x.Quo(x, curvePrecision).Add(x, xp[i])
+	y, err := curveY(i, j, x, xp, amp, d)
+	if err != nil {
+		return nil, nil, err
+	}
+	dy := new(big.Int).Sub(xp[j], y)
+	dy.Sub(dy, common.Big1)
+	if dy.Sign() <= 0 {
+		return nil, nil, ErrInsufficientOutputAmount
+	}
+	dyFee := new(big.Int).Mul(dy, p.Fee)
+	dyFee.Quo(dyFee, bigCurveFeeDenominator)
+	dy.Sub(dy, dyFee).Mul(dy, curvePrecision).Quo(dy, p.Rates[j])
+	adminFee := new(big.Int).Mul(dyFee, p.AdminFee)
+	adminFee.Quo(adminFee, bigCurveFeeDenominator).Mul(adminFee, curvePrecision).Quo(adminFee, p.Rates[j])
+	return dy, adminFee, nil
+}
+
+// GetDy returns the output of swapping dx of coin i for coin j at timestamp, what
+// exchange sends; the get_dy of older pools like 3pool may round one unit apart
+func (p *CurvePool) GetDy(i, j int, dx *big.Int, timestamp uint64) (*big.Int, error) {
+	dy, _, err := p.exchange(i, j, dx, timestamp)
+	return dy, err
+}
+
+// GetDx returns the smallest input of coin i for which GetDy is at least dy
+func (p *CurvePool) GetDx(i, j int, dy *big.Int, timestamp uint64) (*big.Int, error) {
+	if dy == nil || dy.Sign() <= 0 {
+		return nil, ErrInsufficientOutputAmount
+	}
+	if i == j || i < 0 || j < 0 || i >= len(p.Coins) || j >= len(p.Coins) {
+		return nil, fmt.Errorf("pool %s: invalid coins %d and %d", p.Address.Hex(), i, j)
+	}
+	xp, err := p.xp()
+	if err != nil {
+		return nil, err
+	}
+	amp := p.A(timestamp)
+	d, err := curveD(xp, amp)
+	if err != nil {
+		return nil, err
+	}
+	// invert exchange rounding up, then settle the last units with GetDy
+	net := new(big.Int).Mul(dy, p.Rates[j])
+	net = divRoundingUp(net, curvePrecision)
+	gross := new(big.Int).Mul(net, bigCurveFeeDenominator)
+	gross = divRoundingUp(gross, new(big.Int).Sub(bigCurveFeeDenominator, p.Fee))
+	y := new(big.Int).Sub(xp[j], gross)
+	y.Sub(y, common.Big1)
+	if y.Sign() <= 0 {
+		return nil, ErrInsufficientLiquidity
+	}
+	x, err := curveY(j, i, y, xp, amp, d)
+	if err != nil {
+		return nil, err
+	}
+	dx := x.Sub(x, xp[i])
+	if dx.Sign() <= 0 {
+		dx.SetInt64(1)
+	}
+	dx.Mul(dx, curvePrecision)
+	dx = divRoundingUp(dx, p.Rates[i])
+	buys := func(dx *big.Int) (bool, error) {
+		out, err := p.GetDy(i, j, dx, timestamp)
+		if err != nil && !errors.Is(err, ErrInsufficientOutputAmount) {
+			return false, err
+		}
+		return err == nil && out.Cmp(dy) >= 0, nil
+	}
+	for k := 0; k < 32; k++ {
+		ok, err := buys(dx)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			dx.Add(dx, common.Big1)
+			continue
+		}
+		// the estimate may be a few units above the smallest input
+		for k := 0; k < 32 && dx.Cmp(common.Big1) > 0; k++ {
+			smaller := new(big.Int).Sub(dx, common.Big1)
+			if ok, err := buys(smaller); err != nil || !ok {
+				break
+			}
+			dx = smaller
+		}
+		return dx, nil
+	}
+	return nil, ErrInsufficientLiquidity
+}
+
+// AmountOut quotes swapping amountIn of tokenIn for tokenOut at timestamp
+func (p *CurvePool) AmountOut(tokenIn, tokenOut common.Address, amountIn *big.Int, timestamp uint64) (*big.Int, error) {
+	i, j, err := p.indexes(tokenIn, tokenOut)
+	if err != nil {
+		return nil, err
+	}
+	return p.GetDy(i, j, amountIn, timestamp)
+}
+
+// AmountIn quotes the input of tokenIn needed to receive amountOut of tokenOut at timestamp
+func (p *CurvePool) AmountIn(tokenIn, tokenOut common.Address, amountOut *big.Int, timestamp uint64) (*big.Int, error) {
+	i, j, err := p.indexes(tokenIn, tokenOut)
+	if err != nil {
+		return nil, err
+	}
+	return p.GetDx(i, j, amountOut, timestamp)
+}
+
+func (p *CurvePool) indexes(tokenIn, tokenOut common.Address) (int, int, error) {
+	i, err := p.Index(tokenIn)
+	if err != nil {
+		return 0, 0, err
+	}
+	j, err := p.Index(tokenOut)
+	if err != nil {
+		return 0, 0, err
+	}
+	return i, j, nil
+}
+
+// ApplyExchange updates the balances with a TokenExchange of dx of coin i for dy of coin
+// j in the block at timestamp. The balances move by the logged amounts; the admin fee,
+// which the event does not log, is computed from the state before the exchange with the
+// amplification at timestamp.
+func (p *CurvePool) ApplyExchange(i, j int, dx, dy *big.Int, timestamp uint64) error {
+	if dy == nil || dy.Sign() < 0 {
+		return ErrInsufficientOutputAmount
+	}
+	_, adminFee, err := p.exchange(i, j, dx, timestamp)
+	if err != nil {
+		return err
+	}
+	balance := new(big.Int).Sub(p.Balances[j], dy)
+	if balance.Sub(balance, adminFee).Sign() <= 0 {
+		return fmt.Errorf("pool %s: exchange sent %s of coin %d, more than its balance %s", p.Address.Hex(), dy, j, p.Balances[j])
+	}
+	p.Balances[i] = new(big.Int).Add(p.Balances[i], dx)
+	p.Balances[j] = balance
+	return nil
+}
@@ -0,0 +1,137 @@
+package amm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// rampStart is the start of the amplification ramp of testCurvePool
+const rampStart = 1_700_000_000
+
+// testCurvePool is a DAI/USDC/USDT StableSwap pool with A ramping from 1000 to 2000 over a
+// day, 0.04% fee and 50% admin fee
+func testCurvePool() *CurvePool {
+	return &CurvePool{
+		Address:      common.HexToAddress("0xbEbc44782C7dB0a1A60Cb6fe97d0b483032FF1C7"),
+		Coins:        []common.Address{common.HexToAddress("0xda1"), common.HexToAddress("0x05dc"), common.HexToAddress("0x05d7")},
+		Balances:     []*big.Int{ether(100_000_000), big.NewInt(90_000_000e6), big.NewInt(110_000_000e6)},
+		Rates:        []*big.Int{big.NewInt(1e18), mustBig("1000000000000000000000000000000"), mustBig("1000000000000000000000000000000")},
+		Fee:          big.NewInt(4_000_000),
+		AdminFee:     big.NewInt(5_000_000_000),
+		InitialA:     big.NewInt(1000 * curveAPrecision),
+		FutureA:      big.NewInt(2000 * curveAPrecision),
+		InitialATime: rampStart,
+		FutureATime:  rampStart + 86400,
+		APrecision:   curveAPrecision,
+	}
+}
+
+func TestCurveA(t *testing.T) {
+	pool := testCurvePool()
+	for timestamp, want := range map[uint64]int64{
+		rampStart - 1:     1000 * curveAPrecision,
+		rampStart:         1000 * curveAPrecision,
+		rampStart + 43200: 1500 * curveAPrecision,
+		rampStart + 86400: 2000 * curveAPrecision,
+		rampStart + 90000: 2000 * curveAPrecision,
+	} {
+		if got := pool.A(timestamp); got.Int64() != want {
+			t.Errorf("A(%d) = %s, want %d", timestamp, got, want)
+		}
+	}
+
+	// pools without A_precise store A unscaled
+	pool.InitialA, pool.FutureA, pool.APrecision = big.NewInt(100), big.NewInt(200), 1
+	if got := pool.A(rampStart + 43200); got.Int64() != 150*curveAPrecision {
+		t.Errorf("A of a pool without A_precise = %s", got)
+	}
+}
+
+func TestCurveExchange(t *testing.T) {
+	// expected from the StableSwap get_D, get_y and exchange
+	tests := []struct {
+		name         string
+		i, j         int
+		dx           *big.Int
+		timestamp    uint64
+		wantDy       string
+		wantAdminFee string
+	}{
+		{"dai to usdc", 0, 1, ether(1_000_000), rampStart + 86400, "999538256284", "199987646"},
+		{"dai to usdc mid ramp", 0, 1, ether(1_000_000), rampStart + 43200, "999517690810", "199983531"},
+		{"usdt to dai", 2, 0, big.NewInt(50_000e6), rampStart + 86400, "49977694835347903150503", "9999538782582613675"},
+		{"one usdc to usdt", 1, 2, big.NewInt(1e6), rampStart + 86400, "999701", "200"},
+	}
+	pool := testCurvePool()
+	for _, tt := range tests {
+		dy, adminFee, err := pool.exchange(tt.i, tt.j, tt.dx, tt.timestamp)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if dy.String() != tt.wantDy || adminFee.String() != tt.wantAdminFee {
+			t.Errorf("%s = %s, admin fee %s, want %s, %s", tt.name, dy, adminFee, tt.wantDy, tt.wantAdminFee)
+		}
+
+		// GetDx returns the smallest input buying dy
+		dx, err := pool.GetDx(tt.i, tt.j, dy, tt.timestamp)
+		if err != nil {
+			t.Fatalf("%s: GetDx: %v", tt.name, err)
+		}
+		if dx.Cmp(tt.dx) > 0 {
+			t.Errorf("%s: GetDx = %s, more than %s", tt.name, dx, tt.dx)
+		}
+		if out, err := pool.GetDy(tt.i, tt.j, dx, tt.timestamp); err != nil || out.Cmp(dy) < 0 {
+			t.Errorf("%s: GetDy(GetDx) = %v, %v, want at least %s", tt.name, out, err, dy)
+		}
+	}
+}
+
+// tokenExchangeLog is a TokenExchange of dx of coin i for dy of coin j
+func tokenExchangeLog(pool common.Address, i, j int64, dx, dy *big.Int) *types.Log {
+	return &types.Log{
+		Address: pool,
+		Topics:  []common.Hash{crypto.Keccak256Hash([]byte("TokenExchange(address,int128,uint256,int128,uint256)")), {}},
+		Data:    words(big.NewInt(i), dx, big.NewInt(j), dy),
+	}
+}
+
+func TestCurveApplyExchange(t *testing.T) {
+	// a DAI to USDC exchange mined mid ramp, quoted now the ramp is over it sends more
+	dx, dy := ether(1_000_000), big.NewInt(999517690810)
+	log := tokenExchangeLog(testCurvePool().Address, 0, 1, dx, dy)
+
+	tests := []struct {
+		name         string
+		apply        func(*Pools) int
+		wantAdminFee int64
+	}{
+		{"at the block time", func(p *Pools) int { return p.ApplyLogsAt([]*types.Log{log}, rampStart+43200) }, 199983531},
+		{"at the quote time", func(p *Pools) int { p.SetTimestamp(rampStart + 86400); return p.ApplyLogs([]*types.Log{log}) }, 199987646},
+	}
+	for _, tt := range tests {
+		pools := NewPools()
+		pools.SetCurve(testCurvePool())
+		if updated := tt.apply(pools); updated != 1 {
+			t.Fatalf("%s: updated %d pools, want 1", tt.name, updated)
+		}
+		pool, ok := pools.CurvePool(testCurvePool().Address)
+		if !ok {
+			t.Fatalf("%s: the pool was dropped", tt.name)
+		}
+		// the balances move by the logged amounts and the admin fee
+		wantUSDC := big.NewInt(90_000_000e6 - 999517690810 - tt.wantAdminFee)
+		if pool.Balances[0].Cmp(ether(101_000_000)) != 0 || pool.Balances[1].Cmp(wantUSDC) != 0 || pool.Balances[2].Cmp(big.NewInt(110_000_000e6)) != 0 {
+			t.Errorf("%s: balances = %v, want USDC %s", tt.name, pool.Balances, wantUSDC)
+		}
+	}
+
+	// more than the balance means the cached state is wrong
+	pool := testCurvePool()
+	if err := pool.ApplyExchange(0, 1, dx, big.NewInt(90_000_000e6), rampStart); err == nil {
+		t.Error("an exchange emptying the pool was applied")
+	}
+}
@@ -0,0 +1,224 @@
+package amm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	wsClient "github.com/0xKhennati/wsclient"
+	"github.com/0xKhennati/wsclient/helper"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// curveMaxCoins is the most coins a StableSwap pool holds
+const curveMaxCoins = 8
+
+// curveETH is the coin Curve pools use for native ETH
+var curveETH = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+
+// SetCurve adds or replaces a Curve pool, the cache keeps pool
+func (p *Pools) SetCurve(pool *CurvePool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.curvePools[pool.Address] = pool
+}
+
+// CurvePool returns a copy of the cached Curve pool at address
+func (p *Pools) CurvePool(address common.Address) (*CurvePool, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	pool, ok := p.curvePools[address]
+	if !ok {
+		return nil, false
+	}
+	return pool.Clone(), true
+}
+
+// updateCurve applies update to a cached Curve pool at timestamp, 0 for the quote time; a
+// pool the update fails on is dropped since its state is no longer known
+func (p *Pools) updateCurve(address common.Address, timestamp uint64, update func(*CurvePool, uint64) error) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pool, ok := p.curvePools[address]
+	if !ok {
+		return false
+	}
+	if timestamp == 0 {
+		timestamp = p.now()
+	}
+	pool = pool.Clone()
+	if err := update(pool, timestamp); err != nil {
+		delete(p.curvePools, address)
+		return false
+	}
+	p.curvePools[address] = pool
+	return true
+}
+
+// PrimeCurve reads the state of the Curve StableSwap plain pools of pairs at block and
+// caches them: coins, balances, fees, the amplification ramp and the decimals of the coins.
+// Pools with uint256 coin indexes are supported, i.e. not the first Vyper pools; metapools
+// and lending pools, whose rates are not fixed, are not.
+func (p *Pools) PrimeCurve(ctx context.Context, c *wsClient.Client, block wsClient.BlockRef, pairs ...helper.PairInfoInterface) error {
+	addresses := uniquePairs(pairs)
+	if len(addresses) == 0 {
+		return nil
+	}
+	packers := []func() (hexutil.Bytes, error){
+		helper.Pack_curve_fee, helper.Pack_curve_admin_fee, helper.Pack_curve_A, helper.Pack_curve_A_precise,
+		helper.Pack_curve_initial_A, helper.Pack_curve_future_A, helper.Pack_curve_initial_A_time, helper.Pack_curve_future_A_time,
+	}
+	perPool := len(packers) + 2*curveMaxCoins
+	var calls []call
+	for _, address := range addresses {
+		for _, pack := range packers {
+			data, err := pack()
+			if err != nil {
+				return err
+			}
+			calls = append(calls, call{address, data})
+		}
+		for i := 0; i < curveMaxCoins; i++ {
+			coin, err := helper.Pack_curve_coins(big.NewInt(int64(i)))
+			if err != nil {
+				return err
+			}
+			balance, err := helper.Pack_curve_balances(big.NewInt(int64(i)))
+			if err != nil {
+				return err
+			}
+			calls = append(calls, call{address, coin}, call{address, balance})
+		}
+	}
+	results, err := callBatch(ctx, c, block, calls)
+	if err != nil {
+		return err
+	}
+
+	pools := make([]*CurvePool, len(addresses))
+	for i, address := range addresses {
+		pool := &CurvePool{Address: address}
+		if err := pool.decodeState(results[i*perPool : (i+1)*perPool]); err != nil {
+			return fmt.Errorf("failed to get the state of %s: %w", address.Hex(), err)
+		}
+		pools[i] = pool
+	}
+	if err := fetchCurveRates(ctx, c, block, pools); err != nil {
+		return err
+	}
+	for _, pool := range pools {
+		p.SetCurve(pool)
+	}
+	return nil
+}
+
+// decodeState sets the state from the results of the calls of PrimeCurve, the coins end
+// at the first index coins reverts on
+func (p *CurvePool) decodeState(results []callResult) error {
+	// fee, admin_fee, A, A_precise, initial_A, future_A, initial_A_time, future_A_time
+	values := make([]*big.Int, 8)
+	unpackers := []func([]byte) (*big.Int, error){
+		helper.Unpack_curve_fee, helper.Unpack_curve_admin_fee, helper.Unpack_curve_A, helper.Unpack_curve_A_precise,
+		helper.Unpack_curve_initial_A, helper.Unpack_curve_future_A, helper.Unpack_curve_initial_A_time, helper.Unpack_curve_future_A_time,
+	}
+	for i, unpack := range unpackers {
+		if results[i].err != nil {
+			// pools before A_PRECISION have no A_precise
+			if i == 3 {
+				continue
+			}
+			return results[i].err
+		}
+		value, err := unpack(results[i].data)
+		if err != nil {
+			return err
+		}
+		values[i] = value
+	}
+	p.Fee, p.AdminFee = values[0], values[1]
+	p.InitialA, p.FutureA = values[4], values[5]
+	p.InitialATime, p.FutureATime = values[6].Uint64(), values[7].Uint64()
+	p.APrecision = 1
+	if values[3] != nil && values[2].Sign() > 0 {
+		p.APrecision = new(big.Int).Quo(values[3], values[2]).Int64()
+	}
+
+	for i := 0; i < curveMaxCoins; i++ {
+		coinResult, balanceResult := results[len(unpackers)+2*i], results[len(unpackers)+2*i+1]
+		if coinResult.err != nil {
+			break
+		}
+		coin, err := helper.Unpack_curve_coins(coinResult.data)
+		if err != nil {
+			return err
+		}
+		if balanceResult.err != nil {
+			return fmt.Errorf("failed to get balance %d: %w", i, balanceResult.err)
+		}
+		balance, err := helper.Unpack_curve_balances(balanceResult.data)
+		if err != nil {
+			return err
+		}
+		p.Coins = append(p.Coins, coin)
+		p.Balances = append(p.Balances, balance)
+	}
+	if len(p.Coins) < 2 {
+		return fmt.Errorf("%d coins, not a StableSwap pool", len(p.Coins))
+	}
+	return nil
+}
+
+// fetchCurveRates reads the decimals of the coins of pools and sets their rates
+func fetchCurveRates(ctx context.Context, c *wsClient.Client, block wsClient.BlockRef, pools []*CurvePool) error {
+	data, err := helper.Pack_erc20_decimals()
+	if err != nil {
+		return err
+	}
+	var calls []call
+	for _, pool := range pools {
+		for _, coin := range pool.Coins {
+			calls = append(calls, call{coin, data})
+		}
+	}
+	results, err := callBatch(ctx, c, block, calls)
+	if err != nil {
+		return err
+	}
+	for _, pool := range pools {
+		pool.Rates = make([]*big.Int, len(pool.Coins))
+		for i, coin := range pool.Coins {
+			result := results[0]
+			results = results[1:]
+			decimals := uint8(18)
+			if coin != curveETH {
+				if result.err != nil {
+					return fmt.Errorf("failed to get the decimals of %s: %w", coin.Hex(), result.err)
+				}
+				if decimals, err = helper.Unpack_erc20_decimals(result.data); err != nil {
+					return fmt.Errorf("failed to get the decimals of %s: %w", coin.Hex(), err)
+				}
+			}
+			if decimals > 36 {
+				return fmt.Errorf("%s has %d decimals", coin.Hex(), decimals)
+			}
+			pool.Rates[i] = new(big.Int).Exp(big.NewInt(10), big.NewInt(36-int64(decimals)), nil)
+		}
+	}
+	return nil
+}
+
+// uniquePairs returns the addresses of pairs without repeats, several pairs of a route
+// may be the same multi-token pool
+func uniquePairs(pairs []helper.PairInfoInterface) []common.Address {
+	seen := make(map[common.Address]bool, len(pairs))
+	var addresses []common.Address
+	for _, pair := range pairs {
+		address := pair.GetPairHex()
+		if !seen[address] {
+			seen[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
@@ -0,0 +1,500 @@
+package amm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	wsClient "github.com/0xKhennati/wsclient"
+	"github.com/0xKhennati/wsclient/helper"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Pools caches the state of constant-product, concentrated liquidity, Uniswap V4, Curve
+// and Balancer pools and the transfer fees of fee-on-transfer tokens, and quotes routes across them.
+// Pools is safe for concurrent use.
+//
+//	pools := amm.NewPools()
+//	err := pools.Prime(ctx, client, wsClient.BlockRefFromTag(wsClient.BlockLatest), route...)
+//	amounts, err := pools.AmountsOut(tokenIn, amountIn, route...)
+//	// keep the reserves fresh from simulated or mined logs
+//	pools.ApplyLogs(logs)
+type Pools struct {
+	mu            sync.RWMutex
+	pools         map[common.Address]V2Pool
+	v3Pools       map[common.Address]*V3Pool
+	v4Pools       map[common.Hash]*V3Pool
+	curvePools    map[common.Address]*CurvePool
+	balancerPools map[common.Address]*BalancerPool
+	transferFees  map[common.Address]uint32
+	timestamp     uint64
+}
+
+// quoter is a cached pool of any family
+type quoter interface {
+	AmountOut(tokenIn common.Address, amountIn *big.Int) (common.Address, *big.Int, error)
+	AmountIn(tokenIn common.Address, amountOut *big.Int) (common.Address, *big.Int, error)
+}
+
+// pairQuoter quotes a pool of more than two tokens between the two tokens of a route pair
+type pairQuoter struct {
+	token0    common.Address
+	token1    common.Address
+	amountOut func(tokenIn, tokenOut common.Address, amountIn *big.Int) (*big.Int, error)
+	amountIn  func(tokenIn, tokenOut common.Address, amountOut *big.Int) (*big.Int, error)
+}
+
+func (q *pairQuoter) tokenOut(tokenIn common.Address) (common.Address, error) {
+	switch tokenIn {
+	case q.token0:
+		return q.token1, nil
+	case q.token1:
+		return q.token0, nil
+	}
+	return common.Address{}, fmt.Errorf("%w: %s", ErrTokenNotInPool, tokenIn.Hex())
+}
+
+func (q *pairQuoter) AmountOut(tokenIn common.Address, amountIn *big.Int) (common.Address, *big.Int, error) {
+	tokenOut, err := q.tokenOut(tokenIn)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	amountOut, err := q.amountOut(tokenIn, tokenOut, amountIn)
+	return tokenOut, amountOut, err
+}
+
+func (q *pairQuoter) AmountIn(tokenIn common.Address, amountOut *big.Int) (common.Address, *big.Int, error) {
+	tokenOut, err := q.tokenOut(tokenIn)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	amountIn, err := q.amountIn(tokenIn, tokenOut, amountOut)
+	return tokenOut, amountIn, err
+}
+
+// NewPools creates an empty cache
+func NewPools() *Pools {
+	return &Pools{
+		pools:         make(map[common.Address]V2Pool),
+		v3Pools:       make(map[common.Address]*V3Pool),
+		v4Pools:       make(map[common.Hash]*V3Pool),
+		curvePools:    make(map[common.Address]*CurvePool),
+		balancerPools: make(map[common.Address]*BalancerPool),
+		transferFees:  make(map[common.Address]uint32),
+	}
+}
+
+// Set adds or replaces a pool
+func (p *Pools) Set(pool V2Pool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pools[pool.Address] = pool
+}
+
+// Pool returns the cached pool at address
+func (p *Pools) Pool(address common.Address) (V2Pool, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	pool, ok := p.pools[address]
+	return pool, ok
+}
+
+// Remove drops the pool at address
+func (p *Pools) Remove(address common.Address) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.pools, address)
+	delete(p.v3Pools, address)
+	delete(p.curvePools, address)
+	delete(p.balancerPools, address)
+}
+
+// quoter returns the cached pool of pair, Curve and Balancer pools are quoted between
+// the two tokens of pair and Uniswap V4 pairs are found by pool id. The caller holds the
+// lock.
+func (p *Pools) quoter(pair helper.PairInfoInterface) (quoter, error) {
+	if len(p.v4Pools) != 0 {
+		id, ok, err := v4PoolID(pair)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			if pool, ok := p.v4Pools[id]; ok {
+				return pool, nil
+			}
+			return nil, fmt.Errorf("%w: %s", ErrUnknownPool, id.Hex())
+		}
+	}
+	address := pair.GetPairHex()
+	if pool, ok := p.pools[address]; ok {
+		return &pool, nil
+	}
+	if pool, ok := p.v3Pools[address]; ok {
+		return pool, nil
+	}
+	if pool, ok := p.curvePools[address]; ok {
+		timestamp := p.now()
+		return &pairQuoter{
+			token0: pair.GetT0Hex(),
+			token1: pair.GetT1Hex(),
+			amountOut: func(tokenIn, tokenOut common.Address, amountIn *big.Int) (*big.Int, error) {
+				return pool.AmountOut(tokenIn, tokenOut, amountIn, timestamp)
+			},
+			amountIn: func(tokenIn, tokenOut common.Address, amountOut *big.Int) (*big.Int, error) {
+				return pool.AmountIn(tokenIn, tokenOut, amountOut, timestamp)
+			},
+		}, nil
+	}
+	if pool, ok := p.balancerPools[address]; ok {
+		return &pairQuoter{token0: pair.GetT0Hex(), token1: pair.GetT1Hex(), amountOut: pool.AmountOut, amountIn: pool.AmountIn}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownPool, address.Hex())
+}
+
+// Len returns the number of cached pools
+func (p *Pools) Len() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.pools) + len(p.v3Pools) + len(p.v4Pools) + len(p.curvePools) + len(p.balancerPools)
+}
+
+// SetTimestamp sets the block time Curve amplification ramps are quoted at, e.g. the
+// time of the pending block. Until it is set quotes use the current time.
+func (p *Pools) SetTimestamp(timestamp uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.timestamp = timestamp
+}
+
+// now returns the quote time, the caller holds the lock
+func (p *Pools) now() uint64 {
+	if p.timestamp != 0 {
+		return p.timestamp
+	}
+	return uint64(time.Now().Unix())
+}
+
+// SetTransferFee records that token takes fee (in FeeDenominator units) on every transfer
+func (p *Pools) SetTransferFee(token common.Address, fee uint32) error {
+	if fee >= FeeDenominator {
+		return fmt.Errorf("%w: transfer fee %d", ErrInvalidFee, fee)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if fee == 0 {
+		delete(p.transferFees, token)
+	} else {
+		p.transferFees[token] = fee
+	}
+	return nil
+}
+
+// TransferFee returns the transfer fee of token
+func (p *Pools) TransferFee(token common.Address) uint32 {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.transferFees[token]
+}
+
+// SetReserves updates the reserves of a cached pool, it reports whether the pool is cached
+func (p *Pools) SetReserves(address common.Address, reserve0, reserve1 *big.Int) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pool, ok := p.pools[address]
+	if !ok {
+		return false
+	}
+	pool.Reserve0, pool.Reserve1 = reserve0, reserve1
+	p.pools[address] = pool
+	return true
+}
+
+// ApplySync updates the reserves of the pool that emitted a Sync event
+func (p *Pools) ApplySync(event *helper.Uniswapv2SyncEvent) bool {
+	return p.SetReserves(event.Raw.Address, event.Reserve0, event.Reserve1)
+}
+
+// ApplyLogs updates the cached pools from logs in order, e.g. the logs of
+// eth_getPengingBlockLog or eth_getTransactionLog: Sync for constant-product pools, Swap,
+// Mint and Burn for concentrated liquidity pools, Fee for the dynamic fee of Algebra
+// pools, the PoolManager Swap and ModifyLiquidity for Uniswap V4 pools, TokenExchange for
+// Curve pools and the vault Swap for Balancer pools. It returns the number of pools
+// updated. A Mint or Burn in a bitmap word that was not fetched updates the ticks only.
+// The admin fees of Curve exchanges are computed at the time of SetTimestamp, use
+// ApplyLogsAt for the logs of a known block.
+func (p *Pools) ApplyLogs(logs []*types.Log) int {
+	return p.ApplyLogsAt(logs, 0)
+}
+
+// ApplyLogsAt is ApplyLogs for the logs of the block at timestamp, the time the Curve
+// amplification of their exchanges is ramped to
+func (p *Pools) ApplyLogsAt(logs []*types.Log, timestamp uint64) int {
+	updated := make(map[common.Address]bool)
+	updatedV4 := make(map[common.Hash]bool)
+	for _, event := range helper.DecodeLogs(logs) {
+		switch event := event.(type) {
+		case *helper.Uniswapv2SyncEvent:
+			if p.ApplySync(event) {
+				updated[event.Raw.Address] = true
+			}
+		case *helper.Uniswapv3SwapEvent:
+			if p.updateV3(event.Raw.Address, func(pool *V3Pool) error {
+				pool.ApplySwap(event.SqrtPriceX96, int32(event.Tick.Int64()), event.Liquidity)
+				return nil
+			}) {
+				updated[event.Raw.Address] = true
+			}
+		case *helper.Uniswapv3MintEvent:
+			if p.updateV3(event.Raw.Address, func(pool *V3Pool) error {
+				return pool.ApplyMint(int32(event.TickLower.Int64()), int32(event.TickUpper.Int64()), event.Amount)
+			}) {
+				updated[event.Raw.Address] = true
+			}
+		case *helper.Uniswapv3BurnEvent:
+			if p.updateV3(event.Raw.Address, func(pool *V3Pool) error {
+				return pool.ApplyMint(int32(event.TickLower.Int64()), int32(event.TickUpper.Int64()), new(big.Int).Neg(event.Amount))
+			}) {
+				updated[event.Raw.Address] = true
+			}
+		case *helper.AlgebraFeeEvent:
+			if p.updateV3(event.Raw.Address, func(pool *V3Pool) error {
+				pool.Fee = uint32(event.Fee)
+				return nil
+			}) {
+				updated[event.Raw.Address] = true
+			}
+		case *helper.Uniswapv4SwapEvent:
+			if p.updateV4(event.Id, func(pool *V3Pool) error {
+				pool.ApplySwap(event.SqrtPriceX96, int32(event.Tick.Int64()), event.Liquidity)
+				return nil
+			}) {
+				updatedV4[event.Id] = true
+			}
+		case *helper.Uniswapv4ModifyLiquidityEvent:
+			if p.updateV4(event.Id, func(pool *V3Pool) error {
+				return pool.ApplyMint(int32(event.TickLower.Int64()), int32(event.TickUpper.Int64()), event.LiquidityDelta)
+			}) {
+				updatedV4[event.Id] = true
+			}
+		case *helper.CurveTokenExchangeEvent:
+			if p.updateCurve(event.Raw.Address, timestamp, func(pool *CurvePool, timestamp uint64) error {
+				return pool.ApplyExchange(int(event.SoldId.Int64()), int(event.BoughtId.Int64()), event.TokensSold, event.TokensBought, timestamp)
+			}) {
+				updated[event.Raw.Address] = true
+			}
+		case *helper.BalancerSwapEvent:
+			// the vault emits the swap, the pool address is the start of the pool id
+			address := common.BytesToAddress(event.PoolId[:common.AddressLength])
+			if p.updateBalancer(address, func(pool *BalancerPool) error {
+				return pool.ApplySwap(event.TokenIn, event.TokenOut, event.AmountIn, event.AmountOut)
+			}) {
+				updated[address] = true
+			}
+		}
+	}
+	return len(updated) + len(updatedV4)
+}
+
+// updateV3 applies update to a cached concentrated liquidity pool, a pool the update
+// fails on is dropped since its state is no longer known
+func (p *Pools) updateV3(address common.Address, update func(*V3Pool) error) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pool, ok := p.v3Pools[address]
+	if !ok {
+		return false
+	}
+	// copy on write, quotes in progress keep the previous state
+	pool = pool.Clone()
+	if err := update(pool); err != nil {
+		delete(p.v3Pools, address)
+		return false
+	}
+	p.v3Pools[address] = pool
+	return true
+}
+
+// Prime reads the reserves of pairs at block in one batch of eth_call and caches the
+// pools with the tokens and fee of each PairInfo. eth_multiCall only returns its last
+// result so every getReserves is a call of the batch.
+func (p *Pools) Prime(ctx context.Context, c *wsClient.Client, block wsClient.BlockRef, pairs ...helper.PairInfoInterface) error {
+	if len(pairs) == 0 {
+		return nil
+	}
+	callData, err := helper.BuildUniswapV2GetReservesCallData()
+	if err != nil {
+		return err
+	}
+	calls := make([]call, len(pairs))
+	for i, pair := range pairs {
+		calls[i] = call{pair.GetPairHex(), callData}
+	}
+	results, err := callBatch(ctx, c, block, calls)
+	if err != nil {
+		return err
+	}
+
+	pools := make([]V2Pool, len(pairs))
+	for i, result := range results {
+		address := pairs[i].GetPairHex()
+		if result.err != nil {
+			return fmt.Errorf("failed to get reserves of %s: %w", address.Hex(), result.err)
+		}
+		reserves, err := helper.DecodeUniswapV2GetReserves(result.data)
+		if err != nil {
+			return fmt.Errorf("failed to get reserves of %s: %w", address.Hex(), err)
+		}
+		pools[i] = V2Pool{
+			Address:  address,
+			Token0:   pairs[i].GetT0Hex(),
+			Token1:   pairs[i].GetT1Hex(),
+			Reserve0: reserves.Reserve0,
+			Reserve1: reserves.Reserve1,
+			Fee:      pairs[i].GetFee(),
+		}
+	}
+	for _, pool := range pools {
+		p.Set(pool)
+	}
+	return nil
+}
+
+// AmountsOut quotes swapping amountIn of tokenIn through pairs, like the calculator
+// module's GetAmountsOut: amounts[0] is amountIn and amounts[i] the output of pairs[i-1].
+// Transfer fees are taken on every transfer, into each pair and to the recipient.
+func (p *Pools) AmountsOut(tokenIn common.Address, amountIn *big.Int, pairs ...helper.PairInfoInterface) ([]*big.Int, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	amounts := make([]*big.Int, 0, len(pairs)+1)
+	amounts = append(amounts, amountIn)
+	token, amount := tokenIn, amountIn
+	for _, pair := range pairs {
+		pool, err := p.quoter(pair)
+		if err != nil {
+			return nil, err
+		}
+		received := AfterTransferFee(amount, p.transferFees[token])
+		tokenOut, amountOut, err := pool.AmountOut(token, received)
+		if err != nil {
+			return nil, fmt.Errorf("pool %s: %w", pair.GetPairHex().Hex(), err)
+		}
+		token, amount = tokenOut, amountOut
+		amounts = append(amounts, amountOut)
+	}
+	if fee := p.transferFees[token]; fee != 0 {
+		amounts[len(amounts)-1] = AfterTransferFee(amount, fee)
+	}
+	return amounts, nil
+}
+
+// AmountOut returns the final output of AmountsOut
+func (p *Pools) AmountOut(tokenIn common.Address, amountIn *big.Int, pairs ...helper.PairInfoInterface) (*big.Int, error) {
+	amounts, err := p.AmountsOut(tokenIn, amountIn, pairs...)
+	if err != nil {
+		return nil, err
+	}
+	return amounts[len(amounts)-1], nil
+}
+
+// AmountsIn quotes the input of tokenIn needed to receive amountOut at the end of pairs,
+// like UniswapV2Library.getAmountsIn: amounts[len(pairs)] is amountOut and amounts[i]
+// the amount sent to pairs[i]
+func (p *Pools) AmountsIn(tokenIn common.Address, amountOut *big.Int, pairs ...helper.PairInfoInterface) ([]*big.Int, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	// walk forward to find the token entering each pair
+	pools := make([]quoter, len(pairs))
+	tokens := make([]common.Address, len(pairs)+1)
+	tokens[0] = tokenIn
+	for i, pair := range pairs {
+		pool, err := p.quoter(pair)
+		if err != nil {
+			return nil, err
+		}
+		tokenOut, err := otherToken(pool, tokens[i])
+		if err != nil {
+			return nil, err
+		}
+		pools[i], tokens[i+1] = pool, tokenOut
+	}
+
+	amounts := make([]*big.Int, len(pairs)+1)
+	amounts[len(pairs)] = amountOut
+	// the last pair must send enough for the recipient to receive amountOut after fees
+	amount, err := BeforeTransferFee(amountOut, p.transferFees[tokens[len(pairs)]])
+	if err != nil {
+		return nil, err
+	}
+	for i := len(pairs) - 1; i >= 0; i-- {
+		_, received, err := pools[i].AmountIn(tokens[i], amount)
+		if err != nil {
+			return nil, fmt.Errorf("pool %s: %w", pairs[i].GetPairHex().Hex(), err)
+		}
+		if amount, err = BeforeTransferFee(received, p.transferFees[tokens[i]]); err != nil {
+			return nil, err
+		}
+		amounts[i] = amount
+	}
+	return amounts, nil
+}
+
+// otherToken returns the token a swap from tokenIn outputs
+func otherToken(pool quoter, tokenIn common.Address) (common.Address, error) {
+	switch pool := pool.(type) {
+	case *V2Pool:
+		_, _, tokenOut, err := pool.reserves(tokenIn)
+		return tokenOut, err
+	case *V3Pool:
+		_, tokenOut, err := pool.direction(tokenIn)
+		return tokenOut, err
+	case *pairQuoter:
+		return pool.tokenOut(tokenIn)
+	}
+	return common.Address{}, fmt.Errorf("unsupported pool %T", pool)
+}
+
+// call is an eth_call of a batch
+type call struct {
+	to   common.Address
+	data hexutil.Bytes
+}
+
+// callResult is the return data or the error of a call
+type callResult struct {
+	data hexutil.Bytes
+	err  error
+}
+
+// callBatch sends calls as one batch of eth_call at block, a failed call only fails its result
+func callBatch(ctx context.Context, c *wsClient.Client, block wsClient.BlockRef, calls []call) ([]callResult, error) {
+	if len(calls) == 0 {
+		return nil, nil
+	}
+	requests := make([]*wsClient.Request, len(calls))
+	for i := range calls {
+		to := calls[i].to
+		request, err := wsClient.Build_eth_call_request_at(0, wsClient.CallMsg{To: &to, Data: calls[i].data}, nil, block)
+		if err != nil {
+			return nil, err
+		}
+		requests[i] = request
+	}
+	responses, err := c.CallBatch(ctx, requests)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]callResult, len(responses))
+	for i, response := range responses {
+		if response.Error != nil {
+			results[i].err = response.Error
+			continue
+		}
+		results[i].err = results[i].data.UnmarshalJSON(response.Result)
+	}
+	return results, nil
+}
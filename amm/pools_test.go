@@ -0,0 +1,141 @@
+package amm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	wsClient "github.com/0xKhennati/wsclient"
+	"github.com/0xKhennati/wsclient/helper"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gorilla/websocket"
+)
+
+// stubNode is a WebSocket JSON-RPC node answering eth_call with call, which returns the
+// return data or a revert
+type stubNode struct {
+	server *httptest.Server
+}
+
+func newStubNode(t testing.TB, call func(to common.Address, data []byte) ([]byte, error)) *stubNode {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	node := &stubNode{server: httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var request struct {
+				ID     int64             `json:"id"`
+				Method string            `json:"method"`
+				Params []json.RawMessage `json:"params"`
+			}
+			if err := json.Unmarshal(message, &request); err != nil {
+				return
+			}
+			var msg wsClient.CallMsg
+			var response string
+			if request.Method != "eth_call" || len(request.Params) == 0 || json.Unmarshal(request.Params[0], &msg) != nil || msg.To == nil {
+				response = fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"error":{"code":-32601,"message":"unsupported"}}`, request.ID)
+			} else if data, err := call(*msg.To, msg.Data); err != nil {
+				response = fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"error":{"code":3,"message":%q}}`, request.ID, err.Error())
+			} else {
+				response = fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":"0x%x"}`, request.ID, data)
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(response)); err != nil {
+				return
+			}
+		}
+	}))}
+	t.Cleanup(node.server.Close)
+	return node
+}
+
+// dial connects a client to the node
+func (n *stubNode) dial(t testing.TB) *wsClient.Client {
+	t.Helper()
+	client, err := wsClient.NewClient("ws" + strings.TrimPrefix(n.server.URL, "http"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// words ABI-encodes values as 32-byte words, negative values in two's complement
+func words(values ...*big.Int) []byte {
+	var data []byte
+	for _, value := range values {
+		word := new(big.Int).Set(value)
+		if word.Sign() < 0 {
+			word.Add(word, new(big.Int).Lsh(common1, 256))
+		}
+		data = append(data, common.LeftPadBytes(word.Bytes(), 32)...)
+	}
+	return data
+}
+
+func TestApplyLogs(t *testing.T) {
+	pair := common.HexToAddress("0x0d4a11d5EEaaC28EC3F61d100daF4d40471f1852")
+	algebra := common.HexToAddress("0xa1")
+	pools := NewPools()
+	pools.Set(V2Pool{Address: pair, Reserve0: big.NewInt(1), Reserve1: big.NewInt(1), Fee: 3000})
+	pools.SetV3(&V3Pool{Kind: KindAlgebra, Address: algebra, Fee: 100})
+
+	logs := []*types.Log{
+		{Address: pair, Topics: []common.Hash{crypto.Keccak256Hash([]byte("Sync(uint112,uint112)"))}, Data: words(big.NewInt(10), big.NewInt(20))},
+		{Address: algebra, Topics: []common.Hash{crypto.Keccak256Hash([]byte("Fee(uint16)"))}, Data: words(big.NewInt(2500))},
+		// an Algebra pool that is not cached
+		{Address: common.HexToAddress("0xa2"), Topics: []common.Hash{crypto.Keccak256Hash([]byte("Fee(uint16)"))}, Data: words(big.NewInt(1))},
+	}
+	if updated := pools.ApplyLogs(logs); updated != 2 {
+		t.Errorf("ApplyLogs updated %d pools, want 2", updated)
+	}
+	if pool, _ := pools.Pool(pair); pool.Reserve0.Int64() != 10 || pool.Reserve1.Int64() != 20 {
+		t.Errorf("reserves = %s, %s", pool.Reserve0, pool.Reserve1)
+	}
+	if pool, _ := pools.V3Pool(algebra); pool.Fee != 2500 {
+		t.Errorf("algebra fee = %d, want 2500", pool.Fee)
+	}
+}
+
+func TestRefreshAlgebraFees(t *testing.T) {
+	calculator := common.HexToAddress("0xca")
+	cached, uncached := common.HexToAddress("0xa1"), common.HexToAddress("0xa2")
+	node := newStubNode(t, func(to common.Address, data []byte) ([]byte, error) {
+		call, err := helper.DecodeCall(data)
+		if err != nil || to != calculator || call.RawName != "getAlgebraFee" {
+			return nil, errors.New("execution reverted")
+		}
+		return words(big.NewInt(1234)), nil
+	})
+	client := node.dial(t)
+	pools := NewPools()
+	pools.SetV3(&V3Pool{Kind: KindAlgebra, Address: cached, Fee: 100})
+
+	ctx := context.Background()
+	block := wsClient.BlockRefFromTag(wsClient.BlockLatest)
+	if err := pools.RefreshAlgebraFees(ctx, client, calculator, block, cached); err != nil {
+		t.Fatal(err)
+	}
+	if pool, _ := pools.V3Pool(cached); pool.Fee != 1234 {
+		t.Errorf("fee = %d, want 1234", pool.Fee)
+	}
+	if err := pools.RefreshAlgebraFees(ctx, client, calculator, block, cached, uncached); !errors.Is(err, ErrUnknownPool) {
+		t.Errorf("uncached pool err = %v, want ErrUnknownPool", err)
+	}
+}
@@ -0,0 +1,159 @@
+// Package amm quotes swaps off-chain from cached pool state, with the same integer
+// arithmetic as the pools and the calculator module.
+package amm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// FeeDenominator is the unit of pool and transfer fees, hundredths of a bip as in
+// UniswapV3: PairInfo.Fee 3000 is the 0.3% of UniswapV2
+const FeeDenominator = 1_000_000
+
+var (
+	ErrInsufficientInputAmount  = errors.New("insufficient input amount")
+	ErrInsufficientOutputAmount = errors.New("insufficient output amount")
+	ErrInsufficientLiquidity    = errors.New("insufficient liquidity")
+	ErrInvalidFee               = errors.New("invalid fee")
+	ErrTokenNotInPool           = errors.New("token not in pool")
+	ErrUnknownPool              = errors.New("unknown pool")
+)
+
+var bigFeeDenominator = big.NewInt(FeeDenominator)
+
+// GetAmountOut returns the output of a constant-product swap of amountIn, as
+// UniswapV2Library.getAmountOut with fee in FeeDenominator units
+func GetAmountOut(amountIn, reserveIn, reserveOut *big.Int, fee uint32) (*big.Int, error) {
+	if amountIn == nil || amountIn.Sign() <= 0 {
+		return nil, ErrInsufficientInputAmount
+	}
+	if reserveIn == nil || reserveOut == nil || reserveIn.Sign() <= 0 || reserveOut.Sign() <= 0 {
+		return nil, ErrInsufficientLiquidity
+	}
+	if fee >= FeeDenominator {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidFee, fee)
+	}
+	amountInWithFee := new(big.Int).Mul(amountIn, big.NewInt(FeeDenominator-int64(fee)))
+	numerator := new(big.Int).Mul(amountInWithFee, reserveOut)
+	denominator := new(big.Int).Mul(reserveIn, bigFeeDenominator)
+	denominator.Add(denominator, amountInWithFee)
+	return numerator.Quo(numerator, denominator), nil
+}
+
+// GetAmountIn returns the input needed for amountOut, as UniswapV2Library.getAmountIn
+// with fee in FeeDenominator units
+func GetAmountIn(amountOut, reserveIn, reserveOut *big.Int, fee uint32) (*big.Int, error) {
+	if amountOut == nil || amountOut.Sign() <= 0 {
+		return nil, ErrInsufficientOutputAmount
+	}
+	if reserveIn == nil || reserveOut == nil || reserveIn.Sign() <= 0 || reserveOut.Cmp(amountOut) <= 0 {
+		return nil, ErrInsufficientLiquidity
+	}
+	if fee >= FeeDenominator {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidFee, fee)
+	}
+	numerator := new(big.Int).Mul(reserveIn, amountOut)
+	numerator.Mul(numerator, bigFeeDenominator)
+	denominator := new(big.Int).Sub(reserveOut, amountOut)
+	denominator.Mul(denominator, big.NewInt(FeeDenominator-int64(fee)))
+	amountIn := numerator.Quo(numerator, denominator)
+	return amountIn.Add(amountIn, common.Big1), nil
+}
+
+// V2Pool is the state of a constant-product pool
+type V2Pool struct {
+	Address  common.Address
+	Token0   common.Address
+	Token1   common.Address
+	Reserve0 *big.Int
+	Reserve1 *big.Int
+	Fee      uint32 // in FeeDenominator units
+}
+
+// reserves returns the reserves ordered for a swap from tokenIn
+func (p *V2Pool) reserves(tokenIn common.Address) (*big.Int, *big.Int, common.Address, error) {
+	switch tokenIn {
+	case p.Token0:
+		return p.Reserve0, p.Reserve1, p.Token1, nil
+	case p.Token1:
+		return p.Reserve1, p.Reserve0, p.Token0, nil
+	}
+	return nil, nil, common.Address{}, fmt.Errorf("%w: %s not in %s", ErrTokenNotInPool, tokenIn.Hex(), p.Address.Hex())
+}
+
+// AmountOut returns the output token and amount of swapping amountIn of tokenIn
+func (p *V2Pool) AmountOut(tokenIn common.Address, amountIn *big.Int) (common.Address, *big.Int, error) {
+	reserveIn, reserveOut, tokenOut, err := p.reserves(tokenIn)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	amountOut, err := GetAmountOut(amountIn, reserveIn, reserveOut, p.Fee)
+	return tokenOut, amountOut, err
+}
+
+// AmountIn returns the amount of tokenIn needed to receive amountOut of the other token
+func (p *V2Pool) AmountIn(tokenIn common.Address, amountOut *big.Int) (common.Address, *big.Int, error) {
+	reserveIn, reserveOut, tokenOut, err := p.reserves(tokenIn)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	amountIn, err := GetAmountIn(amountOut, reserveIn, reserveOut, p.Fee)
+	return tokenOut, amountIn, err
+}
+
+// Swap returns the pool after swapping amountIn of tokenIn for amountOut
+func (p V2Pool) Swap(tokenIn common.Address, amountIn, amountOut *big.Int) (V2Pool, error) {
+	reserveIn, reserveOut, _, err := p.reserves(tokenIn)
+	if err != nil {
+		return p, err
+	}
+	reserveIn = new(big.Int).Add(reserveIn, amountIn)
+	reserveOut = new(big.Int).Sub(reserveOut, amountOut)
+	if reserveOut.Sign() <= 0 {
+		return p, ErrInsufficientLiquidity
+	}
+	if tokenIn == p.Token0 {
+		p.Reserve0, p.Reserve1 = reserveIn, reserveOut
+	} else {
+		p.Reserve1, p.Reserve0 = reserveIn, reserveOut
+	}
+	return p, nil
+}
+
+// AfterTransferFee returns what the recipient of amount receives from a token taking
+// fee (in FeeDenominator units) on transfers
+func AfterTransferFee(amount *big.Int, fee uint32) *big.Int {
+	if fee == 0 {
+		return new(big.Int).Set(amount)
+	}
+	tax := new(big.Int).Mul(amount, big.NewInt(int64(fee)))
+	tax.Quo(tax, bigFeeDenominator)
+	return tax.Sub(amount, tax)
+}
+
+// BeforeTransferFee returns the smallest amount to send so that the recipient receives
+// at least received, the inverse of AfterTransferFee
+func BeforeTransferFee(received *big.Int, fee uint32) (*big.Int, error) {
+	if fee == 0 {
+		return new(big.Int).Set(received), nil
+	}
+	if fee >= FeeDenominator {
+		return nil, fmt.Errorf("%w: transfer fee %d", ErrInvalidFee, fee)
+	}
+	kept := big.NewInt(FeeDenominator - int64(fee))
+	amount := new(big.Int).Mul(received, bigFeeDenominator)
+	amount.Add(amount, kept).Sub(amount, common.Big1).Quo(amount, kept)
+	// the tax rounds down, a smaller amount may already be enough
+	for amount.Sign() > 0 {
+		smaller := new(big.Int).Sub(amount, common.Big1)
+		if AfterTransferFee(smaller, fee).Cmp(received) < 0 {
+			break
+		}
+		amount = smaller
+	}
+	return amount, nil
+}
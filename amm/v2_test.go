@@ -0,0 +1,146 @@
+package amm
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ether returns n * 10^18
+func ether(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18))
+}
+
+// bigInt parses a decimal constant
+func bigInt(t testing.TB, s string) *big.Int {
+	t.Helper()
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		t.Fatalf("invalid integer %q", s)
+	}
+	return v
+}
+
+func TestGetAmountOut(t *testing.T) {
+	tests := []struct {
+		name       string
+		amountIn   *big.Int
+		reserveIn  *big.Int
+		reserveOut *big.Int
+		fee        uint32
+		want       string
+		wantErr    error
+	}{
+		// UniswapV2Library.getAmountOut(1e18, 100e18, 200e18)
+		{"uniswap v2", ether(1), ether(100), ether(200), 3000, "1974316068794122597", nil},
+		{"no fee", ether(1), ether(100), ether(200), 0, "1980198019801980198", nil},
+		// PancakeSwap V2 0.25%
+		{"pancake", big.NewInt(1000), big.NewInt(1e6), big.NewInt(1e6), 2500, "996", nil},
+		// 1000 USDC into a 50M USDC / 20k WETH pair
+		{"usdc to weth", big.NewInt(1000e6), big.NewInt(50_000_000e6), ether(20_000), 3000, "398792048086561153", nil},
+		{"zero input", new(big.Int), ether(1), ether(1), 3000, "", ErrInsufficientInputAmount},
+		{"empty pool", ether(1), new(big.Int), ether(1), 3000, "", ErrInsufficientLiquidity},
+		{"fee of 100%", ether(1), ether(1), ether(1), FeeDenominator, "", ErrInvalidFee},
+	}
+	for _, tt := range tests {
+		got, err := GetAmountOut(tt.amountIn, tt.reserveIn, tt.reserveOut, tt.fee)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.String() != tt.want {
+			t.Errorf("%s: amountOut = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGetAmountIn(t *testing.T) {
+	tests := []struct {
+		name       string
+		amountOut  *big.Int
+		reserveIn  *big.Int
+		reserveOut *big.Int
+		fee        uint32
+		want       string
+		wantErr    error
+	}{
+		// UniswapV2Library.getAmountIn(1e18, 100e18, 200e18)
+		{"uniswap v2", ether(1), ether(100), ether(200), 3000, "504024636724243082", nil},
+		{"pancake", big.NewInt(1000), big.NewInt(1e6), big.NewInt(1e6), 2500, "1004", nil},
+		// the exact input of the usdc to weth swap buys its output back
+		{"usdc to weth", bigInt(t, "398792048086561153"), big.NewInt(50_000_000e6), ether(20_000), 3000, "1000000000", nil},
+		{"zero output", new(big.Int), ether(1), ether(1), 3000, "", ErrInsufficientOutputAmount},
+		{"whole reserve", ether(1), ether(1), ether(1), 3000, "", ErrInsufficientLiquidity},
+		{"fee of 100%", ether(1), ether(1), ether(2), FeeDenominator, "", ErrInvalidFee},
+	}
+	for _, tt := range tests {
+		got, err := GetAmountIn(tt.amountOut, tt.reserveIn, tt.reserveOut, tt.fee)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got.String() != tt.want {
+			t.Errorf("%s: amountIn = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestV2PoolSwap(t *testing.T) {
+	token0, token1 := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+	pool := V2Pool{Token0: token0, Token1: token1, Reserve0: ether(100), Reserve1: ether(200), Fee: 3000}
+
+	tokenOut, amountOut, err := pool.AmountOut(token0, ether(1))
+	if err != nil || tokenOut != token1 || amountOut.String() != "1974316068794122597" {
+		t.Fatalf("AmountOut = %s, %v, %v", tokenOut.Hex(), amountOut, err)
+	}
+	swapped, err := pool.Swap(token0, ether(1), amountOut)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if swapped.Reserve0.Cmp(ether(101)) != 0 || new(big.Int).Add(swapped.Reserve1, amountOut).Cmp(ether(200)) != 0 {
+		t.Errorf("reserves after swap = %s, %s", swapped.Reserve0, swapped.Reserve1)
+	}
+	if pool.Reserve0.Cmp(ether(100)) != 0 {
+		t.Error("Swap modified the original pool")
+	}
+
+	if _, _, err := pool.AmountOut(common.HexToAddress("0x03"), ether(1)); !errors.Is(err, ErrTokenNotInPool) {
+		t.Errorf("foreign token err = %v", err)
+	}
+}
+
+func TestTransferFee(t *testing.T) {
+	tests := []struct {
+		amount   *big.Int
+		fee      uint32
+		received string
+	}{
+		{ether(1), 0, "1000000000000000000"},
+		{ether(1), 10_000, "990000000000000000"},
+		{big.NewInt(101), 10_000, "100"},
+		{big.NewInt(7), 333_333, "5"},
+	}
+	for _, tt := range tests {
+		received := AfterTransferFee(tt.amount, tt.fee)
+		if received.String() != tt.received {
+			t.Errorf("AfterTransferFee(%s, %d) = %s, want %s", tt.amount, tt.fee, received, tt.received)
+			continue
+		}
+		// BeforeTransferFee returns the smallest amount that still delivers received
+		amount, err := BeforeTransferFee(received, tt.fee)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if AfterTransferFee(amount, tt.fee).Cmp(received) < 0 {
+			t.Errorf("BeforeTransferFee(%s, %d) = %s delivers less", received, tt.fee, amount)
+		}
+		if smaller := new(big.Int).Sub(amount, common.Big1); smaller.Sign() > 0 && AfterTransferFee(smaller, tt.fee).Cmp(received) >= 0 {
+			t.Errorf("BeforeTransferFee(%s, %d) = %s is not the smallest", received, tt.fee, amount)
+		}
+	}
+	if _, err := BeforeTransferFee(ether(1), FeeDenominator); !errors.Is(err, ErrInvalidFee) {
+		t.Errorf("100%% transfer fee err = %v", err)
+	}
+}
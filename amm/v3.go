@@ -0,0 +1,376 @@
+package amm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrMissingTickData is returned when a swap crosses a tick bitmap word that was not fetched
+var ErrMissingTickData = errors.New("missing tick data")
+
+// V3Kind is the family of a concentrated liquidity pool
+type V3Kind uint8
+
+const (
+	// KindUniswapV3 pools expose slot0, tickBitmap and ticks
+	KindUniswapV3 V3Kind = iota
+	// KindAlgebra pools (Algebra V1) expose globalState, tickTable and ticks, with a
+	// dynamic fee read from globalState or the calculator module's getAlgebraFee
+	KindAlgebra
+	// KindUniswapV4 pools live in the PoolManager and are read by pool id from the
+	// StateView, only hookless pools are quoted
+	KindUniswapV4
+)
+
+func (k V3Kind) String() string {
+	switch k {
+	case KindUniswapV3:
+		return "uniswapv3"
+	case KindAlgebra:
+		return "algebra"
+	case KindUniswapV4:
+		return "uniswapv4"
+	}
+	return fmt.Sprintf("V3Kind(%d)", uint8(k))
+}
+
+// Tick is the liquidity of an initialized tick
+type Tick struct {
+	LiquidityGross *big.Int
+	LiquidityNet   *big.Int
+}
+
+// V3Pool is the state of a concentrated liquidity pool. Bitmap holds the fetched words of
+// the tick bitmap, a swap fails with ErrMissingTickData when it needs another word.
+type V3Pool struct {
+	Kind         V3Kind
+	Address      common.Address // the StateView of Uniswap V4 pools
+	PoolID       common.Hash    // the pool id of Uniswap V4 pools
+	Token0       common.Address
+	Token1       common.Address
+	Fee          uint32 // in FeeDenominator units, the LP fee of Uniswap V4 pools
+	ProtocolFee  uint32 // Uniswap V4 protocol fee, 12 bits per direction, 1to0 in the upper bits
+	TickSpacing  int32
+	SqrtPriceX96 *big.Int
+	Tick         int32
+	Liquidity    *big.Int
+	Ticks        map[int32]Tick
+	Bitmap       map[int16]*big.Int
+}
+
+// Clone returns a copy of p that can be updated independently
+func (p *V3Pool) Clone() *V3Pool {
+	clone := *p
+	clone.Ticks = make(map[int32]Tick, len(p.Ticks))
+	for tick, info := range p.Ticks {
+		clone.Ticks[tick] = info
+	}
+	clone.Bitmap = make(map[int16]*big.Int, len(p.Bitmap))
+	for word, bitmap := range p.Bitmap {
+		clone.Bitmap[word] = bitmap
+	}
+	return &clone
+}
+
+// String names the pool in errors, by pool id for Uniswap V4 pools
+func (p *V3Pool) String() string {
+	if p.Kind == KindUniswapV4 {
+		return p.PoolID.Hex()
+	}
+	return p.Address.Hex()
+}
+
+// swapFee returns the fee of a swap, for Uniswap V4 pools the LP fee with the protocol
+// fee of the direction as ProtocolFeeLibrary.calculateSwapFee
+func (p *V3Pool) swapFee(zeroForOne bool) uint32 {
+	if p.Kind != KindUniswapV4 {
+		return p.Fee
+	}
+	protocolFee := p.ProtocolFee >> 12
+	if zeroForOne {
+		protocolFee = p.ProtocolFee & 0xfff
+	}
+	if protocolFee == 0 {
+		return p.Fee
+	}
+	return uint32(uint64(protocolFee) + uint64(p.Fee) - uint64(protocolFee)*uint64(p.Fee)/FeeDenominator)
+}
+
+// compress returns tick / tickSpacing rounded towards negative infinity
+func compress(tick, tickSpacing int32) int32 {
+	compressed := tick / tickSpacing
+	if tick < 0 && tick%tickSpacing != 0 {
+		compressed--
+	}
+	return compressed
+}
+
+// position returns the bitmap word and bit of a compressed tick, as TickBitmap.position
+func position(compressed int32) (int16, uint) {
+	return int16(compressed >> 8), uint(uint8(compressed))
+}
+
+// WordOf returns the bitmap word holding tick
+func (p *V3Pool) WordOf(tick int32) int16 {
+	word, _ := position(compress(tick, p.TickSpacing))
+	return word
+}
+
+// nextInitializedTickWithinOneWord as TickBitmap.nextInitializedTickWithinOneWord
+func (p *V3Pool) nextInitializedTickWithinOneWord(tick int32, lte bool) (int32, bool, error) {
+	compressed := compress(tick, p.TickSpacing)
+	if !lte {
+		compressed++
+	}
+	word, bit := position(compressed)
+	bitmap, ok := p.Bitmap[word]
+	if !ok {
+		return 0, false, fmt.Errorf("%w: word %d of %s", ErrMissingTickData, word, p)
+	}
+
+	one := big.NewInt(1)
+	masked := new(big.Int)
+	if lte {
+		// all the bits at or to the right of bit
+		mask := new(big.Int).Lsh(one, bit+1)
+		masked.And(bitmap, mask.Sub(mask, one))
+		if masked.Sign() != 0 {
+			return (compressed - int32(bit-mostSignificantBit(masked))) * p.TickSpacing, true, nil
+		}
+		return (compressed - int32(bit)) * p.TickSpacing, false, nil
+	}
+	// all the bits at or to the left of bit
+	masked.Rsh(bitmap, bit)
+	if masked.Sign() != 0 {
+		return (compressed + int32(leastSignificantBit(masked))) * p.TickSpacing, true, nil
+	}
+	return (compressed + int32(255-bit)) * p.TickSpacing, false, nil
+}
+
+// SwapResult is the outcome of a simulated swap, amounts are signed from the pool's
+// point of view as in the Swap event: positive is received, negative is sent
+type SwapResult struct {
+	Amount0      *big.Int
+	Amount1      *big.Int
+	SqrtPriceX96 *big.Int
+	Tick         int32
+	Liquidity    *big.Int
+}
+
+// Swap simulates UniswapV3Pool.swap: amountSpecified is positive for an exact input and
+// negative for an exact output, sqrtPriceLimitX96 nil means no limit
+func (p *V3Pool) Swap(zeroForOne bool, amountSpecified, sqrtPriceLimitX96 *big.Int) (*SwapResult, error) {
+	if amountSpecified.Sign() == 0 {
+		return nil, ErrInsufficientInputAmount
+	}
+	if p.SqrtPriceX96 == nil || p.Liquidity == nil || p.TickSpacing <= 0 {
+		return nil, fmt.Errorf("%w: %s has no state", ErrUnknownPool, p)
+	}
+	if sqrtPriceLimitX96 == nil {
+		if zeroForOne {
+			sqrtPriceLimitX96 = new(big.Int).Add(MinSqrtRatio, common.Big1)
+		} else {
+			sqrtPriceLimitX96 = new(big.Int).Sub(MaxSqrtRatio, common.Big1)
+		}
+	}
+	if zeroForOne {
+		if sqrtPriceLimitX96.Cmp(p.SqrtPriceX96) >= 0 || sqrtPriceLimitX96.Cmp(MinSqrtRatio) <= 0 {
+			return nil, ErrInvalidPriceLimit
+		}
+	} else if sqrtPriceLimitX96.Cmp(p.SqrtPriceX96) <= 0 || sqrtPriceLimitX96.Cmp(MaxSqrtRatio) >= 0 {
+		return nil, ErrInvalidPriceLimit
+	}
+
+	exactInput := amountSpecified.Sign() > 0
+	fee := p.swapFee(zeroForOne)
+	if fee >= FeeDenominator && !exactInput {
+		return nil, fmt.Errorf("%w: exact output with fee %d", ErrInvalidFee, fee)
+	}
+	remaining := new(big.Int).Set(amountSpecified)
+	calculated := new(big.Int)
+	sqrtPriceX96 := p.SqrtPriceX96
+	tick := p.Tick
+	liquidity := p.Liquidity
+
+	for remaining.Sign() != 0 && sqrtPriceX96.Cmp(sqrtPriceLimitX96) != 0 {
+		sqrtPriceStart := sqrtPriceX96
+		tickNext, initialized, err := p.nextInitializedTickWithinOneWord(tick, zeroForOne)
+		if err != nil {
+			return nil, err
+		}
+		if tickNext < MinTick {
+			tickNext = MinTick
+		} else if tickNext > MaxTick {
+			tickNext = MaxTick
+		}
+		sqrtPriceNextX96, err := GetSqrtRatioAtTick(tickNext)
+		if err != nil {
+			return nil, err
+		}
+
+		target := sqrtPriceNextX96
+		if (zeroForOne && sqrtPriceNextX96.Cmp(sqrtPriceLimitX96) < 0) || (!zeroForOne && sqrtPriceNextX96.Cmp(sqrtPriceLimitX96) > 0) {
+			target = sqrtPriceLimitX96
+		}
+		step, err := computeSwapStep(sqrtPriceX96, target, liquidity, remaining, fee)
+		if err != nil {
+			return nil, err
+		}
+		sqrtPriceX96 = step.sqrtRatioNextX96
+
+		if exactInput {
+			remaining.Sub(remaining, step.amountIn).Sub(remaining, step.feeAmount)
+			calculated.Sub(calculated, step.amountOut)
+		} else {
+			remaining.Add(remaining, step.amountOut)
+			calculated.Add(calculated, step.amountIn).Add(calculated, step.feeAmount)
+		}
+
+		if sqrtPriceX96.Cmp(sqrtPriceNextX96) == 0 {
+			if initialized {
+				info, ok := p.Ticks[tickNext]
+				if !ok {
+					return nil, fmt.Errorf("%w: tick %d of %s", ErrMissingTickData, tickNext, p)
+				}
+				liquidityNet := info.LiquidityNet
+				if zeroForOne {
+					liquidityNet = new(big.Int).Neg(liquidityNet)
+				}
+				if liquidity, err = addDelta(liquidity, liquidityNet); err != nil {
+					return nil, err
+				}
+			}
+			tick = tickNext
+			if zeroForOne {
+				tick--
+			}
+		} else if sqrtPriceX96.Cmp(sqrtPriceStart) != 0 {
+			if tick, err = GetTickAtSqrtRatio(sqrtPriceX96); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result := &SwapResult{SqrtPriceX96: sqrtPriceX96, Tick: tick, Liquidity: liquidity}
+	specified := new(big.Int).Sub(amountSpecified, remaining)
+	if zeroForOne == exactInput {
+		result.Amount0, result.Amount1 = specified, calculated
+	} else {
+		result.Amount0, result.Amount1 = calculated, specified
+	}
+	return result, nil
+}
+
+// direction returns zeroForOne and the output token of a swap from tokenIn
+func (p *V3Pool) direction(tokenIn common.Address) (bool, common.Address, error) {
+	switch tokenIn {
+	case p.Token0:
+		return true, p.Token1, nil
+	case p.Token1:
+		return false, p.Token0, nil
+	}
+	return false, common.Address{}, fmt.Errorf("%w: %s not in %s", ErrTokenNotInPool, tokenIn.Hex(), p)
+}
+
+// AmountOut returns the output token and amount of swapping amountIn of tokenIn without
+// price limit, like the calculator module's getAmountOut for V3 brands
+func (p *V3Pool) AmountOut(tokenIn common.Address, amountIn *big.Int) (common.Address, *big.Int, error) {
+	zeroForOne, tokenOut, err := p.direction(tokenIn)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	if amountIn == nil || amountIn.Sign() <= 0 {
+		return common.Address{}, nil, ErrInsufficientInputAmount
+	}
+	result, err := p.Swap(zeroForOne, amountIn, nil)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	if zeroForOne {
+		return tokenOut, new(big.Int).Neg(result.Amount1), nil
+	}
+	return tokenOut, new(big.Int).Neg(result.Amount0), nil
+}
+
+// AmountIn returns the amount of tokenIn needed to receive amountOut of the other token
+func (p *V3Pool) AmountIn(tokenIn common.Address, amountOut *big.Int) (common.Address, *big.Int, error) {
+	zeroForOne, tokenOut, err := p.direction(tokenIn)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	if amountOut == nil || amountOut.Sign() <= 0 {
+		return common.Address{}, nil, ErrInsufficientOutputAmount
+	}
+	result, err := p.Swap(zeroForOne, new(big.Int).Neg(amountOut), nil)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	amountIn, received := result.Amount0, new(big.Int).Neg(result.Amount1)
+	if !zeroForOne {
+		amountIn, received = result.Amount1, new(big.Int).Neg(result.Amount0)
+	}
+	if received.Cmp(amountOut) < 0 {
+		return common.Address{}, nil, ErrNotEnoughLiquidity
+	}
+	return tokenOut, amountIn, nil
+}
+
+// ApplySwap sets the state reported by a Swap event
+func (p *V3Pool) ApplySwap(sqrtPriceX96 *big.Int, tick int32, liquidity *big.Int) {
+	p.SqrtPriceX96, p.Tick, p.Liquidity = sqrtPriceX96, tick, liquidity
+}
+
+// ApplyMint adds amount of liquidity between tickLower and tickUpper, as a Mint event;
+// pass a negative amount for a Burn
+func (p *V3Pool) ApplyMint(tickLower, tickUpper int32, amount *big.Int) error {
+	if err := p.updateTick(tickLower, amount, false); err != nil {
+		return err
+	}
+	if err := p.updateTick(tickUpper, amount, true); err != nil {
+		return err
+	}
+	if p.Tick >= tickLower && p.Tick < tickUpper {
+		liquidity, err := addDelta(p.Liquidity, amount)
+		if err != nil {
+			return err
+		}
+		p.Liquidity = liquidity
+	}
+	return nil
+}
+
+// updateTick as Tick.update, flipping the bitmap bit of a tick that gets or loses liquidity
+func (p *V3Pool) updateTick(tick int32, delta *big.Int, upper bool) error {
+	info, ok := p.Ticks[tick]
+	if !ok {
+		info = Tick{LiquidityGross: new(big.Int), LiquidityNet: new(big.Int)}
+	}
+	gross, err := addDelta(info.LiquidityGross, delta)
+	if err != nil {
+		return err
+	}
+	net := new(big.Int)
+	if upper {
+		net.Sub(info.LiquidityNet, delta)
+	} else {
+		net.Add(info.LiquidityNet, delta)
+	}
+	flipped := (gross.Sign() == 0) != (info.LiquidityGross.Sign() == 0)
+	if gross.Sign() == 0 {
+		delete(p.Ticks, tick)
+	} else {
+		p.Ticks[tick] = Tick{LiquidityGross: gross, LiquidityNet: net}
+	}
+	if flipped {
+		// only the fetched words are tracked
+		word, bit := position(compress(tick, p.TickSpacing))
+		if bitmap, ok := p.Bitmap[word]; ok {
+			mask := new(big.Int).Lsh(common.Big1, bit)
+			p.Bitmap[word] = new(big.Int).Xor(bitmap, mask)
+		}
+	}
+	return nil
+}
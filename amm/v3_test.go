@@ -0,0 +1,254 @@
+package amm
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+
+	wsClient "github.com/0xKhennati/wsclient"
+	"github.com/0xKhennati/wsclient/helper"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// testPair is a pair of a cached pool
+type testPair struct {
+	pair, token0, token1 common.Address
+	fee                  uint32
+}
+
+func (p testPair) GetPairHex() common.Address    { return p.pair }
+func (p testPair) GetT0Hex() common.Address      { return p.token0 }
+func (p testPair) GetT1Hex() common.Address      { return p.token1 }
+func (p testPair) GetFee() uint32                { return p.fee }
+func (p testPair) GetBrand() uint8               { return 0 }
+func (p testPair) GetData() string               { return "" }
+func (p testPair) GetCurrency0() *common.Address { return nil }
+func (p testPair) GetCurrency1() *common.Address { return nil }
+
+// fullRangeTick is the widest tick of a tick spacing of 60
+const fullRangeTick = 887220
+
+func TestV3PoolSwap(t *testing.T) {
+	// 1e18 of full range liquidity and 1e18 between -600 and 600 around a price of 1
+	pool := &V3Pool{
+		Token0:       common.HexToAddress("0x01"),
+		Token1:       common.HexToAddress("0x02"),
+		Fee:          3000,
+		TickSpacing:  60,
+		SqrtPriceX96: new(big.Int).Set(q96),
+		Liquidity:    new(big.Int),
+		Ticks:        make(map[int32]Tick),
+		Bitmap:       map[int16]*big.Int{-1: new(big.Int), 0: new(big.Int), 1: new(big.Int)},
+	}
+	for _, position := range [][2]int32{{-600, 600}, {-fullRangeTick, fullRangeTick}} {
+		if err := pool.ApplyMint(position[0], position[1], big.NewInt(1e18)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// expected from the UniswapV3Pool swap loop
+	tests := []struct {
+		name          string
+		zeroForOne    bool
+		amount        *big.Int
+		wantAmount0   string
+		wantAmount1   string
+		wantPrice     string
+		wantTick      int32
+		wantLiquidity int64
+	}{
+		{"exact in zero for one", true, big.NewInt(1e18), "1000000000000000000", "-521047496345750712", "40287957544850748201791945584", -13527, 1e18},
+		{"exact in within the range", true, big.NewInt(5e17), "500000000000000000", "-348375914994647087", "53968409653731001467823478290", -7680, 1e18},
+		{"exact in one for zero", false, big.NewInt(1e18), "-521047496345750712", "1000000000000000000", "155805906228894063324298694445", 13526, 1e18},
+		{"exact out zero for one", true, big.NewInt(-1e17), "106558539891181166", "-100000000000000000", "73646777011556005468884664569", -1462, 1e18},
+		{"exact out one for zero", false, big.NewInt(-3e17), "-300000000000000000", "402362389272742465", "108598225670800262868125107624", 6306, 1e18},
+	}
+	for _, tt := range tests {
+		result, err := pool.Swap(tt.zeroForOne, tt.amount, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if result.Amount0.String() != tt.wantAmount0 || result.Amount1.String() != tt.wantAmount1 ||
+			result.SqrtPriceX96.String() != tt.wantPrice || result.Tick != tt.wantTick || result.Liquidity.Int64() != tt.wantLiquidity {
+			t.Errorf("%s = %s %s price %s tick %d liquidity %s", tt.name,
+				result.Amount0, result.Amount1, result.SqrtPriceX96, result.Tick, result.Liquidity)
+		}
+	}
+
+	// past the fetched words
+	if _, err := pool.Swap(true, ether(100), nil); !errors.Is(err, ErrMissingTickData) {
+		t.Errorf("swap past the fetched words err = %v", err)
+	}
+	if _, amountIn, err := pool.AmountIn(pool.Token0, big.NewInt(1e17)); err != nil || amountIn.String() != "106558539891181166" {
+		t.Errorf("AmountIn = %v, %v", amountIn, err)
+	}
+}
+
+// calculatorQuote is the mocked calculator module's getAmountOut of a single range pool:
+// SqrtPriceMath with the price not leaving the range, ok is false when it would
+func calculatorQuote(sqrtPriceX96, boundary, liquidity, amountIn *big.Int, fee uint32, zeroForOne bool) (*big.Int, bool) {
+	lessFee := new(big.Int).Mul(amountIn, big.NewInt(FeeDenominator-int64(fee)))
+	lessFee.Quo(lessFee, big.NewInt(FeeDenominator))
+	if lessFee.Sign() == 0 {
+		return nil, false
+	}
+	numerator := new(big.Int).Lsh(liquidity, 96)
+	ceilDiv := func(a, b *big.Int) *big.Int {
+		q, r := new(big.Int).QuoRem(a, b, new(big.Int))
+		if r.Sign() != 0 {
+			q.Add(q, common1)
+		}
+		return q
+	}
+
+	if zeroForOne {
+		// the input that reaches the boundary, rounded up
+		toBoundary := ceilDiv(ceilDiv(new(big.Int).Mul(numerator, new(big.Int).Sub(sqrtPriceX96, boundary)), sqrtPriceX96), boundary)
+		if lessFee.Cmp(toBoundary) >= 0 {
+			return nil, false
+		}
+		// L * P / (L + amount * P), rounded up
+		denominator := new(big.Int).Mul(lessFee, sqrtPriceX96)
+		denominator.Add(denominator, numerator)
+		next := ceilDiv(new(big.Int).Mul(numerator, sqrtPriceX96), denominator)
+		if next.Cmp(boundary) <= 0 {
+			return nil, false
+		}
+		// L * (P - next), rounded down
+		out := new(big.Int).Mul(liquidity, new(big.Int).Sub(sqrtPriceX96, next))
+		return out.Rsh(out, 96), true
+	}
+
+	toBoundary := ceilDiv(new(big.Int).Mul(liquidity, new(big.Int).Sub(boundary, sqrtPriceX96)), q96)
+	if lessFee.Cmp(toBoundary) >= 0 {
+		return nil, false
+	}
+	next := new(big.Int).Lsh(lessFee, 96)
+	next.Quo(next, liquidity).Add(next, sqrtPriceX96)
+	if next.Cmp(boundary) >= 0 {
+		return nil, false
+	}
+	// L * (next - P) / next / P, rounded down
+	out := new(big.Int).Mul(numerator, new(big.Int).Sub(next, sqrtPriceX96))
+	out.Quo(out, next).Quo(out, sqrtPriceX96)
+	return out, true
+}
+
+// wordBoundary returns the tick a swap from tick stops at within its bitmap word when the
+// word has no initialized tick
+func wordBoundary(tick, tickSpacing int32, zeroForOne bool) int32 {
+	compressed := compress(tick, tickSpacing)
+	if zeroForOne {
+		return (compressed >> 8 << 8) * tickSpacing
+	}
+	return ((compressed+1)>>8<<8 + 255) * tickSpacing
+}
+
+// FuzzV3AmountOut checks V3Pool.AmountOut of a pool primed from a node against the
+// calculator module's getAmountOut on the same node
+func FuzzV3AmountOut(f *testing.F) {
+	pair := testPair{common.HexToAddress("0x3a"), common.HexToAddress("0x01"), common.HexToAddress("0x02"), 3000}
+	calculator := common.HexToAddress("0xca")
+
+	var mu sync.Mutex
+	var tick int32
+	var sqrtPriceX96, liquidity *big.Int
+	var fee uint32
+	node := newStubNode(f, func(to common.Address, data []byte) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		call, err := helper.DecodeCall(data)
+		if err != nil {
+			return nil, err
+		}
+		zero := new(big.Int)
+		switch {
+		case to == pair.pair && call.RawName == "slot0":
+			return words(sqrtPriceX96, big.NewInt(int64(tick)), zero, zero, zero, zero, common1), nil
+		case to == pair.pair && call.RawName == "liquidity":
+			return words(liquidity), nil
+		case to == pair.pair && call.RawName == "tickSpacing":
+			return words(big.NewInt(60)), nil
+		case to == pair.pair && call.RawName == "fee":
+			return words(big.NewInt(int64(fee))), nil
+		case to == pair.pair && call.RawName == "tickBitmap":
+			// only the full range ticks are initialized, far from the fetched words
+			return words(zero), nil
+		case to == calculator && call.RawName == "getAmountOut":
+			tokenIn, _ := call.Args[0].Value.(common.Address)
+			amountIn, _ := call.Args[1].Value.(*big.Int)
+			zeroForOne := tokenIn == pair.token0
+			boundary, err := GetSqrtRatioAtTick(wordBoundary(tick, 60, zeroForOne))
+			if err != nil {
+				return nil, err
+			}
+			out, ok := calculatorQuote(sqrtPriceX96, boundary, liquidity, amountIn, fee, zeroForOne)
+			if !ok {
+				return nil, errors.New("execution reverted: out of range")
+			}
+			// uint256[] amounts
+			return words(big.NewInt(32), big.NewInt(2), amountIn, out), nil
+		}
+		return nil, errors.New("execution reverted")
+	})
+	client := node.dial(f)
+
+	f.Add(int32(0), uint64(1e18), uint64(1e15), uint16(3000), true)
+	f.Add(int32(-201000), uint64(3e16), uint64(1e9), uint16(500), false)
+	f.Add(int32(76012), uint64(1<<62), uint64(1<<40), uint16(10000), true)
+	f.Add(int32(-887), uint64(1e6), uint64(12345), uint16(100), false)
+	f.Fuzz(func(t *testing.T, fuzzTick int32, fuzzLiquidity, amount uint64, fuzzFee uint16, zeroForOne bool) {
+		// keep the fetched words away from the full range ticks
+		if fuzzTick < -800000 || fuzzTick > 800000 || fuzzLiquidity == 0 || amount == 0 || uint32(fuzzFee) >= FeeDenominator/10 {
+			t.Skip()
+		}
+		price, err := GetSqrtRatioAtTick(fuzzTick)
+		if err != nil {
+			t.Skip()
+		}
+		mu.Lock()
+		tick, sqrtPriceX96, liquidity, fee = fuzzTick, price, new(big.Int).SetUint64(fuzzLiquidity), uint32(fuzzFee)
+		mu.Unlock()
+
+		ctx := context.Background()
+		block := wsClient.BlockRefFromTag(wsClient.BlockLatest)
+		pools := NewPools()
+		if err := pools.PrimeV3(ctx, client, block, KindUniswapV3, 1, pair); err != nil {
+			t.Fatal(err)
+		}
+		tokenIn := pair.token1
+		if zeroForOne {
+			tokenIn = pair.token0
+		}
+		amountIn := new(big.Int).SetUint64(amount)
+		data, err := helper.Pack_calculatorMoudle_getAmountOut(tokenIn, amountIn, []helper.PairInfo{{
+			PairAddr: pair.pair, Token0: pair.token0, Token1: pair.token1, Fee: pair.fee, Data: []byte{},
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		results, err := callBatch(ctx, client, block, []call{{calculator, data}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if results[0].err != nil {
+			// the swap leaves the range the mocked calculator quotes
+			return
+		}
+		amounts, err := helper.Unpack_calculatorMoudle_getAmountOut(results[0].data)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := pools.AmountOut(tokenIn, amountIn, pair)
+		if err != nil {
+			t.Fatalf("AmountOut: %v, calculator quotes %s", err, amounts[1])
+		}
+		if got.Cmp(amounts[1]) != 0 {
+			t.Errorf("tick %d liquidity %d fee %d zeroForOne %t amountIn %d: AmountOut = %s, calculator %s",
+				fuzzTick, fuzzLiquidity, fuzzFee, zeroForOne, amount, got, amounts[1])
+		}
+	})
+}
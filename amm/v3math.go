@@ -0,0 +1,377 @@
+package amm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// Concentrated liquidity math ported from the UniswapV3 libraries (TickMath, FullMath,
+// SqrtPriceMath, SwapMath, LiquidityMath). Every rounding and overflow branch follows
+// Solidity so quotes are bit-identical to the pools.
+
+const (
+	MinTick = -887272
+	MaxTick = 887272
+)
+
+var (
+	// MinSqrtRatio and MaxSqrtRatio are the sqrt prices of MinTick and MaxTick
+	MinSqrtRatio = big.NewInt(4295128739)
+	MaxSqrtRatio = mustBig("1461446703485210103287273052203988822378723970342")
+
+	ErrTickOutOfRange      = errors.New("tick out of range")
+	ErrSqrtPriceOutOfRange = errors.New("sqrt price out of range")
+	ErrMulDivOverflow      = errors.New("mulDiv overflow")
+	ErrLiquidityUnderflow  = errors.New("liquidity underflow")
+	ErrInvalidPriceLimit   = errors.New("invalid sqrt price limit")
+	ErrSqrtPriceUnderflow  = errors.New("sqrt price underflow")
+	ErrNotEnoughLiquidity  = errors.New("not enough liquidity for the requested output")
+	errUint160Overflow     = errors.New("uint160 overflow")
+	q96                    = new(big.Int).Lsh(common1, 96)
+	maxUint160             = new(big.Int).Sub(new(big.Int).Lsh(common1, 160), common1)
+	maxUint256             = new(big.Int).Sub(new(big.Int).Lsh(common1, 256), common1)
+	common1                = big.NewInt(1)
+	bigPipsDenominator     = big.NewInt(FeeDenominator)
+	tickRatioStart         = mustBig("0x100000000000000000000000000000000")
+	tickRatioOdd           = mustBig("0xfffcb933bd6fad37aa2d162d1a594001")
+	tickRatioMultipliers   = [19]*big.Int{
+		mustBig("0xfff97272373d413259a46990580e213a"),
+		mustBig("0xfff2e50f5f656932ef12357cf3c7fdcc"),
+		mustBig("0xffe5caca7e10e4e61c3624eaa0941cd0"),
+		mustBig("0xffcb9843d60f6159c9db58835c926644"),
+		mustBig("0xff973b41fa98c081472e6896dfb254c0"),
+		mustBig("0xff2ea16466c96a3843ec78b326b52861"),
+		mustBig("0xfe5dee046a99a2a811c461f1969c3053"),
+		mustBig("0xfcbe86c7900a88aedcffc83b479aa3a4"),
+		mustBig("0xf987a7253ac413176f2b074cf7815e54"),
+		mustBig("0xf3392b0822b70005940c7a398e4b70f3"),
+		mustBig("0xe7159475a2c29b7443b29c7fa6e889d9"),
+		mustBig("0xd097f3bdfd2022b8845ad8f792aa5825"),
+		mustBig("0xa9f746462d870fdf8a65dc1f90e061e5"),
+		mustBig("0x70d869a156d2a1b890bb3df62baf32f7"),
+		mustBig("0x31be135f97d08fd981231505542fcfa6"),
+		mustBig("0x9aa508b5b7a84e1c677de54f3e99bc9"),
+		mustBig("0x5d6af8dedb81196699c329225ee604"),
+		mustBig("0x2216e584f5fa1ea926041bedfe98"),
+		mustBig("0x48a170391f7dc42444e8fa2"),
+	}
+)
+
+func mustBig(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		panic("invalid constant " + s)
+	}
+	return n
+}
+
+// GetSqrtRatioAtTick returns sqrt(1.0001^tick) * 2^96, as TickMath.getSqrtRatioAtTick
+func GetSqrtRatioAtTick(tick int32) (*big.Int, error) {
+	if tick < MinTick || tick > MaxTick {
+		return nil, fmt.Errorf("%w: %d", ErrTickOutOfRange, tick)
+	}
+	absTick := tick
+	if absTick < 0 {
+		absTick = -absTick
+	}
+	ratio := new(big.Int).Set(tickRatioStart)
+	if absTick&1 != 0 {
+		ratio.Set(tickRatioOdd)
+	}
+	for i, multiplier := range tickRatioMultipliers {
+		if absTick&(2<<i) != 0 {
+			ratio.Mul(ratio, multiplier).Rsh(ratio, 128)
+		}
+	}
+	if tick > 0 {
+		ratio.Quo(maxUint256, ratio)
+	}
+	// round up to the next Q96 value
+	sqrtPriceX96 := new(big.Int).Rsh(ratio, 32)
+	if new(big.Int).And(ratio, big.NewInt(0xffffffff)).Sign() != 0 {
+		sqrtPriceX96.Add(sqrtPriceX96, common1)
+	}
+	return sqrtPriceX96, nil
+}
+
+// GetTickAtSqrtRatio returns the greatest tick whose sqrt ratio is at most sqrtPriceX96,
+// as TickMath.getTickAtSqrtRatio
+func GetTickAtSqrtRatio(sqrtPriceX96 *big.Int) (int32, error) {
+	if sqrtPriceX96.Cmp(MinSqrtRatio) < 0 || sqrtPriceX96.Cmp(MaxSqrtRatio) >= 0 {
+		return 0, fmt.Errorf("%w: %s", ErrSqrtPriceOutOfRange, sqrtPriceX96)
+	}
+	// getSqrtRatioAtTick is monotonic, search the last tick not above the price
+	low, high := int32(MinTick), int32(MaxTick)
+	for low < high {
+		mid := low + (high-low+1)/2
+		ratio, _ := GetSqrtRatioAtTick(mid)
+		if ratio.Cmp(sqrtPriceX96) <= 0 {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+	return low, nil
+}
+
+// mulDiv returns floor(a * b / denominator), failing as FullMath.mulDiv does when the
+// result does not fit in 256 bits
+func mulDiv(a, b, denominator *big.Int) (*big.Int, error) {
+	if denominator.Sign() == 0 {
+		return nil, ErrMulDivOverflow
+	}
+	result := new(big.Int).Mul(a, b)
+	result.Quo(result, denominator)
+	if result.Cmp(maxUint256) > 0 {
+		return nil, ErrMulDivOverflow
+	}
+	return result, nil
+}
+
+// mulDivRoundingUp returns ceil(a * b / denominator), as FullMath.mulDivRoundingUp
+func mulDivRoundingUp(a, b, denominator *big.Int) (*big.Int, error) {
+	if denominator.Sign() == 0 {
+		return nil, ErrMulDivOverflow
+	}
+	product := new(big.Int).Mul(a, b)
+	result, remainder := new(big.Int).QuoRem(product, denominator, new(big.Int))
+	if remainder.Sign() != 0 {
+		result.Add(result, common1)
+	}
+	if result.Cmp(maxUint256) > 0 {
+		return nil, ErrMulDivOverflow
+	}
+	return result, nil
+}
+
+// divRoundingUp returns ceil(a / b), as UnsafeMath.divRoundingUp
+func divRoundingUp(a, b *big.Int) *big.Int {
+	result, remainder := new(big.Int).QuoRem(a, b, new(big.Int))
+	if remainder.Sign() != 0 {
+		result.Add(result, common1)
+	}
+	return result
+}
+
+func toUint160(value *big.Int) (*big.Int, error) {
+	if value.Sign() < 0 || value.Cmp(maxUint160) > 0 {
+		return nil, errUint160Overflow
+	}
+	return value, nil
+}
+
+// getNextSqrtPriceFromAmount0RoundingUp as SqrtPriceMath
+func getNextSqrtPriceFromAmount0RoundingUp(sqrtPX96, liquidity, amount *big.Int, add bool) (*big.Int, error) {
+	if amount.Sign() == 0 {
+		return sqrtPX96, nil
+	}
+	numerator1 := new(big.Int).Lsh(liquidity, 96)
+	product := new(big.Int).Mul(amount, sqrtPX96)
+	productOverflows := product.Cmp(maxUint256) > 0
+	if add {
+		if !productOverflows {
+			denominator := new(big.Int).Add(numerator1, product)
+			if denominator.Cmp(maxUint256) <= 0 {
+				return mulDivRoundingUp(numerator1, sqrtPX96, denominator)
+			}
+		}
+		denominator := new(big.Int).Quo(numerator1, sqrtPX96)
+		return divRoundingUp(numerator1, denominator.Add(denominator, amount)), nil
+	}
+	if productOverflows || numerator1.Cmp(product) <= 0 {
+		return nil, ErrSqrtPriceUnderflow
+	}
+	result, err := mulDivRoundingUp(numerator1, sqrtPX96, new(big.Int).Sub(numerator1, product))
+	if err != nil {
+		return nil, err
+	}
+	return toUint160(result)
+}
+
+// getNextSqrtPriceFromAmount1RoundingDown as SqrtPriceMath
+func getNextSqrtPriceFromAmount1RoundingDown(sqrtPX96, liquidity, amount *big.Int, add bool) (*big.Int, error) {
+	if add {
+		quotient, err := mulDiv(amount, q96, liquidity)
+		if err != nil {
+			return nil, err
+		}
+		return toUint160(quotient.Add(quotient, sqrtPX96))
+	}
+	quotient, err := mulDivRoundingUp(amount, q96, liquidity)
+	if err != nil {
+		return nil, err
+	}
+	if sqrtPX96.Cmp(quotient) <= 0 {
+		return nil, ErrSqrtPriceUnderflow
+	}
+	return quotient.Sub(sqrtPX96, quotient), nil
+}
+
+func getNextSqrtPriceFromInput(sqrtPX96, liquidity, amountIn *big.Int, zeroForOne bool) (*big.Int, error) {
+	if zeroForOne {
+		return getNextSqrtPriceFromAmount0RoundingUp(sqrtPX96, liquidity, amountIn, true)
+	}
+	return getNextSqrtPriceFromAmount1RoundingDown(sqrtPX96, liquidity, amountIn, true)
+}
+
+func getNextSqrtPriceFromOutput(sqrtPX96, liquidity, amountOut *big.Int, zeroForOne bool) (*big.Int, error) {
+	if zeroForOne {
+		return getNextSqrtPriceFromAmount1RoundingDown(sqrtPX96, liquidity, amountOut, false)
+	}
+	return getNextSqrtPriceFromAmount0RoundingUp(sqrtPX96, liquidity, amountOut, false)
+}
+
+// GetAmount0Delta returns the amount of token0 between two sqrt prices for liquidity
+func GetAmount0Delta(sqrtRatioAX96, sqrtRatioBX96, liquidity *big.Int, roundUp bool) (*big.Int, error) {
+	if sqrtRatioAX96.Cmp(sqrtRatioBX96) > 0 {
+		sqrtRatioAX96, sqrtRatioBX96 = sqrtRatioBX96, sqrtRatioAX96
+	}
+	if sqrtRatioAX96.Sign() <= 0 {
+		return nil, ErrSqrtPriceOutOfRange
+	}
+	numerator1 := new(big.Int).Lsh(liquidity, 96)
+	numerator2 := new(big.Int).Sub(sqrtRatioBX96, sqrtRatioAX96)
+	if roundUp {
+		amount, err := mulDivRoundingUp(numerator1, numerator2, sqrtRatioBX96)
+		if err != nil {
+			return nil, err
+		}
+		return divRoundingUp(amount, sqrtRatioAX96), nil
+	}
+	amount, err := mulDiv(numerator1, numerator2, sqrtRatioBX96)
+	if err != nil {
+		return nil, err
+	}
+	return amount.Quo(amount, sqrtRatioAX96), nil
+}
+
+// GetAmount1Delta returns the amount of token1 between two sqrt prices for liquidity
+func GetAmount1Delta(sqrtRatioAX96, sqrtRatioBX96, liquidity *big.Int, roundUp bool) (*big.Int, error) {
+	if sqrtRatioAX96.Cmp(sqrtRatioBX96) > 0 {
+		sqrtRatioAX96, sqrtRatioBX96 = sqrtRatioBX96, sqrtRatioAX96
+	}
+	difference := new(big.Int).Sub(sqrtRatioBX96, sqrtRatioAX96)
+	if roundUp {
+		return mulDivRoundingUp(liquidity, difference, q96)
+	}
+	return mulDiv(liquidity, difference, q96)
+}
+
+// swapStep is the result of SwapMath.computeSwapStep
+type swapStep struct {
+	sqrtRatioNextX96 *big.Int
+	amountIn         *big.Int
+	amountOut        *big.Int
+	feeAmount        *big.Int
+}
+
+// computeSwapStep as SwapMath.computeSwapStep, amountRemaining is positive for an
+// exact input and negative for an exact output
+func computeSwapStep(sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity, amountRemaining *big.Int, feePips uint32) (swapStep, error) {
+	var step swapStep
+	var err error
+	zeroForOne := sqrtRatioCurrentX96.Cmp(sqrtRatioTargetX96) >= 0
+	exactIn := amountRemaining.Sign() >= 0
+	feeComplement := big.NewInt(FeeDenominator - int64(feePips))
+
+	if exactIn {
+		amountRemainingLessFee, err := mulDiv(amountRemaining, feeComplement, bigPipsDenominator)
+		if err != nil {
+			return step, err
+		}
+		if zeroForOne {
+			step.amountIn, err = GetAmount0Delta(sqrtRatioTargetX96, sqrtRatioCurrentX96, liquidity, true)
+		} else {
+			step.amountIn, err = GetAmount1Delta(sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity, true)
+		}
+		if err != nil {
+			return step, err
+		}
+		if amountRemainingLessFee.Cmp(step.amountIn) >= 0 {
+			step.sqrtRatioNextX96 = sqrtRatioTargetX96
+		} else if step.sqrtRatioNextX96, err = getNextSqrtPriceFromInput(sqrtRatioCurrentX96, liquidity, amountRemainingLessFee, zeroForOne); err != nil {
+			return step, err
+		}
+	} else {
+		if zeroForOne {
+			step.amountOut, err = GetAmount1Delta(sqrtRatioTargetX96, sqrtRatioCurrentX96, liquidity, false)
+		} else {
+			step.amountOut, err = GetAmount0Delta(sqrtRatioCurrentX96, sqrtRatioTargetX96, liquidity, false)
+		}
+		if err != nil {
+			return step, err
+		}
+		amountOutRemaining := new(big.Int).Neg(amountRemaining)
+		if amountOutRemaining.Cmp(step.amountOut) >= 0 {
+			step.sqrtRatioNextX96 = sqrtRatioTargetX96
+		} else if step.sqrtRatioNextX96, err = getNextSqrtPriceFromOutput(sqrtRatioCurrentX96, liquidity, amountOutRemaining, zeroForOne); err != nil {
+			return step, err
+		}
+	}
+
+	max := sqrtRatioTargetX96.Cmp(step.sqrtRatioNextX96) == 0
+	if zeroForOne {
+		if !max || !exactIn {
+			if step.amountIn, err = GetAmount0Delta(step.sqrtRatioNextX96, sqrtRatioCurrentX96, liquidity, true); err != nil {
+				return step, err
+			}
+		}
+		if !max || exactIn {
+			if step.amountOut, err = GetAmount1Delta(step.sqrtRatioNextX96, sqrtRatioCurrentX96, liquidity, false); err != nil {
+				return step, err
+			}
+		}
+	} else {
+		if !max || !exactIn {
+			if step.amountIn, err = GetAmount1Delta(sqrtRatioCurrentX96, step.sqrtRatioNextX96, liquidity, true); err != nil {
+				return step, err
+			}
+		}
+		if !max || exactIn {
+			if step.amountOut, err = GetAmount0Delta(sqrtRatioCurrentX96, step.sqrtRatioNextX96, liquidity, false); err != nil {
+				return step, err
+			}
+		}
+	}
+
+	// cap the output amount to not exceed the remaining output amount
+	if !exactIn && step.amountOut.Cmp(new(big.Int).Neg(amountRemaining)) > 0 {
+		step.amountOut = new(big.Int).Neg(amountRemaining)
+	}
+	if exactIn && step.sqrtRatioNextX96.Cmp(sqrtRatioTargetX96) != 0 {
+		// the entire remaining amount is taken as a fee
+		step.feeAmount = new(big.Int).Sub(amountRemaining, step.amountIn)
+	} else if feePips == FeeDenominator {
+		// a 100% fee of Uniswap V4 pools, as the V4 SwapMath
+		step.feeAmount = new(big.Int).Set(step.amountIn)
+	} else if step.feeAmount, err = mulDivRoundingUp(step.amountIn, big.NewInt(int64(feePips)), feeComplement); err != nil {
+		return step, err
+	}
+	return step, nil
+}
+
+// addDelta as LiquidityMath.addDelta
+func addDelta(liquidity, delta *big.Int) (*big.Int, error) {
+	result := new(big.Int).Add(liquidity, delta)
+	if result.Sign() < 0 {
+		return nil, ErrLiquidityUnderflow
+	}
+	return result, nil
+}
+
+// mostSignificantBit and leastSignificantBit as BitMath, x is not zero
+func mostSignificantBit(x *big.Int) uint {
+	return uint(x.BitLen() - 1)
+}
+
+func leastSignificantBit(x *big.Int) uint {
+	words := x.Bits()
+	for i, word := range words {
+		if word != 0 {
+			return uint(i*bits.UintSize + bits.TrailingZeros(uint(word)))
+		}
+	}
+	return 0
+}
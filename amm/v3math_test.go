@@ -0,0 +1,123 @@
+package amm
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// expected values from the UniswapV3 TickMath, SqrtPriceMath and SwapMath specs
+
+func TestGetSqrtRatioAtTick(t *testing.T) {
+	tests := []struct {
+		tick int32
+		want string
+	}{
+		{MinTick, "4295128739"},
+		{MinTick + 1, "4295343490"},
+		{-1000, "75364347830767020784054125655"},
+		{-1, "79224201403219477170569942574"},
+		{0, "79228162514264337593543950336"},
+		{1, "79232123823359799118286999568"},
+		{50, "79426470787362580746886972461"},
+		{1000, "83290069058676223003182343270"},
+		{MaxTick - 1, "1461373636630004318706518188784493106690254656249"},
+		{MaxTick, "1461446703485210103287273052203988822378723970342"},
+	}
+	for _, tt := range tests {
+		got, err := GetSqrtRatioAtTick(tt.tick)
+		if err != nil {
+			t.Fatalf("GetSqrtRatioAtTick(%d): %v", tt.tick, err)
+		}
+		if got.String() != tt.want {
+			t.Errorf("GetSqrtRatioAtTick(%d) = %s, want %s", tt.tick, got, tt.want)
+		}
+		// the tick of a tick's ratio is the tick, one less is the tick below
+		if tt.tick == MaxTick {
+			continue
+		}
+		if tick, err := GetTickAtSqrtRatio(got); err != nil || tick != tt.tick {
+			t.Errorf("GetTickAtSqrtRatio(%s) = %d, %v, want %d", got, tick, err, tt.tick)
+		}
+		if tt.tick == MinTick {
+			continue
+		}
+		if tick, err := GetTickAtSqrtRatio(new(big.Int).Sub(got, common1)); err != nil || tick != tt.tick-1 {
+			t.Errorf("GetTickAtSqrtRatio(%s - 1) = %d, %v, want %d", got, tick, err, tt.tick-1)
+		}
+	}
+
+	if _, err := GetSqrtRatioAtTick(MaxTick + 1); !errors.Is(err, ErrTickOutOfRange) {
+		t.Errorf("MaxTick + 1 err = %v", err)
+	}
+	if _, err := GetTickAtSqrtRatio(MaxSqrtRatio); !errors.Is(err, ErrSqrtPriceOutOfRange) {
+		t.Errorf("MaxSqrtRatio err = %v", err)
+	}
+}
+
+// encodePriceSqrt(1, 1), encodePriceSqrt(101, 100) and encodePriceSqrt(121, 100)
+var (
+	sqrtPrice1to1     = mustBig("79228162514264337593543950336")
+	sqrtPrice101to100 = mustBig("79623317895830914510639640423")
+	sqrtPrice121to100 = mustBig("87150978765690771352898345369")
+)
+
+func TestGetAmountDelta(t *testing.T) {
+	liquidity := big.NewInt(1e18)
+	tests := []struct {
+		name  string
+		delta func(a, b, liquidity *big.Int, roundUp bool) (*big.Int, error)
+		up    string
+		down  string
+	}{
+		{"amount0", GetAmount0Delta, "90909090909090910", "90909090909090909"},
+		{"amount1", GetAmount1Delta, "100000000000000000", "99999999999999999"},
+	}
+	for _, tt := range tests {
+		for _, roundUp := range []bool{true, false} {
+			want := tt.down
+			if roundUp {
+				want = tt.up
+			}
+			// the order of the prices does not matter
+			for _, prices := range [][2]*big.Int{{sqrtPrice1to1, sqrtPrice121to100}, {sqrtPrice121to100, sqrtPrice1to1}} {
+				got, err := tt.delta(prices[0], prices[1], liquidity, roundUp)
+				if err != nil || got.String() != want {
+					t.Errorf("%s roundUp %t = %v, %v, want %s", tt.name, roundUp, got, err, want)
+				}
+			}
+		}
+	}
+}
+
+func TestComputeSwapStep(t *testing.T) {
+	liquidity := big.NewInt(2e18)
+	tests := []struct {
+		name            string
+		target          *big.Int
+		amountRemaining *big.Int
+		wantNext        string
+		wantIn          string
+		wantOut         string
+		wantFee         string
+	}{
+		{"exact in capped at target", sqrtPrice101to100, big.NewInt(1e18),
+			"79623317895830914510639640423", "9975124224178055", "9925619580021728", "5988667735148"},
+		{"exact out capped at target", sqrtPrice101to100, big.NewInt(-1e18),
+			"79623317895830914510639640423", "9975124224178055", "9925619580021728", "5988667735148"},
+		{"exact in fully spent", mustBig("250541448375047931186413801569"), big.NewInt(1e18),
+			"118818475322642227089037862318", "999400000000000000", "666399946655997866", "600000000000000"},
+	}
+	for _, tt := range tests {
+		step, err := computeSwapStep(sqrtPrice1to1, tt.target, liquidity, tt.amountRemaining, 600)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if step.sqrtRatioNextX96.String() != tt.wantNext || step.amountIn.String() != tt.wantIn ||
+			step.amountOut.String() != tt.wantOut || step.feeAmount.String() != tt.wantFee {
+			t.Errorf("%s = next %s in %s out %s fee %s, want %s %s %s %s", tt.name,
+				step.sqrtRatioNextX96, step.amountIn, step.amountOut, step.feeAmount,
+				tt.wantNext, tt.wantIn, tt.wantOut, tt.wantFee)
+		}
+	}
+}
@@ -0,0 +1,340 @@
+package amm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	wsClient "github.com/0xKhennati/wsclient"
+	"github.com/0xKhennati/wsclient/helper"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// algebraTickSpacing is the tick spacing of Algebra V1 pools without tickSpacing()
+const algebraTickSpacing = 60
+
+// SetV3 adds or replaces a concentrated liquidity pool, the cache keeps pool
+func (p *Pools) SetV3(pool *V3Pool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.v3Pools[pool.Address] = pool
+}
+
+// V3Pool returns a copy of the cached concentrated liquidity pool at address
+func (p *Pools) V3Pool(address common.Address) (*V3Pool, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	pool, ok := p.v3Pools[address]
+	if !ok {
+		return nil, false
+	}
+	return pool.Clone(), true
+}
+
+// PrimeV3 reads the state of concentrated liquidity pairs at block and caches them: the
+// price, tick, liquidity, fee and tick spacing, then the bitmap words within words of the
+// current one and the liquidity of their initialized ticks. Swaps moving the price past
+// the fetched words fail with ErrMissingTickData, see FetchTickWords.
+func (p *Pools) PrimeV3(ctx context.Context, c *wsClient.Client, block wsClient.BlockRef, kind V3Kind, words int, pairs ...helper.PairInfoInterface) error {
+	if len(pairs) == 0 {
+		return nil
+	}
+	pools, err := fetchV3State(ctx, c, block, kind, pairs)
+	if err != nil {
+		return err
+	}
+	if err := fetchTickWords(ctx, c, block, wordsAround(pools, words)); err != nil {
+		return err
+	}
+	for _, pool := range pools {
+		p.SetV3(pool)
+	}
+	return nil
+}
+
+// FetchTickWords reads more bitmap words of a cached pool at block, e.g. after a quote
+// failed with ErrMissingTickData. block must be the block the pool state is at.
+func (p *Pools) FetchTickWords(ctx context.Context, c *wsClient.Client, block wsClient.BlockRef, address common.Address, words ...int16) error {
+	pool, ok := p.V3Pool(address)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownPool, address.Hex())
+	}
+	update := func(update func(*V3Pool) error) bool { return p.updateV3(address, update) }
+	return fetchMoreTickWords(ctx, c, block, pool, update, words)
+}
+
+// fetchMoreTickWords reads words of pool and merges them into the cached pool with update
+func fetchMoreTickWords(ctx context.Context, c *wsClient.Client, block wsClient.BlockRef, pool *V3Pool, update func(func(*V3Pool) error) bool, words []int16) error {
+	// fetch into an empty pool and merge, logs may update the cached one meanwhile
+	fetched := &V3Pool{Kind: pool.Kind, Address: pool.Address, PoolID: pool.PoolID, TickSpacing: pool.TickSpacing, Ticks: make(map[int32]Tick), Bitmap: make(map[int16]*big.Int)}
+	requests := make([]wordRequest, len(words))
+	for i, word := range words {
+		requests[i] = wordRequest{fetched, word}
+	}
+	if err := fetchTickWords(ctx, c, block, requests); err != nil {
+		return err
+	}
+	if !update(func(pool *V3Pool) error {
+		for word, bitmap := range fetched.Bitmap {
+			pool.Bitmap[word] = bitmap
+		}
+		for tick, info := range fetched.Ticks {
+			pool.Ticks[tick] = info
+		}
+		return nil
+	}) {
+		return fmt.Errorf("%w: %s", ErrUnknownPool, pool)
+	}
+	return nil
+}
+
+// RefreshAlgebraFees updates the dynamic fee of cached Algebra pools with the calculator
+// module's getAlgebraFee, the fee changes with the volatility after every swap. Pools
+// that are not cached fail with ErrUnknownPool. ApplyLogs also applies the Fee events.
+func (p *Pools) RefreshAlgebraFees(ctx context.Context, c *wsClient.Client, calculator common.Address, block wsClient.BlockRef, pools ...common.Address) error {
+	calls := make([]call, len(pools))
+	for i, pool := range pools {
+		data, err := helper.Pack_calculatorMoudle_getAlgebraFee(pool)
+		if err != nil {
+			return err
+		}
+		calls[i] = call{calculator, data}
+	}
+	results, err := callBatch(ctx, c, block, calls)
+	if err != nil {
+		return err
+	}
+	for i, result := range results {
+		if result.err != nil {
+			return fmt.Errorf("failed to get the fee of %s: %w", pools[i].Hex(), result.err)
+		}
+		fee, err := helper.Unpack_calculatorMoudle_getAlgebraFee(result.data)
+		if err != nil {
+			return fmt.Errorf("failed to get the fee of %s: %w", pools[i].Hex(), err)
+		}
+		if !p.updateV3(pools[i], func(pool *V3Pool) error {
+			pool.Fee = uint32(fee)
+			return nil
+		}) {
+			return fmt.Errorf("%w: %s", ErrUnknownPool, pools[i].Hex())
+		}
+	}
+	return nil
+}
+
+// fetchV3State reads the state of pairs without tick data
+func fetchV3State(ctx context.Context, c *wsClient.Client, block wsClient.BlockRef, kind V3Kind, pairs []helper.PairInfoInterface) ([]*V3Pool, error) {
+	var packers []func() (hexutil.Bytes, error)
+	switch kind {
+	case KindUniswapV3:
+		packers = []func() (hexutil.Bytes, error){helper.Pack_uniswapv3_slot0, helper.Pack_uniswapv3_liquidity, helper.Pack_uniswapv3_tickSpacing, helper.Pack_uniswapv3_fee}
+	case KindAlgebra:
+		packers = []func() (hexutil.Bytes, error){helper.Pack_algebra_globalState, helper.Pack_algebra_liquidity, helper.Pack_algebra_tickSpacing}
+	default:
+		return nil, fmt.Errorf("unsupported pool kind %s", kind)
+	}
+	var calls []call
+	for _, pair := range pairs {
+		for _, pack := range packers {
+			data, err := pack()
+			if err != nil {
+				return nil, err
+			}
+			calls = append(calls, call{pair.GetPairHex(), data})
+		}
+	}
+	results, err := callBatch(ctx, c, block, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	pools := make([]*V3Pool, len(pairs))
+	for i, pair := range pairs {
+		pool := &V3Pool{
+			Kind:    kind,
+			Address: pair.GetPairHex(),
+			Token0:  pair.GetT0Hex(),
+			Token1:  pair.GetT1Hex(),
+			Ticks:   make(map[int32]Tick),
+			Bitmap:  make(map[int16]*big.Int),
+		}
+		if err := pool.decodeState(results[i*len(packers) : (i+1)*len(packers)]); err != nil {
+			return nil, fmt.Errorf("failed to get the state of %s: %w", pool.Address.Hex(), err)
+		}
+		pools[i] = pool
+	}
+	return pools, nil
+}
+
+// decodeState sets the state from the results of the calls of fetchV3State
+func (p *V3Pool) decodeState(results []callResult) error {
+	for i, result := range results {
+		// Algebra V1 pools before 1.9 have no tickSpacing
+		if result.err != nil && !(p.Kind == KindAlgebra && i == 2) {
+			return result.err
+		}
+	}
+	if p.Kind == KindAlgebra {
+		state, err := helper.Unpack_algebra_globalState(results[0].data)
+		if err != nil {
+			return err
+		}
+		liquidity, err := helper.Unpack_algebra_liquidity(results[1].data)
+		if err != nil {
+			return err
+		}
+		p.SqrtPriceX96, p.Tick, p.Fee, p.Liquidity = state.Price, int32(state.Tick.Int64()), uint32(state.Fee), liquidity
+		p.TickSpacing = algebraTickSpacing
+		if results[2].err == nil {
+			spacing, err := helper.Unpack_algebra_tickSpacing(results[2].data)
+			if err != nil {
+				return err
+			}
+			p.TickSpacing = int32(spacing.Int64())
+		}
+		return nil
+	}
+
+	slot0, err := helper.Unpack_uniswapv3_slot0(results[0].data)
+	if err != nil {
+		return err
+	}
+	liquidity, err := helper.Unpack_uniswapv3_liquidity(results[1].data)
+	if err != nil {
+		return err
+	}
+	spacing, err := helper.Unpack_uniswapv3_tickSpacing(results[2].data)
+	if err != nil {
+		return err
+	}
+	fee, err := helper.Unpack_uniswapv3_fee(results[3].data)
+	if err != nil {
+		return err
+	}
+	p.SqrtPriceX96, p.Tick, p.Liquidity = slot0.SqrtPriceX96, int32(slot0.Tick.Int64()), liquidity
+	p.TickSpacing, p.Fee = int32(spacing.Int64()), uint32(fee.Uint64())
+	return nil
+}
+
+// wordsAround returns the bitmap words within words of the current word of pools
+func wordsAround(pools []*V3Pool, words int) []wordRequest {
+	var requests []wordRequest
+	for _, pool := range pools {
+		center := pool.WordOf(pool.Tick)
+		for word := int(center) - words; word <= int(center)+words; word++ {
+			if word >= -1<<15 && word < 1<<15 {
+				requests = append(requests, wordRequest{pool, int16(word)})
+			}
+		}
+	}
+	return requests
+}
+
+// wordRequest is a bitmap word of a pool to fetch
+type wordRequest struct {
+	pool *V3Pool
+	word int16
+}
+
+// fetchTickWords reads bitmap words and the liquidity of their initialized ticks
+func fetchTickWords(ctx context.Context, c *wsClient.Client, block wsClient.BlockRef, requests []wordRequest) error {
+	calls := make([]call, len(requests))
+	for i, request := range requests {
+		var data hexutil.Bytes
+		var err error
+		switch request.pool.Kind {
+		case KindAlgebra:
+			data, err = helper.Pack_algebra_tickTable(request.word)
+		case KindUniswapV4:
+			data, err = helper.Pack_uniswapv4_getTickBitmap(request.pool.PoolID, request.word)
+		default:
+			data, err = helper.Pack_uniswapv3_tickBitmap(request.word)
+		}
+		if err != nil {
+			return err
+		}
+		calls[i] = call{request.pool.Address, data}
+	}
+	results, err := callBatch(ctx, c, block, calls)
+	if err != nil {
+		return err
+	}
+
+	type tickRequest struct {
+		pool *V3Pool
+		tick int32
+	}
+	var ticks []tickRequest
+	calls = calls[:0]
+	bitmaps := make([]*big.Int, len(requests))
+	for i, result := range results {
+		request := requests[i]
+		if result.err != nil {
+			return fmt.Errorf("failed to get word %d of %s: %w", request.word, request.pool, result.err)
+		}
+		// the words of every kind are a uint256
+		bitmap, err := helper.Unpack_uniswapv3_tickBitmap(result.data)
+		if err != nil {
+			return fmt.Errorf("failed to get word %d of %s: %w", request.word, request.pool, err)
+		}
+		bitmaps[i] = bitmap
+		for bit := 0; bit < 256; bit++ {
+			if bitmap.Bit(bit) == 0 {
+				continue
+			}
+			tick := (int32(request.word)<<8 + int32(bit)) * request.pool.TickSpacing
+			var data hexutil.Bytes
+			switch request.pool.Kind {
+			case KindAlgebra:
+				data, err = helper.Pack_algebra_ticks(big.NewInt(int64(tick)))
+			case KindUniswapV4:
+				data, err = helper.Pack_uniswapv4_getTickLiquidity(request.pool.PoolID, big.NewInt(int64(tick)))
+			default:
+				data, err = helper.Pack_uniswapv3_ticks(big.NewInt(int64(tick)))
+			}
+			if err != nil {
+				return err
+			}
+			ticks = append(ticks, tickRequest{request.pool, tick})
+			calls = append(calls, call{request.pool.Address, data})
+		}
+	}
+	if results, err = callBatch(ctx, c, block, calls); err != nil {
+		return err
+	}
+
+	for i, result := range results {
+		request := ticks[i]
+		if result.err != nil {
+			return fmt.Errorf("failed to get tick %d of %s: %w", request.tick, request.pool, result.err)
+		}
+		var info Tick
+		switch request.pool.Kind {
+		case KindAlgebra:
+			tick, err := helper.Unpack_algebra_ticks(result.data)
+			if err != nil {
+				return fmt.Errorf("failed to get tick %d of %s: %w", request.tick, request.pool, err)
+			}
+			info = Tick{LiquidityGross: tick.LiquidityTotal, LiquidityNet: tick.LiquidityDelta}
+		case KindUniswapV4:
+			tick, err := helper.Unpack_uniswapv4_getTickLiquidity(result.data)
+			if err != nil {
+				return fmt.Errorf("failed to get tick %d of %s: %w", request.tick, request.pool, err)
+			}
+			info = Tick{LiquidityGross: tick.LiquidityGross, LiquidityNet: tick.LiquidityNet}
+		default:
+			tick, err := helper.Unpack_uniswapv3_ticks(result.data)
+			if err != nil {
+				return fmt.Errorf("failed to get tick %d of %s: %w", request.tick, request.pool, err)
+			}
+			info = Tick{LiquidityGross: tick.LiquidityGross, LiquidityNet: tick.LiquidityNet}
+		}
+		request.pool.Ticks[request.tick] = info
+	}
+	// the words are complete once their ticks are known
+	for i, request := range requests {
+		request.pool.Bitmap[request.word] = bitmaps[i]
+	}
+	return nil
+}
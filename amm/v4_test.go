@@ -0,0 +1,207 @@
+package amm
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+
+	wsClient "github.com/0xKhennati/wsclient"
+	"github.com/0xKhennati/wsclient/helper"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// v4PoolManager is the mainnet PoolManager, the pair address of every V4 pool
+var v4PoolManager = common.HexToAddress("0x000000000004444c5dc75cB358380D2e3dE08A90")
+
+// v4TestPair is a pair of a Uniswap V4 pool knowing its pool key
+type v4TestPair struct {
+	key helper.PoolKey
+}
+
+func (p v4TestPair) GetPairHex() common.Address    { return v4PoolManager }
+func (p v4TestPair) GetT0Hex() common.Address      { return p.key.Currency0 }
+func (p v4TestPair) GetT1Hex() common.Address      { return p.key.Currency1 }
+func (p v4TestPair) GetFee() uint32                { return p.key.Fee }
+func (p v4TestPair) GetBrand() uint8               { return uint8(helper.BrandUniswapV4) }
+func (p v4TestPair) GetData() string               { return "" }
+func (p v4TestPair) GetCurrency0() *common.Address { return nil }
+func (p v4TestPair) GetCurrency1() *common.Address { return nil }
+func (p v4TestPair) GetPoolKey() helper.PoolKey    { return p.key }
+
+// v4State is the StateView of a pool with the liquidity of TestV3PoolSwap: 1e18 of full
+// range liquidity and 1e18 between -600 and 600 around a price of 1
+type v4State struct {
+	mu          sync.Mutex
+	id          common.Hash
+	sqrtPrice   *big.Int
+	protocolFee int64
+	calls       int
+}
+
+func (s *v4State) call(t testing.TB) func(to common.Address, data []byte) ([]byte, error) {
+	return func(to common.Address, data []byte) ([]byte, error) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.calls++
+		call, err := helper.DecodeCall(data)
+		if err != nil {
+			return nil, err
+		}
+		if id, _ := call.Args[0].Value.([32]byte); common.Hash(id) != s.id {
+			return nil, errors.New("execution reverted: unknown pool")
+		}
+		zero := new(big.Int)
+		switch call.RawName {
+		case "getSlot0":
+			return words(s.sqrtPrice, zero, big.NewInt(s.protocolFee), big.NewInt(3000)), nil
+		case "getLiquidity":
+			return words(ether(2)), nil
+		case "getTickBitmap":
+			bitmap := new(big.Int)
+			switch word, _ := call.Args[1].Value.(int16); word {
+			case -1:
+				bitmap.SetBit(bitmap, 246, 1) // -600
+			case 0:
+				bitmap.SetBit(bitmap, 10, 1) // 600
+			case fullRangeTick / 60 >> 8:
+				bitmap.SetBit(bitmap, fullRangeTick/60&0xff, 1)
+			}
+			return words(bitmap), nil
+		case "getTickLiquidity":
+			tick, _ := call.Args[1].Value.(*big.Int)
+			switch tick.Int64() {
+			case -600:
+				return words(ether(1), ether(1)), nil
+			case 600, fullRangeTick:
+				return words(ether(1), new(big.Int).Neg(ether(1))), nil
+			}
+			t.Errorf("getTickLiquidity of tick %s that is not initialized", tick)
+			return words(zero, zero), nil
+		}
+		return nil, errors.New("execution reverted")
+	}
+}
+
+func TestPrimeV4(t *testing.T) {
+	stateView := common.HexToAddress("0x7ffe42c4a5deea5b0fec41c94c136cf115597227")
+	key, err := helper.NewPoolKey(common.HexToAddress("0x02"), helper.NativeCurrency, 3000, 60, common.Address{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := key.ID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pair := v4TestPair{key}
+	state := &v4State{id: id, sqrtPrice: new(big.Int).Set(q96)}
+	client := newStubNode(t, state.call(t)).dial(t)
+
+	ctx := context.Background()
+	block := wsClient.BlockRefFromTag(wsClient.BlockLatest)
+	pools := NewPools()
+	if err := pools.PrimeV4(ctx, client, block, stateView, 1, pair); err != nil {
+		t.Fatal(err)
+	}
+	pool, ok := pools.V4Pool(id)
+	if !ok {
+		t.Fatal("the pool was not cached")
+	}
+	if pool.Kind != KindUniswapV4 || pool.Token0 != helper.NativeCurrency || pool.Fee != 3000 || pool.Liquidity.Cmp(ether(2)) != 0 ||
+		len(pool.Bitmap) != 3 || len(pool.Ticks) != 2 {
+		t.Errorf("primed pool = %+v", pool)
+	}
+
+	// the same quotes as the V3 pool of TestV3PoolSwap, native ETH is currency0
+	if got, err := pools.AmountOut(helper.NativeCurrency, ether(1), pair); err != nil || got.String() != "521047496345750712" {
+		t.Errorf("AmountOut = %v, %v, want 521047496345750712", got, err)
+	}
+	if amounts, err := pools.AmountsIn(helper.NativeCurrency, big.NewInt(1e17), pair); err != nil || amounts[0].String() != "106558539891181166" {
+		t.Errorf("AmountsIn = %v, %v, want 106558539891181166", amounts, err)
+	}
+
+	// past the fetched words until FetchV4TickWords reads the full range tick
+	if _, err := pools.AmountOut(common.HexToAddress("0x02"), ether(100), pair); !errors.Is(err, ErrMissingTickData) {
+		t.Errorf("AmountOut past the fetched words err = %v", err)
+	}
+	if err := pools.FetchV4TickWords(ctx, client, block, id, fullRangeTick/60>>8); err != nil {
+		t.Fatal(err)
+	}
+	if pool, _ := pools.V4Pool(id); pool.Ticks[fullRangeTick].LiquidityNet.Cmp(new(big.Int).Neg(ether(1))) != 0 {
+		t.Errorf("full range tick = %+v", pool.Ticks[fullRangeTick])
+	}
+	if err := pools.FetchV4TickWords(ctx, client, block, common.HexToHash("0x01"), 0); !errors.Is(err, ErrUnknownPool) {
+		t.Errorf("FetchV4TickWords of an uncached pool err = %v", err)
+	}
+}
+
+func TestPrimeV4ProtocolFee(t *testing.T) {
+	key, err := helper.NewPoolKey(common.HexToAddress("0x01"), common.HexToAddress("0x02"), 3000, 60, common.Address{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _ := key.ID()
+	// 0.1% of the zeroForOne swaps, none of the others
+	state := &v4State{id: id, sqrtPrice: new(big.Int).Set(q96), protocolFee: 1000}
+	client := newStubNode(t, state.call(t)).dial(t)
+	pools := NewPools()
+	if err := pools.PrimeV4(context.Background(), client, wsClient.BlockRefFromTag(wsClient.BlockLatest), common.HexToAddress("0x57a7e"), 1, v4TestPair{key}); err != nil {
+		t.Fatal(err)
+	}
+	pool, _ := pools.V4Pool(id)
+	// ProtocolFeeLibrary.calculateSwapFee: 1000 + 3000 - 1000 * 3000 / 1e6
+	if fee := pool.swapFee(true); fee != 3997 {
+		t.Errorf("zeroForOne swap fee = %d, want 3997", fee)
+	}
+	if fee := pool.swapFee(false); fee != 3000 {
+		t.Errorf("oneForZero swap fee = %d, want 3000", fee)
+	}
+
+	// a V3 pool charging the swap fee quotes the same
+	v3 := pool.Clone()
+	v3.Kind, v3.Fee = KindUniswapV3, 3997
+	want, err := v3.Swap(true, ether(1), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := pools.AmountOut(key.Currency0, ether(1), v4TestPair{key})
+	if err != nil || got.Cmp(new(big.Int).Neg(want.Amount1)) != 0 {
+		t.Errorf("AmountOut = %v, %v, want %s", got, err, new(big.Int).Neg(want.Amount1))
+	}
+}
+
+func TestPrimeV4Rejects(t *testing.T) {
+	hooked, err := helper.NewPoolKey(common.HexToAddress("0x01"), common.HexToAddress("0x02"), 3000, 60, common.HexToAddress("0x4444"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	uninitialized, _ := helper.NewPoolKey(common.HexToAddress("0x01"), common.HexToAddress("0x02"), 500, 10, common.Address{})
+	id, _ := uninitialized.ID()
+	state := &v4State{id: id, sqrtPrice: new(big.Int)}
+	client := newStubNode(t, state.call(t)).dial(t)
+
+	ctx := context.Background()
+	block := wsClient.BlockRefFromTag(wsClient.BlockLatest)
+	pools := NewPools()
+	if err := pools.PrimeV4(ctx, client, block, common.HexToAddress("0x57a7e"), 1, v4TestPair{hooked}); !errors.Is(err, ErrHookedPool) {
+		t.Errorf("hooked pool err = %v, want ErrHookedPool", err)
+	}
+	state.mu.Lock()
+	if state.calls != 0 {
+		t.Errorf("a hooked pool was read with %d calls", state.calls)
+	}
+	state.mu.Unlock()
+	if err := pools.PrimeV4(ctx, client, block, common.HexToAddress("0x57a7e"), 1, v4TestPair{uninitialized}); err == nil {
+		t.Error("an uninitialized pool was primed")
+	}
+	if _, ok := pools.V4Pool(id); ok {
+		t.Error("an uninitialized pool was cached")
+	}
+
+	// a V4 pair of a pool that is not cached is not quoted as another pool
+	pools.SetV4(&V3Pool{Kind: KindUniswapV4, PoolID: common.HexToHash("0x01")})
+	if _, err := pools.AmountOut(common.HexToAddress("0x01"), ether(1), v4TestPair{uninitialized}); !errors.Is(err, ErrUnknownPool) {
+		t.Errorf("uncached V4 pool err = %v, want ErrUnknownPool", err)
+	}
+}
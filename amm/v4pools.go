@@ -0,0 +1,167 @@
+package amm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	wsClient "github.com/0xKhennati/wsclient"
+	"github.com/0xKhennati/wsclient/helper"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrHookedPool is returned for Uniswap V4 pools with hooks, their swaps run the hooks
+// and cannot be quoted from the pool state
+var ErrHookedPool = errors.New("pool has hooks")
+
+// SetV4 adds or replaces a Uniswap V4 pool, the cache keeps pool
+func (p *Pools) SetV4(pool *V3Pool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.v4Pools[pool.PoolID] = pool
+}
+
+// V4Pool returns a copy of the cached Uniswap V4 pool with pool id id
+func (p *Pools) V4Pool(id common.Hash) (*V3Pool, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	pool, ok := p.v4Pools[id]
+	if !ok {
+		return nil, false
+	}
+	return pool.Clone(), true
+}
+
+// RemoveV4 drops the Uniswap V4 pool with pool id id
+func (p *Pools) RemoveV4(id common.Hash) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.v4Pools, id)
+}
+
+// updateV4 applies update to a cached Uniswap V4 pool as updateV3
+func (p *Pools) updateV4(id common.Hash, update func(*V3Pool) error) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pool, ok := p.v4Pools[id]
+	if !ok {
+		return false
+	}
+	pool = pool.Clone()
+	if err := update(pool); err != nil {
+		delete(p.v4Pools, id)
+		return false
+	}
+	p.v4Pools[id] = pool
+	return true
+}
+
+// v4PoolID returns the pool id of a pair of a Uniswap V4 brand, ok is false for the
+// pairs of other brands
+func v4PoolID(pair helper.PairInfoInterface) (common.Hash, bool, error) {
+	info, err := helper.Brands().Lookup(helper.Brand(pair.GetBrand()))
+	if err != nil || info.Family != helper.FamilyUniswapV4 {
+		return common.Hash{}, false, nil
+	}
+	key, err := helper.PoolKeyOf(pair)
+	if err != nil {
+		return common.Hash{}, true, fmt.Errorf("pair %s: %w", pair.GetPairHex().Hex(), err)
+	}
+	id, err := key.ID()
+	return id, true, err
+}
+
+// PrimeV4 reads the state of the Uniswap V4 pools of pairs at block from stateView and
+// caches them as PrimeV3 does. The pool key of each pair is read with helper.PoolKeyOf,
+// pools with hooks fail with ErrHookedPool. Native ETH is the zero address currency.
+func (p *Pools) PrimeV4(ctx context.Context, c *wsClient.Client, block wsClient.BlockRef, stateView common.Address, words int, pairs ...helper.PairInfoInterface) error {
+	if len(pairs) == 0 {
+		return nil
+	}
+	pools := make([]*V3Pool, len(pairs))
+	var calls []call
+	for i, pair := range pairs {
+		key, err := helper.PoolKeyOf(pair)
+		if err != nil {
+			return fmt.Errorf("pair %s: %w", pair.GetPairHex().Hex(), err)
+		}
+		id, err := key.ID()
+		if err != nil {
+			return err
+		}
+		if !key.Hookless() {
+			return fmt.Errorf("%w: %s hooks %s", ErrHookedPool, id.Hex(), key.Hooks.Hex())
+		}
+		slot0, err := helper.BuildUniswapV4GetSlot0CallData(id)
+		if err != nil {
+			return err
+		}
+		liquidity, err := helper.BuildUniswapV4GetLiquidityCallData(id)
+		if err != nil {
+			return err
+		}
+		calls = append(calls, call{stateView, slot0}, call{stateView, liquidity})
+		pools[i] = &V3Pool{
+			Kind:        KindUniswapV4,
+			Address:     stateView,
+			PoolID:      id,
+			Token0:      key.Currency0,
+			Token1:      key.Currency1,
+			TickSpacing: key.TickSpacing,
+			Ticks:       make(map[int32]Tick),
+			Bitmap:      make(map[int16]*big.Int),
+		}
+	}
+	results, err := callBatch(ctx, c, block, calls)
+	if err != nil {
+		return err
+	}
+	for i, pool := range pools {
+		if err := pool.decodeV4State(results[2*i], results[2*i+1]); err != nil {
+			return fmt.Errorf("failed to get the state of %s: %w", pool, err)
+		}
+	}
+	if err := fetchTickWords(ctx, c, block, wordsAround(pools, words)); err != nil {
+		return err
+	}
+	for _, pool := range pools {
+		p.SetV4(pool)
+	}
+	return nil
+}
+
+// decodeV4State sets the state from the getSlot0 and getLiquidity results of PrimeV4
+func (p *V3Pool) decodeV4State(slot0Result, liquidityResult callResult) error {
+	if slot0Result.err != nil {
+		return slot0Result.err
+	}
+	if liquidityResult.err != nil {
+		return liquidityResult.err
+	}
+	slot0, err := helper.DecodeUniswapV4Slot0(slot0Result.data)
+	if err != nil {
+		return err
+	}
+	if slot0.SqrtPriceX96.Sign() == 0 {
+		return fmt.Errorf("pool is not initialized")
+	}
+	liquidity, err := helper.Unpack_uniswapv4_getLiquidity(liquidityResult.data)
+	if err != nil {
+		return err
+	}
+	p.SqrtPriceX96, p.Tick, p.Liquidity = slot0.SqrtPriceX96, int32(slot0.Tick.Int64()), liquidity
+	p.Fee, p.ProtocolFee = uint32(slot0.LpFee.Uint64()), uint32(slot0.ProtocolFee.Uint64())
+	return nil
+}
+
+// FetchV4TickWords reads more bitmap words of a cached Uniswap V4 pool at block, as
+// FetchTickWords
+func (p *Pools) FetchV4TickWords(ctx context.Context, c *wsClient.Client, block wsClient.BlockRef, id common.Hash, words ...int16) error {
+	pool, ok := p.V4Pool(id)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownPool, id.Hex())
+	}
+	update := func(update func(*V3Pool) error) bool { return p.updateV4(id, update) }
+	return fetchMoreTickWords(ctx, c, block, pool, update, words)
+}
@@ -0,0 +1,254 @@
+package wsClient
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BlockTag names a block relative to the chain head
+type BlockTag string
+
+const (
+	BlockLatest    BlockTag = "latest"
+	BlockPending   BlockTag = "pending"
+	BlockSafe      BlockTag = "safe"
+	BlockFinalized BlockTag = "finalized"
+	BlockEarliest  BlockTag = "earliest"
+)
+
+// Negative block numbers used by go-ethereum for the named tags
+const (
+	pendingBlockNumber   = -1
+	latestBlockNumber    = -2
+	finalizedBlockNumber = -3
+	safeBlockNumber      = -4
+)
+
+// valid reports whether t is one of the named tags
+func (t BlockTag) valid() bool {
+	switch t {
+	case BlockLatest, BlockPending, BlockSafe, BlockFinalized, BlockEarliest:
+		return true
+	}
+	return false
+}
+
+// BlockRef references a block by tag, by number, or by hash as defined in EIP-1898
+// The zero value references the latest block
+type BlockRef struct {
+	tag              BlockTag
+	number           *big.Int
+	hash             *common.Hash
+	requireCanonical bool
+}
+
+// BlockRefFromTag references a named block, e.g. BlockFinalized
+func BlockRefFromTag(tag BlockTag) BlockRef {
+	return BlockRef{tag: tag}
+}
+
+// BlockRefFromNumber references a block by number
+func BlockRefFromNumber(number uint64) BlockRef {
+	return BlockRef{number: new(big.Int).SetUint64(number)}
+}
+
+// BlockRefFromHash references a block by hash (EIP-1898)
+// with requireCanonical the node rejects the call if the block is not in the canonical chain,
+// pinning a batch of calls to one hash keeps them consistent across reorgs
+func BlockRefFromHash(hash common.Hash, requireCanonical bool) BlockRef {
+	return BlockRef{hash: &hash, requireCanonical: requireCanonical}
+}
+
+// ParseBlockRef converts the legacy block arguments to a BlockRef:
+// nil, BlockRef, BlockTag, tag or hex strings, signed and unsigned integers
+// (go-ethereum negative numbers map to the named tags), *big.Int and common.Hash
+func ParseBlockRef(block any) (BlockRef, error) {
+	switch b := block.(type) {
+	case nil:
+		return BlockRef{}, nil
+	case BlockRef:
+		return b, b.Validate()
+	case *BlockRef:
+		if b == nil {
+			return BlockRef{}, nil
+		}
+		return *b, b.Validate()
+	case BlockTag:
+		ref := BlockRefFromTag(b)
+		return ref, ref.Validate()
+	case string:
+		return parseBlockString(b)
+	case common.Hash:
+		return BlockRefFromHash(b, false), nil
+	case *common.Hash:
+		if b == nil {
+			return BlockRef{}, nil
+		}
+		return BlockRefFromHash(*b, false), nil
+	case *big.Int:
+		if b == nil {
+			return BlockRef{}, nil
+		}
+		if b.IsInt64() && b.Sign() < 0 {
+			return blockRefFromInt(b.Int64())
+		}
+		if b.Sign() < 0 {
+			return BlockRef{}, fmt.Errorf("invalid block number: %s", b)
+		}
+		return BlockRef{number: new(big.Int).Set(b)}, nil
+	case hexutil.Uint64:
+		return BlockRefFromNumber(uint64(b)), nil
+	case uint64:
+		return BlockRefFromNumber(b), nil
+	case uint32:
+		return BlockRefFromNumber(uint64(b)), nil
+	case uint16:
+		return BlockRefFromNumber(uint64(b)), nil
+	case uint8:
+		return BlockRefFromNumber(uint64(b)), nil
+	case uint:
+		return BlockRefFromNumber(uint64(b)), nil
+	case int64:
+		return blockRefFromInt(b)
+	case int32:
+		return blockRefFromInt(int64(b))
+	case int16:
+		return blockRefFromInt(int64(b))
+	case int8:
+		return blockRefFromInt(int64(b))
+	case int:
+		return blockRefFromInt(int64(b))
+	}
+	return BlockRef{}, fmt.Errorf("invalid block number type: %T", block)
+}
+
+// blockRefFromInt maps go-ethereum's negative block numbers to tags
+func blockRefFromInt(number int64) (BlockRef, error) {
+	switch {
+	case number >= 0:
+		return BlockRefFromNumber(uint64(number)), nil
+	case number == pendingBlockNumber:
+		return BlockRefFromTag(BlockPending), nil
+	case number == latestBlockNumber:
+		return BlockRefFromTag(BlockLatest), nil
+	case number == finalizedBlockNumber:
+		return BlockRefFromTag(BlockFinalized), nil
+	case number == safeBlockNumber:
+		return BlockRefFromTag(BlockSafe), nil
+	}
+	return BlockRef{}, fmt.Errorf("invalid block number: %d", number)
+}
+
+// parseBlockString parses a tag, a hex block number or a 32-byte block hash
+func parseBlockString(s string) (BlockRef, error) {
+	if s == "" {
+		return BlockRef{}, nil
+	}
+	if tag := BlockTag(strings.ToLower(s)); tag.valid() {
+		return BlockRefFromTag(tag), nil
+	}
+	if len(s) == 66 && strings.HasPrefix(s, "0x") {
+		raw, err := hexutil.Decode(s)
+		if err != nil {
+			return BlockRef{}, fmt.Errorf("invalid block hash %q: %w", s, err)
+		}
+		return BlockRefFromHash(common.BytesToHash(raw), false), nil
+	}
+	number, err := hexutil.DecodeBig(s)
+	if err != nil {
+		return BlockRef{}, fmt.Errorf("invalid block %q: %w", s, err)
+	}
+	return BlockRef{number: number}, nil
+}
+
+// Validate reports an error for an unknown tag
+func (b BlockRef) Validate() error {
+	if b.hash == nil && b.number == nil && b.tag != "" && !b.tag.valid() {
+		return fmt.Errorf("invalid block tag: %q", b.tag)
+	}
+	return nil
+}
+
+// Tag returns the block tag, latest for the zero value, empty for number and hash refs
+func (b BlockRef) Tag() BlockTag {
+	if b.hash != nil || b.number != nil {
+		return ""
+	}
+	if b.tag == "" {
+		return BlockLatest
+	}
+	return b.tag
+}
+
+// Number returns the block number, nil for tag and hash refs
+func (b BlockRef) Number() *big.Int {
+	if b.number == nil {
+		return nil
+	}
+	return new(big.Int).Set(b.number)
+}
+
+// Hash returns the block hash and whether the ref is a hash ref
+func (b BlockRef) Hash() (common.Hash, bool) {
+	if b.hash == nil {
+		return common.Hash{}, false
+	}
+	return *b.hash, true
+}
+
+// IsHash reports whether the ref is an EIP-1898 hash ref
+func (b BlockRef) IsHash() bool {
+	return b.hash != nil
+}
+
+// param returns the JSON-RPC param for the ref: a tag, a hex number or an EIP-1898 object
+func (b BlockRef) param() any {
+	switch {
+	case b.hash != nil:
+		return blockHashParam{BlockHash: *b.hash, RequireCanonical: b.requireCanonical}
+	case b.number != nil:
+		return (*hexutil.Big)(b.number).String()
+	}
+	return string(b.Tag())
+}
+
+// numberParam returns the param for methods that only accept a tag or a number
+func (b BlockRef) numberParam() (string, error) {
+	if err := b.Validate(); err != nil {
+		return "", err
+	}
+	if b.hash != nil {
+		return "", fmt.Errorf("block hash %s is not accepted here, use a number or a tag", b.hash.Hex())
+	}
+	return b.param().(string), nil
+}
+
+// blockHashParam is the EIP-1898 block parameter
+type blockHashParam struct {
+	BlockHash        common.Hash `json:"blockHash"`
+	RequireCanonical bool        `json:"requireCanonical,omitempty"`
+}
+
+// MarshalJSON encodes the ref as a JSON-RPC block parameter
+func (b BlockRef) MarshalJSON() ([]byte, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(b.param())
+}
+
+// String returns a string representation of the ref
+func (b BlockRef) String() string {
+	switch {
+	case b.hash != nil:
+		return fmt.Sprintf("BlockRef{Hash: %s, RequireCanonical: %t}", b.hash.Hex(), b.requireCanonical)
+	case b.number != nil:
+		return fmt.Sprintf("BlockRef{Number: %s}", b.number)
+	}
+	return fmt.Sprintf("BlockRef{Tag: %s}", b.Tag())
+}
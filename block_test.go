@@ -0,0 +1,89 @@
+package wsClient
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestBlockRefJSON(t *testing.T) {
+	hash := common.HexToHash("0x21c67e77068de97969ba93d4aab21826d33ca12bb9f565d8496e8fda8a82ca27")
+	tests := []struct {
+		name    string
+		block   any
+		want    string
+		wantErr bool
+	}{
+		{"nil", nil, `"latest"`, false},
+		{"tag", BlockFinalized, `"finalized"`, false},
+		{"tag string", "SAFE", `"safe"`, false},
+		{"hex string", "0x10", `"0x10"`, false},
+		{"uint64", uint64(255), `"0xff"`, false},
+		{"zero", 0, `"0x0"`, false},
+		{"pending", int64(-1), `"pending"`, false},
+		{"latest", -2, `"latest"`, false},
+		{"finalized", big.NewInt(-3), `"finalized"`, false},
+		{"safe", int32(-4), `"safe"`, false},
+		{"big", new(big.Int).Lsh(big.NewInt(1), 70), `"0x400000000000000000"`, false},
+		{"hash", hash, `{"blockHash":"` + hash.Hex() + `"}`, false},
+		{"hash string", hash.Hex(), `{"blockHash":"` + hash.Hex() + `"}`, false},
+		{"canonical", BlockRefFromHash(hash, true), `{"blockHash":"` + hash.Hex() + `","requireCanonical":true}`, false},
+		{"bad tag", BlockTag("head"), "", true},
+		{"bad number", -5, "", true},
+		{"bad big", big.NewInt(-5), "", true},
+		{"bad string", "0xzz", "", true},
+		{"bad type", 1.5, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := ParseBlockRef(tt.block)
+			if err == nil {
+				var out []byte
+				out, err = json.Marshal(ref)
+				if err == nil && string(out) != tt.want {
+					t.Errorf("marshal = %s, want %s", out, tt.want)
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildRequestGetBlock(t *testing.T) {
+	hash := common.HexToHash("0x01")
+	tests := []struct {
+		name    string
+		request func() (*Request, error)
+		want    string
+	}{
+		{"number", func() (*Request, error) { return BuildRequestGetBlockByNumber(16, false), nil }, `"eth_getBlockByNumber",["0x10",false]`},
+		// numbers are formatted as they are, tags go through BlockRef
+		{"negative number", func() (*Request, error) { return BuildRequestGetBlockByNumber(-2, true), nil }, `"eth_getBlockByNumber",["0x-2",true]`},
+		{"tag", func() (*Request, error) { return BuildRequestGetBlock(BlockRefFromTag(BlockLatest), true) }, `"eth_getBlockByNumber",["latest",true]`},
+		{"ref", func() (*Request, error) { return BuildRequestGetBlock(BlockRefFromNumber(16), false) }, `"eth_getBlockByNumber",["0x10",false]`},
+		{"hash", func() (*Request, error) { return BuildRequestGetBlock(BlockRefFromHash(hash, true), false) }, `"eth_getBlockByHash",["` + hash.Hex() + `",false]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := tt.request()
+			if err != nil {
+				t.Fatal(err)
+			}
+			params, err := json.Marshal(request.Params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := `"` + request.Method + `",` + string(params); got != tt.want {
+				t.Errorf("request = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := BuildRequestGetBalanceAt(common.Address{}, BlockRefFromTag("head")); err == nil {
+		t.Error("invalid tag accepted")
+	}
+}
@@ -21,7 +21,21 @@ func Build_eth_call_request(id int64, callMsg CallMsg, stateOverrides map[common
 	return NewRequest(id, "eth_call", params)
 }
 
+// Build_eth_call_request_at creates an eth_call request pinned to block
+// pass the same BlockRefFromHash to every call of a simulation batch to read one consistent state
+func Build_eth_call_request_at(id int64, callMsg CallMsg, stateOverrides map[common.Address]StateOverride, block BlockRef) (*Request, error) {
+	if err := block.Validate(); err != nil {
+		return nil, err
+	}
+	params := []interface{}{callMsg, block.param()}
+	if stateOverrides != nil {
+		params = append(params, stateOverrides)
+	}
+	return NewRequest(id, "eth_call", params), nil
+}
+
 // BuildStateDiff builds StateDiff to update holder balance in token
+// slot is the Solidity balance mapping slot, see DiscoverBalanceSlot and BuildBalanceOverride
 func BuildStateDiff(tokenContract, holder common.Address, slot int64, newBalance *big.Int) (map[common.Address]StateOverride, error) {
 	stateOverrides := make(map[common.Address]StateOverride)
 	if newBalance == nil {
@@ -104,6 +118,14 @@ func Build_MultiCall_request(id int64, args []CallMsg, blockNumber ...any) *Requ
 	return NewRequest(id, "eth_multiCall", []interface{}{args, buildBlockNumber(blockNumber)})
 }
 
+// MultiCall pinned to block, see Build_MultiCall_request
+func Build_MultiCall_request_at(id int64, args []CallMsg, block BlockRef) (*Request, error) {
+	if err := block.Validate(); err != nil {
+		return nil, err
+	}
+	return NewRequest(id, "eth_multiCall", []interface{}{args, block.param()}), nil
+}
+
 // GetTransactionLog to get logs for transaction still not mined
 // we can apply this transaction in latest or in pendnig state
 // if use the pendnig state, only use the cash pending state
@@ -113,6 +135,14 @@ func Build_GetTransactionLog_request(id int64, args CallMsg, blockNumber ...any)
 	return NewRequest(id, "eth_getTransactionLog", []interface{}{args, buildBlockNumber(blockNumber)})
 }
 
+// GetTransactionLog pinned to block, see Build_GetTransactionLog_request
+func Build_GetTransactionLog_request_at(id int64, args CallMsg, block BlockRef) (*Request, error) {
+	if err := block.Validate(); err != nil {
+		return nil, err
+	}
+	return NewRequest(id, "eth_getTransactionLog", []interface{}{args, block.param()}), nil
+}
+
 // SendRawTransactions to send multi raws of transactions direct to the conneted peers.
 func Build_SendRawTransactions_request(id int64, args []hexutil.Bytes) *Request {
 	return NewRequest(id, "eth_sendRawTransactions", []interface{}{args})
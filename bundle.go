@@ -0,0 +1,286 @@
+package wsClient
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// FlashbotsSignatureHeader is the header relays authenticate searchers with
+const FlashbotsSignatureHeader = "X-Flashbots-Signature"
+
+// SendBundleArgs is the param of eth_sendBundle
+type SendBundleArgs struct {
+	Txs               []hexutil.Bytes `json:"txs"`         // signed raw transactions
+	BlockNumber       hexutil.Uint64  `json:"blockNumber"` // block the bundle is valid for
+	MinTimestamp      uint64          `json:"minTimestamp,omitempty"`
+	MaxTimestamp      uint64          `json:"maxTimestamp,omitempty"`
+	RevertingTxHashes []common.Hash   `json:"revertingTxHashes,omitempty"` // txs allowed to revert
+	ReplacementUUID   string          `json:"replacementUuid,omitempty"`   // lets eth_cancelBundle or a later bundle replace this one
+	Builders          []string        `json:"builders,omitempty"`
+}
+
+// CallBundleArgs is the param of eth_callBundle
+// StateBlockNumber is the state the bundle is simulated on, the zero value is latest
+type CallBundleArgs struct {
+	Txs              []hexutil.Bytes `json:"txs"`
+	BlockNumber      hexutil.Uint64  `json:"blockNumber"`
+	StateBlockNumber BlockRef        `json:"stateBlockNumber"`
+	Timestamp        uint64          `json:"timestamp,omitempty"`
+	Coinbase         *common.Address `json:"coinbase,omitempty"`
+	GasLimit         uint64          `json:"gasLimit,omitempty"`
+	BaseFee          *hexutil.Big    `json:"baseFee,omitempty"`
+}
+
+// CancelBundleArgs is the param of eth_cancelBundle
+type CancelBundleArgs struct {
+	ReplacementUUID string `json:"replacementUuid"`
+}
+
+// MevSendBundleArgs is the param of mev_sendBundle (MEV-Share)
+type MevSendBundleArgs struct {
+	Version   string             `json:"version"` // "v0.1"
+	Inclusion MevBundleInclusion `json:"inclusion"`
+	Body      []MevBundleBody    `json:"body"`
+	Validity  *MevBundleValidity `json:"validity,omitempty"`
+	Privacy   *MevBundlePrivacy  `json:"privacy,omitempty"`
+	Metadata  *MevBundleMetadata `json:"metadata,omitempty"`
+}
+
+// MevBundleInclusion is the block range a MEV-Share bundle is valid for
+type MevBundleInclusion struct {
+	Block    hexutil.Uint64  `json:"block"`
+	MaxBlock *hexutil.Uint64 `json:"maxBlock,omitempty"`
+}
+
+// MevBundleBody is one element of a MEV-Share bundle: a signed tx, the hash of a
+// matched pending tx, or a nested bundle
+type MevBundleBody struct {
+	Tx        *hexutil.Bytes     `json:"tx,omitempty"`
+	CanRevert bool               `json:"canRevert,omitempty"`
+	Hash      *common.Hash       `json:"hash,omitempty"`
+	Bundle    *MevSendBundleArgs `json:"bundle,omitempty"`
+}
+
+// MevBundleValidity sets the refunds of a MEV-Share bundle
+type MevBundleValidity struct {
+	Refund       []MevBundleRefund       `json:"refund,omitempty"`
+	RefundConfig []MevBundleRefundConfig `json:"refundConfig,omitempty"`
+}
+
+// MevBundleRefund gives Percent of the bundle profit to the sender of body BodyIdx
+type MevBundleRefund struct {
+	BodyIdx int `json:"bodyIdx"`
+	Percent int `json:"percent"`
+}
+
+// MevBundleRefundConfig splits the refund of the bundle between addresses
+type MevBundleRefundConfig struct {
+	Address common.Address `json:"address"`
+	Percent int            `json:"percent"`
+}
+
+// MevBundlePrivacy selects the hints shared with searchers and the builders allowed
+type MevBundlePrivacy struct {
+	Hints    []string `json:"hints,omitempty"` // calldata, contract_address, logs, function_selector, hash...
+	Builders []string `json:"builders,omitempty"`
+}
+
+// MevBundleMetadata identifies the origin of a MEV-Share bundle
+type MevBundleMetadata struct {
+	OriginID string `json:"originId,omitempty"`
+}
+
+// NewMevSendBundleArgs creates a v0.1 MEV-Share bundle of signed txs valid for block
+func NewMevSendBundleArgs(block uint64, txs ...hexutil.Bytes) MevSendBundleArgs {
+	args := MevSendBundleArgs{
+		Version:   "v0.1",
+		Inclusion: MevBundleInclusion{Block: hexutil.Uint64(block)},
+	}
+	for i := range txs {
+		args.Body = append(args.Body, MevBundleBody{Tx: &txs[i]})
+	}
+	return args
+}
+
+// Build_eth_sendBundle_request creates an eth_sendBundle request
+func Build_eth_sendBundle_request(id int64, args SendBundleArgs) (*Request, error) {
+	if len(args.Txs) == 0 {
+		return nil, fmt.Errorf("bundle has no transactions")
+	}
+	if args.BlockNumber == 0 {
+		return nil, fmt.Errorf("bundle block number is not set")
+	}
+	return NewRequest(id, "eth_sendBundle", []interface{}{args}), nil
+}
+
+// Build_eth_callBundle_request creates an eth_callBundle request simulating the bundle
+// on top of StateBlockNumber
+func Build_eth_callBundle_request(id int64, args CallBundleArgs) (*Request, error) {
+	if len(args.Txs) == 0 {
+		return nil, fmt.Errorf("bundle has no transactions")
+	}
+	if _, err := args.StateBlockNumber.numberParam(); err != nil {
+		return nil, fmt.Errorf("invalid stateBlockNumber: %w", err)
+	}
+	return NewRequest(id, "eth_callBundle", []interface{}{args}), nil
+}
+
+// Build_eth_cancelBundle_request creates an eth_cancelBundle request for the bundles
+// sent with replacementUUID
+func Build_eth_cancelBundle_request(id int64, replacementUUID string) (*Request, error) {
+	if replacementUUID == "" {
+		return nil, fmt.Errorf("replacement uuid is not set")
+	}
+	return NewRequest(id, "eth_cancelBundle", []interface{}{CancelBundleArgs{ReplacementUUID: replacementUUID}}), nil
+}
+
+// Build_mev_sendBundle_request creates a mev_sendBundle request
+func Build_mev_sendBundle_request(id int64, args MevSendBundleArgs) (*Request, error) {
+	if len(args.Body) == 0 {
+		return nil, fmt.Errorf("bundle has no body")
+	}
+	if args.Version == "" {
+		args.Version = "v0.1"
+	}
+	return NewRequest(id, "mev_sendBundle", []interface{}{args}), nil
+}
+
+// FlashbotsSignature signs body for the X-Flashbots-Signature header:
+// the key signs the hex keccak256 of the body as an EIP-191 personal message,
+// the header value is "<address>:<signature>"
+func FlashbotsSignature(key *ecdsa.PrivateKey, body []byte) (string, error) {
+	hash := crypto.Keccak256Hash(body).Hex()
+	signature, err := crypto.Sign(accounts.TextHash([]byte(hash)), key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign payload: %w", err)
+	}
+	return crypto.PubkeyToAddress(key.PublicKey).Hex() + ":" + hexutil.Encode(signature), nil
+}
+
+// VerifyFlashbotsSignature checks an X-Flashbots-Signature header against body and
+// returns the signer
+func VerifyFlashbotsSignature(header string, body []byte) (common.Address, error) {
+	address, signatureHex, ok := strings.Cut(header, ":")
+	if !ok || !common.IsHexAddress(address) {
+		return common.Address{}, fmt.Errorf("malformed signature header %q", header)
+	}
+	signature, err := hexutil.Decode(signatureHex)
+	if err != nil || len(signature) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("malformed signature %q", signatureHex)
+	}
+	hash := crypto.Keccak256Hash(body).Hex()
+	publicKey, err := crypto.SigToPub(accounts.TextHash([]byte(hash)), signature)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to recover signer: %w", err)
+	}
+	signer := crypto.PubkeyToAddress(*publicKey)
+	if signer != common.HexToAddress(address) {
+		return common.Address{}, fmt.Errorf("signature is from %s, header claims %s", signer.Hex(), address)
+	}
+	return signer, nil
+}
+
+// DecimalBig is an integer relays encode as a decimal string, hex strings and
+// JSON numbers are accepted too
+type DecimalBig big.Int
+
+// UnmarshalJSON implements json.Unmarshaler
+func (d *DecimalBig) UnmarshalJSON(b []byte) error {
+	s := string(bytes.Trim(b, `"`))
+	value, ok := new(big.Int), false
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		value, ok = value.SetString(s[2:], 16)
+	} else {
+		value, ok = value.SetString(s, 10)
+	}
+	if !ok {
+		return fmt.Errorf("invalid integer %s", b)
+	}
+	*d = DecimalBig(*value)
+	return nil
+}
+
+// MarshalJSON encodes the value as a decimal string
+func (d DecimalBig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.ToInt().String())
+}
+
+// ToInt returns the value as a *big.Int
+func (d *DecimalBig) ToInt() *big.Int {
+	if d == nil {
+		return new(big.Int)
+	}
+	return (*big.Int)(d)
+}
+
+// SendBundleResult is the result of eth_sendBundle and mev_sendBundle
+type SendBundleResult struct {
+	BundleHash common.Hash `json:"bundleHash"`
+}
+
+// CallBundleResult is the result of eth_callBundle
+type CallBundleResult struct {
+	BundleGasPrice    *DecimalBig          `json:"bundleGasPrice"`
+	BundleHash        common.Hash          `json:"bundleHash"`
+	CoinbaseDiff      *DecimalBig          `json:"coinbaseDiff"`
+	EthSentToCoinbase *DecimalBig          `json:"ethSentToCoinbase"`
+	GasFees           *DecimalBig          `json:"gasFees"`
+	Results           []CallBundleTxResult `json:"results"`
+	StateBlockNumber  uint64               `json:"stateBlockNumber"`
+	TotalGasUsed      uint64               `json:"totalGasUsed"`
+}
+
+// CallBundleTxResult is the simulation result of one transaction of the bundle
+// Value holds the return data, Error and Revert are set when the transaction failed
+type CallBundleTxResult struct {
+	CoinbaseDiff      *DecimalBig     `json:"coinbaseDiff"`
+	EthSentToCoinbase *DecimalBig     `json:"ethSentToCoinbase"`
+	FromAddress       common.Address  `json:"fromAddress"`
+	ToAddress         *common.Address `json:"toAddress"`
+	GasFees           *DecimalBig     `json:"gasFees"`
+	GasPrice          *DecimalBig     `json:"gasPrice"`
+	GasUsed           uint64          `json:"gasUsed"`
+	TxHash            common.Hash     `json:"txHash"`
+	Value             hexutil.Bytes   `json:"value,omitempty"`
+	Error             string          `json:"error,omitempty"`
+	Revert            string          `json:"revert,omitempty"` // raw revert data
+}
+
+// Failed reports whether the transaction reverted or errored
+func (r *CallBundleTxResult) Failed() bool {
+	return r.Error != "" || r.Revert != ""
+}
+
+// RevertError decodes the revert of the transaction against abis, nil if it succeeded
+func (r *CallBundleTxResult) RevertError(abis ...*abi.ABI) *RevertError {
+	if !r.Failed() {
+		return nil
+	}
+	revertErr := DecodeRevertData([]byte(r.Revert), abis...)
+	if revertErr.Reason == "" && revertErr.Custom == nil && revertErr.PanicCode == nil {
+		revertErr.Reason = strings.TrimPrefix(strings.TrimPrefix(r.Error, "execution reverted"), ": ")
+		revertErr.Sentinel = lookupRevertReason(revertErr.Reason)
+	}
+	return revertErr
+}
+
+// Failure returns the index and result of the first failed transaction, -1 and nil if
+// the whole bundle succeeded
+func (r *CallBundleResult) Failure() (int, *CallBundleTxResult) {
+	for i := range r.Results {
+		if r.Results[i].Failed() {
+			return i, &r.Results[i]
+		}
+	}
+	return -1, nil
+}
@@ -0,0 +1,122 @@
+package wsClient
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// FromEthereumCallMsg converts a go-ethereum CallMsg to a CallMsg ready for JSON-RPC
+// zero values are left out so the node applies its own defaults
+func FromEthereumCallMsg(msg ethereum.CallMsg) CallMsg {
+	callMsg := CallMsg{
+		To:    msg.To,
+		Data:  hexutil.Bytes(msg.Data),
+		Input: hexutil.Bytes(msg.Data),
+	}
+
+	// Only include fields if they're not zero values
+	if msg.From != (common.Address{}) {
+		from := msg.From
+		callMsg.From = &from
+	}
+	if msg.Gas != 0 {
+		gas := hexutil.Uint64(msg.Gas)
+		callMsg.Gas = &gas
+	}
+	if msg.GasPrice != nil {
+		callMsg.GasPrice = (*hexutil.Big)(msg.GasPrice)
+	}
+	if msg.GasFeeCap != nil {
+		callMsg.GasFeeCap = (*hexutil.Big)(msg.GasFeeCap)
+	}
+	if msg.GasTipCap != nil {
+		callMsg.GasTipCap = (*hexutil.Big)(msg.GasTipCap)
+	}
+	if msg.Value != nil && msg.Value.Sign() > 0 {
+		callMsg.Value = (*hexutil.Big)(msg.Value)
+	}
+	if len(msg.AccessList) > 0 {
+		accessList := msg.AccessList
+		callMsg.AccessList = &accessList
+	}
+	return callMsg
+}
+
+// ToEthereumCallMsg converts the CallMsg to a go-ethereum CallMsg
+// Input is used when Data is empty; nonce, blob and chain fields have no
+// counterpart in ethereum.CallMsg and are dropped
+func (msg CallMsg) ToEthereumCallMsg() ethereum.CallMsg {
+	callMsg := ethereum.CallMsg{
+		To:        msg.To,
+		GasPrice:  (*hexutil.Big).ToInt(msg.GasPrice),
+		GasFeeCap: (*hexutil.Big).ToInt(msg.GasFeeCap),
+		GasTipCap: (*hexutil.Big).ToInt(msg.GasTipCap),
+		Value:     (*hexutil.Big).ToInt(msg.Value),
+		Data:      msg.Data,
+	}
+	if len(callMsg.Data) == 0 {
+		callMsg.Data = msg.Input
+	}
+	if msg.From != nil {
+		callMsg.From = *msg.From
+	}
+	if msg.Gas != nil {
+		callMsg.Gas = uint64(*msg.Gas)
+	}
+	if msg.AccessList != nil {
+		callMsg.AccessList = *msg.AccessList
+	}
+	return callMsg
+}
+
+// WithInput returns a copy of the CallMsg carrying the payload in both "data" and "input"
+func (msg CallMsg) WithInput() CallMsg {
+	if len(msg.Input) == 0 {
+		msg.Input = msg.Data
+	}
+	if len(msg.Data) == 0 {
+		msg.Data = msg.Input
+	}
+	return msg
+}
+
+// MarshalJSON sends the payload as both "data" and "input", geth reads "input" first
+// and older nodes only "data". Data wins when both are set, so a CallMsg whose Data was
+// replaced after FromEthereumCallMsg never sends a stale Input.
+func (msg CallMsg) MarshalJSON() ([]byte, error) {
+	type callMsg CallMsg
+	if len(msg.Data) != 0 {
+		msg.Input = msg.Data
+	} else {
+		msg.Data = msg.Input
+	}
+	return json.Marshal(callMsg(msg))
+}
+
+// WithType returns a copy of the CallMsg simulated as the given tx type,
+// e.g. types.AccessListTxType, types.DynamicFeeTxType or types.BlobTxType
+func (msg CallMsg) WithType(txType uint8) CallMsg {
+	t := hexutil.Uint64(txType)
+	msg.Type = &t
+	return msg
+}
+
+// TxType returns the tx type to simulate: the explicit Type, otherwise the
+// type implied by the populated fields
+func (msg CallMsg) TxType() uint8 {
+	switch {
+	case msg.Type != nil:
+		return uint8(*msg.Type)
+	case len(msg.BlobHashes) > 0 || msg.MaxFeePerBlobGas != nil:
+		return types.BlobTxType
+	case msg.GasFeeCap != nil || msg.GasTipCap != nil:
+		return types.DynamicFeeTxType
+	case msg.AccessList != nil:
+		return types.AccessListTxType
+	}
+	return types.LegacyTxType
+}
@@ -0,0 +1,65 @@
+package wsClient
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestCallMsgConversion(t *testing.T) {
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	msg := ethereum.CallMsg{
+		From:       common.HexToAddress("0x2222222222222222222222222222222222222222"),
+		To:         &to,
+		Gas:        21000,
+		GasFeeCap:  big.NewInt(2),
+		Value:      big.NewInt(0),
+		Data:       []byte{0xde, 0xad},
+		AccessList: types.AccessList{{Address: to, StorageKeys: []common.Hash{{1}}}},
+	}
+	callMsg := FromEthereumCallMsg(msg)
+	if callMsg.Value != nil {
+		t.Error("zero value was kept")
+	}
+	if callMsg.TxType() != types.DynamicFeeTxType {
+		t.Errorf("TxType() = %d", callMsg.TxType())
+	}
+	out, err := json.Marshal(callMsg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"from":"0x2222222222222222222222222222222222222222","to":"0x1111111111111111111111111111111111111111","gas":"0x5208","gasFeeCap":"0x2","data":"0xdead","accessList":[{"address":"0x1111111111111111111111111111111111111111","storageKeys":["0x0100000000000000000000000000000000000000000000000000000000000000"]}],"input":"0xdead"}`
+	if string(out) != want {
+		t.Errorf("marshal =\n%s\nwant\n%s", out, want)
+	}
+
+	back := callMsg.ToEthereumCallMsg()
+	if back.From != msg.From || back.Gas != msg.Gas || string(back.Data) != string(msg.Data) || len(back.AccessList) != 1 {
+		t.Errorf("round trip = %+v", back)
+	}
+}
+
+func TestCallMsgInput(t *testing.T) {
+	// Data replaced after the conversion wins over the converted Input
+	callMsg := FromEthereumCallMsg(ethereum.CallMsg{Data: []byte{1}})
+	callMsg.Data = []byte{2}
+	out, err := json.Marshal(callMsg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"data":"0x02","input":"0x02"}`; string(out) != want {
+		t.Errorf("marshal = %s, want %s", out, want)
+	}
+
+	out, err = json.Marshal(CallMsg{Input: []byte{3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"data":"0x03","input":"0x03"}`; string(out) != want {
+		t.Errorf("marshal = %s, want %s", out, want)
+	}
+}
@@ -1,19 +1,46 @@
 package wsClient
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"sync"
 	"sync/atomic"
 
 	"github.com/gorilla/websocket"
 )
 
 // Client represents a WebSocket client for JSON-RPC communication
+// One reader goroutine per connection routes each response to the channel of the
+// request id waiting for it and each notification to its subscription; frames nobody
+// waits for are queued for Receive in arrival order
 type Client struct {
 	conn    *websocket.Conn
 	counter int32 // Counter for pending messages
 	url     string
+	writeMu sync.Mutex // gorilla/websocket allows one concurrent writer
+
+	mu            sync.Mutex             // guards conn and the routing state below
+	reader        *connReader            // reader of conn
+	pending       map[int64]pendingCall  // response channels by request id
+	abandoned     map[int64]struct{}     // ids of cancelled calls, their responses are dropped
+	subscriptions map[string]*frameQueue // notification queues by subscription id
+	inbox         *frameQueue            // frames no call or subscription waits for
+}
+
+// connReader tracks the reader goroutine of one connection
+type connReader struct {
+	done chan struct{} // closed when the reader exits
+	err  error         // why the reader exited, set before done is closed
+}
+
+// pendingCall is the response channel of a request id
+// the response of a subscribe request registers notifications under the subscription id
+type pendingCall struct {
+	ch            chan *bytes.Buffer
+	notifications *frameQueue
 }
 
 // NewClient creates a new WebSocket client
@@ -28,15 +55,159 @@ func NewClient(wsURL string) (*Client, error) {
 		return nil, fmt.Errorf("failed to connect: %w", err)
 	}
 
-	return &Client{
-		conn: conn,
-		url:  wsURL,
-	}, nil
+	c := &Client{url: wsURL}
+	c.start(conn)
+	return c, nil
+}
+
+// start resets the routing state and starts the reader of conn
+func (c *Client) start(conn *websocket.Conn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.inbox != nil {
+		c.inbox.release()
+	}
+	c.conn = conn
+	c.reader = &connReader{done: make(chan struct{})}
+	c.pending = make(map[int64]pendingCall)
+	c.abandoned = make(map[int64]struct{})
+	c.subscriptions = make(map[string]*frameQueue)
+	c.inbox = newFrameQueue()
+	go c.readLoop(conn, c.reader)
+}
+
+// readLoop reads the frames of conn and routes them until the connection fails or is closed
+func (c *Client) readLoop(conn *websocket.Conn, r *connReader) {
+	for {
+		_, reader, err := conn.NextReader()
+		if err == nil {
+			buf := getBuffer()
+			if _, err = buf.ReadFrom(reader); err == nil {
+				c.route(conn, buf)
+				continue
+			}
+			putBuffer(buf)
+		}
+
+		c.mu.Lock()
+		if c.conn != conn {
+			err = fmt.Errorf("connection is closed")
+		} else {
+			err = fmt.Errorf("failed to read message: %w", err)
+		}
+		c.mu.Unlock()
+		r.err = err
+		close(r.done)
+		return
+	}
+}
+
+// route forwards a frame to the call or subscription waiting for it, or to the inbox
+func (c *Client) route(conn *websocket.Conn, buf *bytes.Buffer) {
+	var env Envelope
+	scanErr := ScanEnvelope(buf.Bytes(), &env)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case c.conn != conn:
+		// the connection was replaced while the frame was read
+		putBuffer(buf)
+		return
+	case scanErr != nil:
+		// Receive reports the malformed frame
+	case bytes.Equal(env.Method, subscriptionMethod):
+		var params struct {
+			Subscription string `json:"subscription"`
+		}
+		if json.Unmarshal(env.Params, &params) == nil {
+			if q, ok := c.subscriptions[params.Subscription]; ok {
+				if q == nil {
+					// cancelled, waiting for the eth_unsubscribe response
+					putBuffer(buf)
+				} else {
+					q.push(buf)
+				}
+				return
+			}
+		}
+	default:
+		if call, ok := c.pending[env.ID]; ok {
+			delete(c.pending, env.ID)
+			if call.notifications != nil && env.Error == nil {
+				// register before the next frame, notifications may follow right away
+				var subscriptionID string
+				if json.Unmarshal(env.Result, &subscriptionID) == nil {
+					c.subscriptions[subscriptionID] = call.notifications
+				}
+			}
+			call.ch <- buf
+			return
+		}
+		if _, ok := c.abandoned[env.ID]; ok {
+			delete(c.abandoned, env.ID)
+			atomic.AddInt32(&c.counter, -1)
+			putBuffer(buf)
+			return
+		}
+	}
+	c.inbox.push(buf)
+}
+
+// register routes the responses of ids to ch and returns the reader to wait on
+// ch must have room for every response
+func (c *Client) register(ids []int64, ch chan *bytes.Buffer, notifications *frameQueue) (*connReader, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return nil, fmt.Errorf("connection is closed")
+	}
+	for _, id := range ids {
+		if _, ok := c.pending[id]; ok {
+			return nil, fmt.Errorf("request id %d is already pending", id)
+		}
+	}
+	for _, id := range ids {
+		c.pending[id] = pendingCall{ch: ch, notifications: notifications}
+	}
+	return c.reader, nil
+}
+
+// abandon unregisters the ids of an unfinished call, the first sent of them were sent
+// responses already routed to ch are released, the reader drops the later ones
+func (c *Client) abandon(ids []int64, sent int, ch chan *bytes.Buffer) {
+	c.mu.Lock()
+	for i, id := range ids {
+		if call, ok := c.pending[id]; !ok || call.ch != ch {
+			continue
+		}
+		delete(c.pending, id)
+		if i < sent {
+			c.abandoned[id] = struct{}{}
+		}
+	}
+	c.mu.Unlock()
+
+	for {
+		select {
+		case buf := <-ch:
+			atomic.AddInt32(&c.counter, -1)
+			putBuffer(buf)
+		default:
+			return
+		}
+	}
 }
 
 // Send sends a request without waiting for response and increments counter
 func (c *Client) Send(request *Request) error {
-	if c.conn == nil {
+	c.mu.Lock()
+	conn := c.conn
+	c.mu.Unlock()
+	if conn == nil {
 		return fmt.Errorf("connection is closed")
 	}
 
@@ -45,7 +216,9 @@ func (c *Client) Send(request *Request) error {
 		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	err = c.conn.WriteMessage(websocket.TextMessage, data)
+	c.writeMu.Lock()
+	err = conn.WriteMessage(websocket.TextMessage, data)
+	c.writeMu.Unlock()
 	if err != nil {
 		return fmt.Errorf("failed to send message: %w", err)
 	}
@@ -56,22 +229,100 @@ func (c *Client) Send(request *Request) error {
 
 // Receive receives a response and decrements counter
 func (c *Client) Receive(response any) error {
-	if c.conn == nil {
-		return fmt.Errorf("connection is closed")
+	buf, err := c.readMessage()
+	if err != nil {
+		return err
 	}
+	defer putBuffer(buf)
 
-	_, data, err := c.conn.ReadMessage()
-	if err != nil {
-		return fmt.Errorf("failed to read message: %w", err)
+	if err := json.Unmarshal(buf.Bytes(), response); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return nil
+}
+
+// readMessage waits for the next frame no call or subscription waits for and
+// decrements counter; the caller must release the buffer with putBuffer
+func (c *Client) readMessage() (*bytes.Buffer, error) {
+	c.mu.Lock()
+	conn, inbox, reader := c.conn, c.inbox, c.reader
+	c.mu.Unlock()
+	if conn == nil {
+		return nil, fmt.Errorf("connection is closed")
 	}
 
+	buf, err := inbox.next(context.Background(), reader)
+	if err != nil {
+		return nil, err
+	}
 	atomic.AddInt32(&c.counter, -1)
+	return buf, nil
+}
 
-	if err := json.Unmarshal(data, response); err != nil {
-		return fmt.Errorf("failed to unmarshal response: %w", err)
+// ReceiveEnvelope receives a response and passes its scanned envelope to fn
+// the envelope slices are only valid while fn runs
+func (c *Client) ReceiveEnvelope(fn func(env *Envelope) error) error {
+	buf, err := c.readMessage()
+	if err != nil {
+		return err
 	}
+	defer putBuffer(buf)
 
-	return nil
+	var env Envelope
+	if err := ScanEnvelope(buf.Bytes(), &env); err != nil {
+		return err
+	}
+	return fn(&env)
+}
+
+// ReceiveAmount receives an eth_call style response straight into amount,
+// reusing its memory, and returns the response id
+// an error response is returned as *RPCError
+func (c *Client) ReceiveAmount(amount *BigInt, opts DecodeOptions) (int64, error) {
+	buf, err := c.readMessage()
+	if err != nil {
+		return 0, err
+	}
+	defer putBuffer(buf)
+
+	var env Envelope
+	if err := ScanEnvelope(buf.Bytes(), &env); err != nil {
+		return 0, err
+	}
+	if env.Error != nil {
+		return env.ID, decodeRPCError(env.Error)
+	}
+	return env.ID, amount.DecodeRawOptions(env.Result, opts)
+}
+
+// ReceiveAmounts receives a response holding a list of words straight into amounts,
+// reusing its memory, and returns the response id; set opts.Dynamic for a uint256[] result
+// an error response is returned as *RPCError
+func (c *Client) ReceiveAmounts(amounts *BigIntSlice, opts DecodeOptions) (int64, error) {
+	buf, err := c.readMessage()
+	if err != nil {
+		return 0, err
+	}
+	defer putBuffer(buf)
+
+	var env Envelope
+	if err := ScanEnvelope(buf.Bytes(), &env); err != nil {
+		return 0, err
+	}
+	if env.Error != nil {
+		return env.ID, decodeRPCError(env.Error)
+	}
+	return env.ID, amounts.DecodeRawOptions(env.Result, opts)
+}
+
+// decodeRPCError unmarshals a raw "error" member
+func decodeRPCError(raw []byte) error {
+	rpcErr := new(RPCError)
+	if err := json.Unmarshal(raw, rpcErr); err != nil {
+		return fmt.Errorf("failed to unmarshal error: %w", err)
+	}
+	return rpcErr
 }
 
 // SendAndReceive sends a request and receives response
@@ -82,19 +333,111 @@ func (c *Client) SendAndReceive(request *Request, response any) error {
 	return c.Receive(response)
 }
 
+// Call sends request, waits for its response and unmarshals the result into result
+// Calls are routed by request id, so they run concurrently with each other, with
+// subscriptions and with Send/Receive users of the connection. The deadline or
+// cancellation of ctx abandons the call; the connection stays usable and its late
+// response is dropped. An error response is returned as *RPCError.
+func (c *Client) Call(ctx context.Context, request *Request, result any) error {
+	responses, err := c.CallBatch(ctx, []*Request{request})
+	if err != nil {
+		return err
+	}
+	response := responses[0]
+
+	if response.Error != nil {
+		return response.Error
+	}
+	if result == nil || len(response.Result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+	return nil
+}
+
+// CallBatch pipelines requests on the connection: it sends them all, then collects one
+// response per request, returned in the order of requests. The node works on them
+// concurrently. Request ids must be unique among pending calls; the same rules as Call apply.
+func (c *Client) CallBatch(ctx context.Context, requests []*Request) ([]*Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, len(requests))
+	index := make(map[int64]int, len(requests))
+	for i, request := range requests {
+		if _, ok := index[request.ID]; ok {
+			return nil, fmt.Errorf("duplicate request id %d in batch", request.ID)
+		}
+		index[request.ID] = i
+		ids[i] = request.ID
+	}
+	ch := make(chan *bytes.Buffer, len(requests))
+	reader, err := c.register(ids, ch, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i, request := range requests {
+		if err := c.Send(request); err != nil {
+			c.abandon(ids, i, ch)
+			return nil, err
+		}
+	}
+
+	responses := make([]*Response, len(requests))
+	receive := func(buf *bytes.Buffer) error {
+		defer putBuffer(buf)
+		atomic.AddInt32(&c.counter, -1)
+		response := new(Response)
+		if err := json.Unmarshal(buf.Bytes(), response); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		responses[index[response.ID]] = response
+		return nil
+	}
+	for received := 0; received < len(requests); received++ {
+		var err error
+		select {
+		case buf := <-ch:
+			err = receive(buf)
+		case <-ctx.Done():
+			err = ctx.Err()
+		case <-reader.done:
+			// responses routed before the connection failed are still valid
+			select {
+			case buf := <-ch:
+				err = receive(buf)
+			default:
+				err = reader.err
+			}
+		}
+		if err != nil {
+			c.abandon(ids, len(ids), ch)
+			return nil, err
+		}
+	}
+	return responses, nil
+}
+
 // PendingCounter returns the number of pending messages
 func (c *Client) PendingCounter() int32 {
 	return atomic.LoadInt32(&c.counter)
 }
 
 // Close closes the WebSocket connection
+// calls waiting for a response fail with "connection is closed"
 func (c *Client) Close() error {
-	if c.conn == nil {
+	c.mu.Lock()
+	conn := c.conn
+	c.conn = nil
+	c.mu.Unlock()
+	if conn == nil {
 		return nil
 	}
 
-	err := c.conn.Close()
-	c.conn = nil
+	err := conn.Close()
 	atomic.StoreInt32(&c.counter, 0)
 	return err
 }
@@ -106,6 +449,8 @@ func (c *Client) GetURL() string {
 
 // IsConnected checks if the client is connected
 func (c *Client) IsConnected() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	return c.conn != nil
 }
 
@@ -113,9 +458,7 @@ func (c *Client) IsConnected() bool {
 func (c *Client) CheckAndReopenConnection() error {
 	if c.PendingCounter() > 0 {
 		// Close existing connection
-		if c.conn != nil {
-			c.conn.Close()
-		}
+		c.Close()
 
 		// Reset counter
 		atomic.StoreInt32(&c.counter, 0)
@@ -126,7 +469,7 @@ func (c *Client) CheckAndReopenConnection() error {
 			return fmt.Errorf("failed to reopen connection: %w", err)
 		}
 
-		c.conn = conn
+		c.start(conn)
 	}
 	return nil
 }
@@ -0,0 +1,305 @@
+package wsClient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// stubNode is a WebSocket JSON-RPC node answering each request with handle
+type stubNode struct {
+	server  *httptest.Server
+	writeMu sync.Mutex
+}
+
+// newStubNode starts a node; handle runs on the read loop of the connection and
+// answers through write, which may also be called later from other goroutines
+// the node drops the connection on a stub_drop request
+func newStubNode(t *testing.T, handle func(request *Request, write func(message string))) *stubNode {
+	t.Helper()
+	node := new(stubNode)
+	upgrader := websocket.Upgrader{}
+	node.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		write := func(message string) {
+			node.writeMu.Lock()
+			defer node.writeMu.Unlock()
+			conn.WriteMessage(websocket.TextMessage, []byte(message))
+		}
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var request Request
+			if err := json.Unmarshal(data, &request); err != nil || request.Method == "stub_drop" {
+				return
+			}
+			handle(&request, write)
+		}
+	}))
+	t.Cleanup(node.server.Close)
+	return node
+}
+
+// dial connects a client to the node
+func (n *stubNode) dial(t *testing.T) *Client {
+	t.Helper()
+	client, err := NewClient("ws" + strings.TrimPrefix(n.server.URL, "http"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// result formats a response with a JSON result
+func result(id int64, value string) string {
+	return fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":%s}`, id, value)
+}
+
+func TestCallBatchRoutesByID(t *testing.T) {
+	var mu sync.Mutex
+	var held []*Request
+	node := newStubNode(t, func(request *Request, write func(string)) {
+		mu.Lock()
+		defer mu.Unlock()
+		held = append(held, request)
+		if len(held) < 3 {
+			return
+		}
+		// an unrelated response and a notification come first, then the answers in reverse
+		write(result(999999, `"0xdead"`))
+		write(`{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0x9","result":"0x1"}}`)
+		for i := len(held) - 1; i >= 0; i-- {
+			write(result(held[i].ID, fmt.Sprintf(`"%s"`, held[i].Method)))
+		}
+		held = nil
+	})
+	client := node.dial(t)
+
+	requests := []*Request{NewRequest(0, "a", nil), NewRequest(0, "b", nil), NewRequest(0, "c", nil)}
+	responses, err := client.CallBatch(context.Background(), requests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, response := range responses {
+		if response.ID != requests[i].ID || string(response.Result) != `"`+requests[i].Method+`"` {
+			t.Errorf("response %d = %s", i, response)
+		}
+	}
+
+	// the frames nobody waited for are kept for Receive, in order
+	var unclaimed Response
+	if err := client.Receive(&unclaimed); err != nil || unclaimed.ID != 999999 {
+		t.Errorf("Receive = %s, %v", &unclaimed, err)
+	}
+	var notification map[string]any
+	if err := client.Receive(&notification); err != nil || notification["method"] != "eth_subscription" {
+		t.Errorf("Receive = %v, %v", notification, err)
+	}
+}
+
+func TestCallConcurrent(t *testing.T) {
+	node := newStubNode(t, func(request *Request, write func(string)) {
+		go func() {
+			time.Sleep(time.Duration(request.ID%5) * time.Millisecond)
+			write(result(request.ID, fmt.Sprintf("%d", request.ID)))
+		}()
+	})
+	client := node.dial(t)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			request := NewRequest(0, "echo", nil)
+			var id int64
+			if err := client.Call(context.Background(), request, &id); err != nil {
+				errs <- err
+			} else if id != request.ID {
+				errs <- fmt.Errorf("request %d got the response of %d", request.ID, id)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	if pending := client.PendingCounter(); pending != 0 {
+		t.Errorf("PendingCounter = %d", pending)
+	}
+}
+
+func TestCallCancelKeepsConnection(t *testing.T) {
+	release := make(chan struct{})
+	node := newStubNode(t, func(request *Request, write func(string)) {
+		if request.Method == "slow" {
+			go func() {
+				<-release
+				write(result(request.ID, `"late"`))
+			}()
+			return
+		}
+		write(result(request.ID, `"fast"`))
+	})
+	client := node.dial(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := client.Call(ctx, NewRequest(0, "slow", nil), nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Call = %v, want deadline exceeded", err)
+	}
+	close(release)
+
+	var got string
+	if err := client.Call(context.Background(), NewRequest(0, "fast", nil), &got); err != nil || got != "fast" {
+		t.Fatalf("Call after cancel = %q, %v", got, err)
+	}
+	// the late response is dropped instead of reaching Receive
+	deadline := time.Now().Add(time.Second)
+	for client.PendingCounter() != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if pending := client.PendingCounter(); pending != 0 {
+		t.Errorf("PendingCounter = %d", pending)
+	}
+	if client.inbox.pop() != nil {
+		t.Error("late response reached the inbox")
+	}
+}
+
+func TestCallConnectionLost(t *testing.T) {
+	node := newStubNode(t, func(*Request, func(string)) {})
+	client := node.dial(t)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- client.Call(context.Background(), NewRequest(0, "stub_drop", nil), nil)
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("Call succeeded on a lost connection")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Call still waits on a lost connection")
+	}
+}
+
+func TestSubscribeRoutesNotifications(t *testing.T) {
+	node := newStubNode(t, func(request *Request, write func(string)) {
+		switch request.Method {
+		case "eth_subscribe":
+			// notifications right behind the subscription id
+			write(result(request.ID, `"0xabc"`))
+			for i := 1; i <= 3; i++ {
+				write(fmt.Sprintf(`{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0xabc","result":%d}}`, i))
+			}
+		case "eth_unsubscribe":
+			write(result(request.ID, "true"))
+		default:
+			write(result(request.ID, `"call"`))
+		}
+	})
+	client := node.dial(t)
+
+	var got []string
+	err := client.Subscribe(context.Background(), BuildRequestSubscribe("newHeads"), func(result json.RawMessage) error {
+		got = append(got, string(result))
+		if len(got) == 1 {
+			// calls share the subscribed connection
+			var call string
+			if err := client.Call(context.Background(), NewRequest(0, "eth_chainId", nil), &call); err != nil || call != "call" {
+				t.Errorf("Call = %q, %v", call, err)
+			}
+		}
+		if len(got) == 3 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("Subscribe = %v", err)
+	}
+	if strings.Join(got, ",") != "1,2,3" {
+		t.Errorf("notifications = %v", got)
+	}
+}
+
+func TestSubscribeRejected(t *testing.T) {
+	node := newStubNode(t, func(request *Request, write func(string)) {
+		write(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"error":{"code":-32601,"message":"notifications not supported"}}`, request.ID))
+	})
+	client := node.dial(t)
+
+	err := client.Subscribe(context.Background(), BuildRequestSubscribe("newHeads"), func(json.RawMessage) error { return nil })
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32601 {
+		t.Errorf("Subscribe = %v, want RPCError", err)
+	}
+}
+
+func TestSubscribeUnsubscribes(t *testing.T) {
+	unsubscribed := make(chan string, 1)
+	node := newStubNode(t, func(request *Request, write func(string)) {
+		notification := `{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0xabc","result":1}}`
+		switch request.Method {
+		case "eth_subscribe":
+			write(result(request.ID, `"0xabc"`))
+			write(notification)
+		case "eth_unsubscribe":
+			// a notification in flight before the confirmation
+			write(notification)
+			write(result(request.ID, "true"))
+			unsubscribed <- request.Params.([]interface{})[0].(string)
+		}
+	})
+	client := node.dial(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err := client.Subscribe(ctx, BuildRequestSubscribe("newHeads"), func(json.RawMessage) error {
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Subscribe = %v", err)
+	}
+	select {
+	case id := <-unsubscribed:
+		if id != "0xabc" {
+			t.Errorf("unsubscribed %s", id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no eth_unsubscribe")
+	}
+	if pending := client.PendingCounter(); pending != 0 {
+		t.Errorf("PendingCounter = %d", pending)
+	}
+	if client.inbox.pop() != nil {
+		t.Error("a frame of the cancelled subscription reached the inbox")
+	}
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if len(client.subscriptions) != 0 {
+		t.Errorf("subscriptions = %v", client.subscriptions)
+	}
+}
+
+var errStop = errors.New("stop")
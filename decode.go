@@ -0,0 +1,402 @@
+package wsClient
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// Fast path decoding for hot loops: responses are read into pooled buffers and the
+// JSON-RPC envelope is scanned in place instead of going through json.Unmarshal.
+
+// maxPooledBuffer caps the buffers kept in bufferPool so one huge response
+// (e.g. a full block) does not pin its memory forever
+const maxPooledBuffer = 1 << 20
+
+// bufferPool holds read buffers reused across Receive calls
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// getBuffer returns an empty buffer from the pool
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// putBuffer returns buf to the pool
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBuffer {
+		return
+	}
+	bufferPool.Put(buf)
+}
+
+// Envelope holds the raw members of a JSON-RPC message, sliced from the read buffer
+// The slices are only valid until the buffer is released, copy them to keep them
+type Envelope struct {
+	ID     int64  // numeric id, 0 for notifications
+	Result []byte // raw JSON of "result", nil when absent or null
+	Error  []byte // raw JSON of "error", nil when absent or null
+	Method []byte // raw JSON string of "method", set for subscription notifications
+	Params []byte // raw JSON of "params", set for subscription notifications
+}
+
+// ScanEnvelope scans the top-level members of a JSON-RPC message without unmarshalling it
+func ScanEnvelope(data []byte, env *Envelope) error {
+	*env = Envelope{}
+	i := skipSpace(data, 0)
+	if i >= len(data) || data[i] != '{' {
+		return fmt.Errorf("invalid envelope: expected object")
+	}
+	i++
+	for {
+		i = skipSpace(data, i)
+		if i >= len(data) {
+			return fmt.Errorf("invalid envelope: unexpected end of input")
+		}
+		switch data[i] {
+		case '}':
+			return nil
+		case ',':
+			i++
+			continue
+		case '"':
+		default:
+			return fmt.Errorf("invalid envelope: unexpected %q at offset %d", data[i], i)
+		}
+
+		keyEnd, err := skipString(data, i)
+		if err != nil {
+			return err
+		}
+		key := data[i+1 : keyEnd-1]
+
+		i = skipSpace(data, keyEnd)
+		if i >= len(data) || data[i] != ':' {
+			return fmt.Errorf("invalid envelope: expected ':' at offset %d", i)
+		}
+		i = skipSpace(data, i+1)
+		valueEnd, err := skipValue(data, i)
+		if err != nil {
+			return err
+		}
+		value := data[i:valueEnd]
+		if isNull(value) {
+			value = nil
+		}
+
+		switch string(key) {
+		case "id":
+			env.ID = parseID(value)
+		case "result":
+			env.Result = value
+		case "error":
+			env.Error = value
+		case "method":
+			env.Method = value
+		case "params":
+			env.Params = value
+		}
+		i = valueEnd
+	}
+}
+
+// skipSpace returns the offset of the next non-whitespace byte
+func skipSpace(data []byte, i int) int {
+	for i < len(data) {
+		switch data[i] {
+		case ' ', '\t', '\n', '\r':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// skipString returns the offset after the string starting at data[i]
+func skipString(data []byte, i int) (int, error) {
+	for j := i + 1; j < len(data); j++ {
+		switch data[j] {
+		case '\\':
+			j++
+		case '"':
+			return j + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid envelope: unterminated string at offset %d", i)
+}
+
+// skipValue returns the offset after the JSON value starting at data[i]
+func skipValue(data []byte, i int) (int, error) {
+	if i >= len(data) {
+		return 0, fmt.Errorf("invalid envelope: missing value")
+	}
+	switch data[i] {
+	case '"':
+		return skipString(data, i)
+	case '{', '[':
+		depth := 0
+		for j := i; j < len(data); j++ {
+			switch data[j] {
+			case '"':
+				end, err := skipString(data, j)
+				if err != nil {
+					return 0, err
+				}
+				j = end - 1
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return j + 1, nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("invalid envelope: unterminated value at offset %d", i)
+	}
+	// number or literal
+	j := i
+	for j < len(data) {
+		switch data[j] {
+		case ',', '}', ']', ' ', '\t', '\n', '\r':
+			return j, nil
+		}
+		j++
+	}
+	return j, nil
+}
+
+// isNull reports whether value is the JSON literal null
+func isNull(value []byte) bool {
+	return len(value) == 4 && value[0] == 'n' && value[1] == 'u' && value[2] == 'l' && value[3] == 'l'
+}
+
+// parseID parses a numeric id, quoted or not; other ids yield 0
+func parseID(value []byte) int64 {
+	if len(value) >= 2 && value[0] == '"' {
+		value = value[1 : len(value)-1]
+	}
+	negative := len(value) > 0 && value[0] == '-'
+	if negative {
+		value = value[1:]
+	}
+	var id int64
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return 0
+		}
+		id = id*10 + int64(c-'0')
+	}
+	if negative {
+		return -id
+	}
+	return id
+}
+
+// hexDigits returns the digits of a quoted "0x..." JSON string
+func hexDigits(raw []byte) ([]byte, error) {
+	if len(raw) < 2 || raw[0] != '"' || raw[len(raw)-1] != '"' {
+		return nil, fmt.Errorf("json: cannot unmarshal non-string into hex value")
+	}
+	digits := raw[1 : len(raw)-1]
+	if len(digits) < 2 || digits[0] != '0' || (digits[1] != 'x' && digits[1] != 'X') {
+		return nil, fmt.Errorf("hex string without 0x prefix")
+	}
+	digits = digits[2:]
+	if len(digits)%2 != 0 {
+		return nil, fmt.Errorf("hex string of odd length")
+	}
+	return digits, nil
+}
+
+// unhex converts one hex digit, ok is false for invalid input
+func unhex(c byte) (byte, bool) {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0', true
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10, true
+	case 'A' <= c && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
+
+// decodeHexInto decodes len(dst)*2 hex digits into dst
+func decodeHexInto(dst []byte, digits []byte) error {
+	for i := range dst {
+		hi, ok1 := unhex(digits[2*i])
+		lo, ok2 := unhex(digits[2*i+1])
+		if !ok1 || !ok2 {
+			return fmt.Errorf("invalid hex digit in %q", digits[2*i:2*i+2])
+		}
+		dst[i] = hi<<4 | lo
+	}
+	return nil
+}
+
+// setWord stores up to 32 bytes of word into value, reusing its memory
+func setWord(value *big.Int, word []byte, signed bool) {
+	value.SetBytes(word)
+	if signed && len(word) == 32 && word[0]&0x80 != 0 {
+		value.Sub(value, two256)
+	}
+}
+
+// wordUint64 returns word as a uint64, ok is false when it does not fit
+func wordUint64(word *[32]byte) (uint64, bool) {
+	for _, b := range word[:24] {
+		if b != 0 {
+			return 0, false
+		}
+	}
+	var value uint64
+	for _, b := range word[24:] {
+		value = value<<8 | uint64(b)
+	}
+	return value, true
+}
+
+// DecodeRaw decodes a raw JSON hex string such as Envelope.Result into u as a uint256,
+// reusing u.Int when it is already allocated
+func (u *BigInt) DecodeRaw(raw []byte) error {
+	return u.DecodeRawOptions(raw, DecodeOptions{})
+}
+
+// DecodeRawOptions decodes raw into u as DecodeRaw, reading the word as opts selects
+func (u *BigInt) DecodeRawOptions(raw []byte, opts DecodeOptions) error {
+	if u.Int == nil {
+		u.Int = new(big.Int)
+	}
+	return decodeRawWord(u.Int, raw, opts)
+}
+
+// DecodeRaw decodes a raw JSON hex string into u as an int256, reusing u.Int when it is
+// already allocated
+func (u *SignedBigInt) DecodeRaw(raw []byte) error {
+	return u.DecodeRawOptions(raw, DecodeOptions{Signed: true})
+}
+
+// DecodeRawOptions decodes raw into u as DecodeRaw, reading the word as opts selects
+func (u *SignedBigInt) DecodeRawOptions(raw []byte, opts DecodeOptions) error {
+	if u.Int == nil {
+		u.Int = new(big.Int)
+	}
+	opts.Signed = true
+	return decodeRawWord(u.Int, raw, opts)
+}
+
+// decodeRawWord decodes a raw JSON hex string of one word into value
+func decodeRawWord(value *big.Int, raw []byte, opts DecodeOptions) error {
+	if isNull(raw) {
+		value.SetUint64(0)
+		return nil
+	}
+	digits, err := hexDigits(raw)
+	if err != nil {
+		return err
+	}
+	size := len(digits) / 2
+	if size > 32 {
+		if opts.Strict {
+			return fmt.Errorf("payload len=%d exceeds 32 bytes", size)
+		}
+		// validate the dropped prefix like a full decode would
+		for _, c := range digits[:len(digits)-64] {
+			if _, ok := unhex(c); !ok {
+				return fmt.Errorf("invalid hex digit %q", c)
+			}
+		}
+		digits = digits[len(digits)-64:]
+		size = 32
+	}
+
+	var word [32]byte
+	if err := decodeHexInto(word[:size], digits); err != nil {
+		return err
+	}
+	setWord(value, word[:size], opts.Signed)
+	return nil
+}
+
+// DecodeRaw decodes a raw JSON hex string of concatenated words into a, reusing the
+// existing Values and their big.Int memory
+// arrays of hex strings are delegated to UnmarshalJSON
+func (a *BigIntSlice) DecodeRaw(raw []byte) error {
+	return a.DecodeRawOptions(raw, DecodeOptions{})
+}
+
+// DecodeRawOptions decodes raw into a as DecodeRaw, reading the words as opts selects;
+// with opts.Dynamic a hex string must be an ABI-encoded dynamic array
+func (a *BigIntSlice) DecodeRawOptions(raw []byte, opts DecodeOptions) error {
+	if isNull(raw) {
+		a.Values = a.Values[:0]
+		return nil
+	}
+	if len(raw) > 0 && raw[0] == '[' {
+		return a.unmarshalArray(raw, opts)
+	}
+	digits, err := hexDigits(raw)
+	if err != nil {
+		return fmt.Errorf("decode hex string: %w", err)
+	}
+	if len(digits)%64 != 0 {
+		return fmt.Errorf("invalid concatenated payload len=%d (not multiple of 32)", len(digits)/2)
+	}
+
+	count := len(digits) / 64
+	var word [32]byte
+	if opts.Dynamic {
+		// ABI dynamic array: offset 0x20, then the element count
+		if count < 2 {
+			return fmt.Errorf("invalid dynamic array payload len=%d", len(digits)/2)
+		}
+		if err := decodeHexInto(word[:], digits[:64]); err != nil {
+			return err
+		}
+		if offset, ok := wordUint64(&word); !ok || offset != 32 {
+			return fmt.Errorf("invalid dynamic array offset %x", word)
+		}
+		if err := decodeHexInto(word[:], digits[64:128]); err != nil {
+			return err
+		}
+		if length, ok := wordUint64(&word); !ok || length != uint64(count-2) {
+			return fmt.Errorf("dynamic array length %x does not match %d words", word, count-2)
+		}
+		digits = digits[128:]
+		count -= 2
+	}
+
+	if cap(a.Values) >= count {
+		a.Values = a.Values[:count]
+	} else {
+		a.Values = append(a.Values[:cap(a.Values)], make([]*big.Int, count-cap(a.Values))...)
+	}
+	for i := 0; i < count; i++ {
+		if err := decodeHexInto(word[:], digits[i*64:(i+1)*64]); err != nil {
+			return err
+		}
+		if a.Values[i] == nil {
+			a.Values[i] = new(big.Int)
+		}
+		setWord(a.Values[i], word[:], opts.Signed)
+	}
+	return nil
+}
+
+// DecodeRaw decodes a raw JSON ABI-encoded dynamic array into a, reusing the existing
+// Values and their big.Int memory
+func (a *DynamicBigIntSlice) DecodeRaw(raw []byte) error {
+	return a.DecodeRawOptions(raw, DecodeOptions{})
+}
+
+// DecodeRawOptions decodes raw into a as DecodeRaw, reading the words as opts selects
+func (a *DynamicBigIntSlice) DecodeRawOptions(raw []byte, opts DecodeOptions) error {
+	opts.Dynamic = true
+	return (*BigIntSlice)(a).DecodeRawOptions(raw, opts)
+}
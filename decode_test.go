@@ -0,0 +1,128 @@
+package wsClient
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var (
+	amountMessage  = []byte(`{"jsonrpc":"2.0","id":42,"result":"0x` + word(123456789) + `"}`)
+	amountsMessage = []byte(`{"jsonrpc":"2.0","id":43,"result":"0x` + word(0x20) + word(3) + word(1) + word(2) + word(3) + `"}`)
+)
+
+func TestScanEnvelope(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		id      int64
+		result  string
+		error   string
+		method  string
+		wantErr bool
+	}{
+		{"result", `{"jsonrpc":"2.0","id":1,"result":"0x01"}`, 1, `"0x01"`, "", "", false},
+		{"quoted id", `{"id":"7","result":{"a":[1,"}"]}}`, 7, `{"a":[1,"}"]}`, "", "", false},
+		{"error", ` { "id" : 2 , "error" : {"code":3,"message":"execution reverted"} } `, 2, "", `{"code":3,"message":"execution reverted"}`, "", false},
+		{"null result", `{"id":3,"result":null}`, 3, "", "", "", false},
+		{"notification", `{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0x1","result":"0x2"}}`, 0, "", "", `"eth_subscription"`, false},
+		{"escaped key", `{"i\"d":5,"id":6}`, 6, "", "", "", false},
+		{"not an object", `[1]`, 0, "", "", "", true},
+		{"truncated", `{"id":1,"result":"0x`, 0, "", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var env Envelope
+			err := ScanEnvelope([]byte(tt.data), &env)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if env.ID != tt.id || string(env.Result) != tt.result || string(env.Error) != tt.error || string(env.Method) != tt.method {
+				t.Errorf("envelope = {%d %s %s %s}", env.ID, env.Result, env.Error, env.Method)
+			}
+		})
+	}
+}
+
+func TestDecodeZeroAlloc(t *testing.T) {
+	var env Envelope
+	var amount BigInt
+	var amounts BigIntSlice
+	// warm up the reused memory
+	if err := ScanEnvelope(amountsMessage, &env); err != nil {
+		t.Fatal(err)
+	}
+	if err := amounts.DecodeRawOptions(env.Result, DecodeOptions{Dynamic: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := amount.DecodeRaw([]byte(`"0x` + strings.Repeat("ff", 32) + `"`)); err != nil {
+		t.Fatal(err)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		ScanEnvelope(amountMessage, &env)
+		amount.DecodeRaw(env.Result)
+		ScanEnvelope(amountsMessage, &env)
+		amounts.DecodeRawOptions(env.Result, DecodeOptions{Dynamic: true})
+	})
+	if allocs != 0 {
+		t.Errorf("decoding allocates %v times per run", allocs)
+	}
+	if amount.Int64() != 123456789 || len(amounts.Values) != 3 || amounts.Values[2].Int64() != 3 {
+		t.Errorf("decoded %s and %v", amount, amounts.Values)
+	}
+}
+
+func BenchmarkAmountUnmarshal(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var response ResponseAmount
+		if err := json.Unmarshal(amountMessage, &response); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAmountDecodeRaw(b *testing.B) {
+	b.ReportAllocs()
+	var env Envelope
+	var amount BigInt
+	for i := 0; i < b.N; i++ {
+		if err := ScanEnvelope(amountMessage, &env); err != nil {
+			b.Fatal(err)
+		}
+		if err := amount.DecodeRaw(env.Result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAmountsUnmarshal(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var response struct {
+			ID     int64              `json:"id"`
+			Result DynamicBigIntSlice `json:"result"`
+		}
+		if err := json.Unmarshal(amountsMessage, &response); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAmountsDecodeRaw(b *testing.B) {
+	b.ReportAllocs()
+	var env Envelope
+	var amounts BigIntSlice
+	for i := 0; i < b.N; i++ {
+		if err := ScanEnvelope(amountsMessage, &env); err != nil {
+			b.Fatal(err)
+		}
+		if err := amounts.DecodeRawOptions(env.Result, DecodeOptions{Dynamic: true}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package wsClient
+
+import (
+	"errors"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// ErrorCategory classifies a JSON-RPC error independently of the node implementation
+type ErrorCategory int
+
+const (
+	CategoryUnknown ErrorCategory = iota
+	CategoryParseError
+	CategoryInvalidRequest
+	CategoryMethodNotFound
+	CategoryInvalidParams
+	CategoryInternalError
+	CategoryExecutionReverted
+	CategoryLimitExceeded
+	CategoryNonceTooLow
+	CategoryNonceTooHigh
+	CategoryReplacementUnderpriced
+	CategoryAlreadyKnown
+	CategoryInsufficientFunds
+	CategoryHeaderNotFound
+	CategoryResultTooLarge
+	CategoryMissingState
+)
+
+// Standard JSON-RPC and Ethereum error codes
+const (
+	CodeExecutionReverted = 3
+	CodeParseError        = -32700
+	CodeInvalidRequest    = -32600
+	CodeMethodNotFound    = -32601
+	CodeInvalidParams     = -32602
+	CodeInternalError     = -32603
+	CodeServerError       = -32000
+	CodeLimitExceeded     = -32005
+)
+
+// Sentinel errors matched by RPCError through errors.Is
+// ErrExecutionReverted (see revert.go) covers CategoryExecutionReverted
+var (
+	ErrParseError             = errors.New("parse error")
+	ErrInvalidRequest         = errors.New("invalid request")
+	ErrMethodNotFound         = errors.New("method not found")
+	ErrInvalidParams          = errors.New("invalid params")
+	ErrInternalError          = errors.New("internal error")
+	ErrLimitExceeded          = errors.New("limit exceeded")
+	ErrNonceTooLow            = errors.New("nonce too low")
+	ErrNonceTooHigh           = errors.New("nonce too high")
+	ErrReplacementUnderpriced = errors.New("replacement transaction underpriced")
+	ErrAlreadyKnown           = errors.New("already known")
+	ErrInsufficientFunds      = errors.New("insufficient funds")
+	ErrHeaderNotFound         = errors.New("header not found")
+	ErrResultTooLarge         = errors.New("result too large")
+	ErrMissingState           = errors.New("missing state")
+)
+
+// categoryErrors maps each category to its sentinel error
+var categoryErrors = map[ErrorCategory]error{
+	CategoryParseError:             ErrParseError,
+	CategoryInvalidRequest:         ErrInvalidRequest,
+	CategoryMethodNotFound:         ErrMethodNotFound,
+	CategoryInvalidParams:          ErrInvalidParams,
+	CategoryInternalError:          ErrInternalError,
+	CategoryExecutionReverted:      ErrExecutionReverted,
+	CategoryLimitExceeded:          ErrLimitExceeded,
+	CategoryNonceTooLow:            ErrNonceTooLow,
+	CategoryNonceTooHigh:           ErrNonceTooHigh,
+	CategoryReplacementUnderpriced: ErrReplacementUnderpriced,
+	CategoryAlreadyKnown:           ErrAlreadyKnown,
+	CategoryInsufficientFunds:      ErrInsufficientFunds,
+	CategoryHeaderNotFound:         ErrHeaderNotFound,
+	CategoryResultTooLarge:         ErrResultTooLarge,
+	CategoryMissingState:           ErrMissingState,
+}
+
+// messagePatterns maps lower-cased message fragments to categories
+// covering geth, bor, erigon and nethermind wordings, checked in order
+var messagePatterns = []struct {
+	pattern  string
+	category ErrorCategory
+}{
+	// nonce
+	{"nonce too low", CategoryNonceTooLow},
+	{"oldnonce", CategoryNonceTooLow}, // nethermind
+	{"nonce has already been used", CategoryNonceTooLow},
+	{"nonce too high", CategoryNonceTooHigh},
+	{"noncegap", CategoryNonceTooHigh}, // nethermind
+	{"nonce gap", CategoryNonceTooHigh},
+	// replacement
+	{"replacement transaction underpriced", CategoryReplacementUnderpriced},
+	{"replacement underpriced", CategoryReplacementUnderpriced},
+	{"could not replace existing tx", CategoryReplacementUnderpriced}, // erigon
+	{"replacementnotallowed", CategoryReplacementUnderpriced},         // nethermind
+	{"feetoolowtocompete", CategoryReplacementUnderpriced},            // nethermind
+	// already known
+	{"already known", CategoryAlreadyKnown},
+	{"known transaction", CategoryAlreadyKnown},
+	{"alreadyknown", CategoryAlreadyKnown},   // nethermind
+	{"already_exists", CategoryAlreadyKnown}, // erigon
+	{"already imported", CategoryAlreadyKnown},
+	// funds
+	{"insufficient funds", CategoryInsufficientFunds},
+	{"insufficientfunds", CategoryInsufficientFunds}, // nethermind
+	{"insufficient balance", CategoryInsufficientFunds},
+	// missing state
+	{"header not found", CategoryHeaderNotFound},
+	{"header for hash not found", CategoryHeaderNotFound},
+	{"unknown block", CategoryHeaderNotFound},
+	{"block not found", CategoryHeaderNotFound},
+	// pruned state, only an archive node has it
+	{"missing trie node", CategoryMissingState},
+	{"historical state unavailable", CategoryMissingState},
+	// result size and block range, the same query fails again
+	{"query returned more than", CategoryResultTooLarge},
+	{"block range", CategoryResultTooLarge},
+	{"range too large", CategoryResultTooLarge},
+	{"range is too large", CategoryResultTooLarge},
+	{"response size", CategoryResultTooLarge},
+	{"too many results", CategoryResultTooLarge},
+	{"query timeout exceeded", CategoryResultTooLarge},
+	// rate limits
+	{"limit exceeded", CategoryLimitExceeded},
+	{"rate limit", CategoryLimitExceeded},
+	{"too many requests", CategoryLimitExceeded},
+	// reverts
+	{"reverted", CategoryExecutionReverted}, // nethermind "Reverted 0x..."
+}
+
+// Category classifies the error by its message, falling back to its code
+func (e *RPCError) Category() ErrorCategory {
+	if e == nil {
+		return CategoryUnknown
+	}
+	// revert reasons are contract defined and must not be matched against node messages
+	if e.Code == CodeExecutionReverted || strings.HasPrefix(e.Message, "execution reverted") {
+		return CategoryExecutionReverted
+	}
+	message := strings.ToLower(e.Message)
+	for _, p := range messagePatterns {
+		if strings.Contains(message, p.pattern) {
+			return p.category
+		}
+	}
+
+	switch e.Code {
+	case CodeParseError:
+		return CategoryParseError
+	case CodeInvalidRequest:
+		return CategoryInvalidRequest
+	case CodeMethodNotFound:
+		return CategoryMethodNotFound
+	case CodeInvalidParams:
+		return CategoryInvalidParams
+	case CodeInternalError:
+		return CategoryInternalError
+	case CodeLimitExceeded:
+		return CategoryLimitExceeded
+	}
+	return CategoryUnknown
+}
+
+// Is lets errors.Is match an RPCError against the category sentinels, e.g. ErrNonceTooLow
+func (e *RPCError) Is(target error) bool {
+	sentinel, ok := categoryErrors[e.Category()]
+	return ok && sentinel == target
+}
+
+// categoryNames are the short names of the categories used in RPCError.Error
+var categoryNames = map[ErrorCategory]string{
+	CategoryParseError:             "parse error",
+	CategoryInvalidRequest:         "invalid request",
+	CategoryMethodNotFound:         "method not found",
+	CategoryInvalidParams:          "invalid params",
+	CategoryInternalError:          "internal error",
+	CategoryExecutionReverted:      "revert",
+	CategoryLimitExceeded:          "limit exceeded",
+	CategoryNonceTooLow:            "nonce too low",
+	CategoryNonceTooHigh:           "nonce too high",
+	CategoryReplacementUnderpriced: "replacement underpriced",
+	CategoryAlreadyKnown:           "already known",
+	CategoryInsufficientFunds:      "insufficient funds",
+	CategoryHeaderNotFound:         "header not found",
+	CategoryResultTooLarge:         "result too large",
+	CategoryMissingState:           "missing state",
+}
+
+// String returns the category name
+func (c ErrorCategory) String() string {
+	if name, ok := categoryNames[c]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ErrorCategoryOf returns the category of the RPCError wrapped by err
+func ErrorCategoryOf(err error) ErrorCategory {
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		return rpcErr.Category()
+	}
+	return CategoryUnknown
+}
+
+// IsRetryable reports whether err is transient: rate limits, a node lagging behind
+// the requested block, internal node errors or a dropped connection. Results too large
+// for the node and state it has pruned fail the same way again and are not retryable.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch ErrorCategoryOf(err) {
+	case CategoryLimitExceeded, CategoryHeaderNotFound, CategoryInternalError:
+		return true
+	case CategoryUnknown:
+	default:
+		return false
+	}
+
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) && rpcErr != nil {
+		message := strings.ToLower(rpcErr.Message)
+		return strings.Contains(message, "timeout") ||
+			strings.Contains(message, "try again") ||
+			strings.Contains(message, "busy") ||
+			strings.Contains(message, "temporarily unavailable")
+	}
+
+	// transport errors
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	var closeErr *websocket.CloseError
+	return errors.As(err, &closeErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// IsNonceError reports whether err means the nonce of a sent transaction is already
+// taken or out of order, so the sender should refresh its nonce before resending
+func IsNonceError(err error) bool {
+	switch ErrorCategoryOf(err) {
+	case CategoryNonceTooLow, CategoryNonceTooHigh, CategoryReplacementUnderpriced, CategoryAlreadyKnown:
+		return true
+	}
+	return false
+}
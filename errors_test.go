@@ -0,0 +1,88 @@
+package wsClient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestRPCErrorCategory(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       *RPCError
+		category  ErrorCategory
+		sentinel  error
+		retryable bool
+		nonce     bool
+	}{
+		{"parse", &RPCError{Code: -32700, Message: "parse error"}, CategoryParseError, ErrParseError, false, false},
+		{"invalid request", &RPCError{Code: -32600, Message: "invalid request"}, CategoryInvalidRequest, ErrInvalidRequest, false, false},
+		{"method not found", &RPCError{Code: -32601, Message: "the method eth_foo does not exist/is not available"}, CategoryMethodNotFound, ErrMethodNotFound, false, false},
+		{"invalid params", &RPCError{Code: -32602, Message: "invalid argument 0"}, CategoryInvalidParams, ErrInvalidParams, false, false},
+		{"internal", &RPCError{Code: -32603, Message: "internal error"}, CategoryInternalError, ErrInternalError, true, false},
+		{"revert code", &RPCError{Code: 3, Message: "execution reverted: nonce too low"}, CategoryExecutionReverted, ErrExecutionReverted, false, false},
+		{"revert message", &RPCError{Code: -32000, Message: "execution reverted"}, CategoryExecutionReverted, ErrExecutionReverted, false, false},
+		{"nethermind revert", &RPCError{Code: -32015, Message: "Reverted 0x"}, CategoryExecutionReverted, ErrExecutionReverted, false, false},
+		{"limit code", &RPCError{Code: -32005, Message: "request rejected"}, CategoryLimitExceeded, ErrLimitExceeded, true, false},
+		{"rate limit", &RPCError{Code: -32005, Message: "project ID request rate exceeded, rate limited"}, CategoryLimitExceeded, ErrLimitExceeded, true, false},
+		{"log range", &RPCError{Code: -32005, Message: "query returned more than 10000 results"}, CategoryResultTooLarge, ErrResultTooLarge, false, false},
+		{"block range", &RPCError{Code: -32000, Message: "exceed maximum block range: 5000"}, CategoryResultTooLarge, ErrResultTooLarge, false, false},
+		{"log timeout", &RPCError{Code: -32000, Message: "query timeout exceeded"}, CategoryResultTooLarge, ErrResultTooLarge, false, false},
+		{"geth nonce", &RPCError{Code: -32000, Message: "nonce too low: next nonce 5, tx nonce 4"}, CategoryNonceTooLow, ErrNonceTooLow, false, true},
+		{"nethermind nonce", &RPCError{Code: -32010, Message: "OldNonce"}, CategoryNonceTooLow, ErrNonceTooLow, false, true},
+		{"nonce gap", &RPCError{Code: -32010, Message: "NonceGap"}, CategoryNonceTooHigh, ErrNonceTooHigh, false, true},
+		{"underpriced", &RPCError{Code: -32000, Message: "replacement transaction underpriced"}, CategoryReplacementUnderpriced, ErrReplacementUnderpriced, false, true},
+		{"erigon underpriced", &RPCError{Code: -32000, Message: "could not replace existing tx"}, CategoryReplacementUnderpriced, ErrReplacementUnderpriced, false, true},
+		{"already known", &RPCError{Code: -32000, Message: "already known"}, CategoryAlreadyKnown, ErrAlreadyKnown, false, true},
+		{"erigon known", &RPCError{Code: -32000, Message: "ALREADY_EXISTS"}, CategoryAlreadyKnown, ErrAlreadyKnown, false, true},
+		{"funds", &RPCError{Code: -32000, Message: "insufficient funds for gas * price + value"}, CategoryInsufficientFunds, ErrInsufficientFunds, false, false},
+		{"header", &RPCError{Code: -32000, Message: "header not found"}, CategoryHeaderNotFound, ErrHeaderNotFound, true, false},
+		{"bor missing trie node", &RPCError{Code: -32000, Message: "missing trie node 1234 (path )"}, CategoryMissingState, ErrMissingState, false, false},
+		{"pruned state", &RPCError{Code: -32000, Message: "required historical state unavailable (reexec=128)"}, CategoryMissingState, ErrMissingState, false, false},
+		{"busy", &RPCError{Code: -32000, Message: "server busy, try again"}, CategoryUnknown, nil, true, false},
+		{"unknown", &RPCError{Code: -32000, Message: "something else"}, CategoryUnknown, nil, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Category(); got != tt.category {
+				t.Errorf("Category() = %s, want %s", got, tt.category)
+			}
+			wrapped := fmt.Errorf("send: %w", tt.err)
+			if tt.sentinel != nil && !errors.Is(wrapped, tt.sentinel) {
+				t.Errorf("errors.Is(%v) = false", tt.sentinel)
+			}
+			if got := IsRetryable(wrapped); got != tt.retryable {
+				t.Errorf("IsRetryable = %v, want %v", got, tt.retryable)
+			}
+			if got := IsNonceError(wrapped); got != tt.nonce {
+				t.Errorf("IsNonceError = %v, want %v", got, tt.nonce)
+			}
+		})
+	}
+}
+
+func TestRPCErrorError(t *testing.T) {
+	tests := []struct {
+		err  *RPCError
+		want string
+	}{
+		{&RPCError{Code: 3, Message: "execution reverted"}, "code 3 (revert): execution reverted"},
+		{&RPCError{Code: -32000, Message: "nonce too low"}, "code -32000 (nonce too low): nonce too low"},
+		{&RPCError{Code: -32000, Message: "something else"}, "code -32000: something else"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestIsRetryableTransport(t *testing.T) {
+	if !IsRetryable(fmt.Errorf("failed to read message: %w", io.ErrUnexpectedEOF)) {
+		t.Error("unexpected EOF is not retryable")
+	}
+	if IsRetryable(nil) || IsRetryable(errors.New("boom")) {
+		t.Error("plain errors are retryable")
+	}
+}
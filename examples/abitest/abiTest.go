@@ -5,8 +5,8 @@ import (
 	"log"
 	"math/big"
 
+	"github.com/0xKhennati/wsclient/helper"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/khennati22/wsClient/helper"
 )
 
 func main() {
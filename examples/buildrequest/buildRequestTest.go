@@ -6,48 +6,13 @@ import (
 	"log"
 	"math/big"
 
+	wsClient "github.com/0xKhennati/wsclient"
+	"github.com/0xKhennati/wsclient/helper"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	wsClient "github.com/khennati22/wsClient"
-	"github.com/khennati22/wsClient/helper"
 )
 
-// CallMsgForRPC is a wrapper around ethereum.CallMsg that properly handles JSON marshaling for RPC
-type CallMsgForRPC struct {
-	From     *common.Address `json:"from,omitempty"`
-	To       *common.Address `json:"to,omitempty"`
-	Gas      *hexutil.Uint64 `json:"gas,omitempty"`
-	GasPrice *hexutil.Big    `json:"gasPrice,omitempty"`
-	Value    *hexutil.Big    `json:"value,omitempty"`
-	Data     hexutil.Bytes   `json:"data,omitempty"`
-}
-
-// ToRPCCallMsg converts ethereum.CallMsg to CallMsgForRPC
-func ToRPCCallMsg(msg ethereum.CallMsg) CallMsgForRPC {
-	rpcMsg := CallMsgForRPC{
-		To:   msg.To,
-		Data: hexutil.Bytes(msg.Data),
-	}
-
-	// Only include fields if they're not zero values
-	if msg.From != (common.Address{}) {
-		rpcMsg.From = &msg.From
-	}
-	if msg.Gas != 0 {
-		gas := hexutil.Uint64(msg.Gas)
-		rpcMsg.Gas = &gas
-	}
-	if msg.GasPrice != nil && msg.GasPrice.Sign() > 0 {
-		rpcMsg.GasPrice = (*hexutil.Big)(msg.GasPrice)
-	}
-	if msg.Value != nil && msg.Value.Sign() > 0 {
-		rpcMsg.Value = (*hexutil.Big)(msg.Value)
-	}
-
-	return rpcMsg
-}
-
 // Test constants
 const (
 	WPOL_ADDRESS = "0x0d500B1d8E8eF31E21C99d1Db9A6444d3ADf1270"
@@ -129,9 +94,6 @@ func testEthCall(client *wsClient.Client, wpolAddress, myWallet common.Address)
 		Data: balanceOfData,
 	}
 
-	// Convert to RPC-compatible format
-	// rpcCallMsg := ToRPCCallMsg(callMsg)
-
 	request := wsClient.Build_eth_call_request(1, callMsg, nil, "latest")
 	// Create request manually with proper formatting
 
@@ -205,7 +167,7 @@ func testEthCallWithStateOverrides(client *wsClient.Client, wpolAddress, myWalle
 	}
 
 	// Convert to RPC-compatible format
-	rpcCallMsg := ToRPCCallMsg(callMsg)
+	rpcCallMsg := wsClient.FromEthereumCallMsg(callMsg)
 
 	// Create request manually with proper formatting and state overrides
 	request := &wsClient.Request{
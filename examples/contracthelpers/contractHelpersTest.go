@@ -5,9 +5,9 @@ import (
 	"log"
 	"math/big"
 
+	wsClient "github.com/0xKhennati/wsclient"
+	"github.com/0xKhennati/wsclient/helper"
 	"github.com/ethereum/go-ethereum/common"
-	wsClient "github.com/khennati22/wsClient"
-	"github.com/khennati22/wsClient/helper"
 )
 
 // Test constants - you can replace these with real contract addresses
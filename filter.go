@@ -0,0 +1,257 @@
+package wsClient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// FilterQuery selects logs for eth_getLogs
+// Addresses are OR-ed. Topics are matched by position: each position is a list of
+// alternatives (OR), an empty position matches anything, positions are AND-ed.
+// A query targets either a block range or a single BlockHash.
+type FilterQuery struct {
+	BlockHash *common.Hash
+	FromBlock BlockRef
+	ToBlock   BlockRef
+	Addresses []common.Address
+	Topics    [][]common.Hash
+}
+
+// NewFilterQuery creates an empty query over the latest block
+func NewFilterQuery() *FilterQuery {
+	return &FilterQuery{}
+}
+
+// Address adds contract addresses to match
+func (q *FilterQuery) Address(addresses ...common.Address) *FilterQuery {
+	q.Addresses = append(q.Addresses, addresses...)
+	return q
+}
+
+// Topic sets the alternatives for the topic at position, e.g. Topic(0, swapSig, syncSig)
+func (q *FilterQuery) Topic(position int, alternatives ...common.Hash) *FilterQuery {
+	for len(q.Topics) <= position {
+		q.Topics = append(q.Topics, nil)
+	}
+	q.Topics[position] = append(q.Topics[position], alternatives...)
+	return q
+}
+
+// Range sets the block range, both ends included
+func (q *FilterQuery) Range(from, to BlockRef) *FilterQuery {
+	q.FromBlock, q.ToBlock, q.BlockHash = from, to, nil
+	return q
+}
+
+// NumberRange sets the block range by numbers, both ends included
+func (q *FilterQuery) NumberRange(from, to uint64) *FilterQuery {
+	return q.Range(BlockRefFromNumber(from), BlockRefFromNumber(to))
+}
+
+// AtBlockHash restricts the query to a single block
+func (q *FilterQuery) AtBlockHash(hash common.Hash) *FilterQuery {
+	q.BlockHash = &hash
+	q.FromBlock, q.ToBlock = BlockRef{}, BlockRef{}
+	return q
+}
+
+// Validate checks the query can be sent as is
+func (q *FilterQuery) Validate() error {
+	if q.BlockHash != nil {
+		return nil
+	}
+	if _, err := q.FromBlock.numberParam(); err != nil {
+		return fmt.Errorf("invalid fromBlock: %w", err)
+	}
+	if _, err := q.ToBlock.numberParam(); err != nil {
+		return fmt.Errorf("invalid toBlock: %w", err)
+	}
+	from, to := q.FromBlock.Number(), q.ToBlock.Number()
+	if from != nil && to != nil && from.Cmp(to) > 0 {
+		return fmt.Errorf("fromBlock %s is after toBlock %s", from, to)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the query as the eth_getLogs filter object
+func (q FilterQuery) MarshalJSON() ([]byte, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	filter := map[string]interface{}{}
+	if q.BlockHash != nil {
+		filter["blockHash"] = *q.BlockHash
+	} else {
+		filter["fromBlock"] = q.FromBlock.param()
+		filter["toBlock"] = q.ToBlock.param()
+	}
+	if len(q.Addresses) > 0 {
+		filter["address"] = q.Addresses
+	}
+	if len(q.Topics) > 0 {
+		topics := make([]interface{}, len(q.Topics))
+		for i, alternatives := range q.Topics {
+			if len(alternatives) > 0 {
+				topics[i] = alternatives
+			}
+		}
+		filter["topics"] = topics
+	}
+	return json.Marshal(filter)
+}
+
+// BuildRequestGetLogs creates an eth_getLogs request for the query
+func BuildRequestGetLogs(q *FilterQuery) (*Request, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	return NewRequest(0, "eth_getLogs", []interface{}{*q}), nil
+}
+
+// LogsOptions tunes GetLogs
+type LogsOptions struct {
+	ChunkSize   uint64 // blocks per request, 0 sends the whole range first and only splits on errors
+	Concurrency int    // requests pipelined at once, defaults to 8
+}
+
+// isRangeLimitError reports whether the node refused the query for its size,
+// in which case a smaller block range may succeed. Rate limits are not range errors,
+// splitting would only send more requests.
+func isRangeLimitError(err error) bool {
+	return ErrorCategoryOf(err) == CategoryResultTooLarge
+}
+
+// blockRange is an inclusive range of block numbers
+type blockRange struct{ from, to uint64 }
+
+// GetLogs fetches the logs matching q, splitting the block range into chunks sent
+// concurrently through c. A chunk the node rejects for its size ("query returned more
+// than N results", block range limits) is split in half and retried. Other errors,
+// rate limits included, are returned: IsRetryable tells whether to call again later.
+// Logs are returned ordered by block number and log index.
+func GetLogs(ctx context.Context, c *Client, q *FilterQuery, opts LogsOptions) ([]types.Log, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 8
+	}
+
+	// a single block needs no chunking
+	if q.BlockHash != nil {
+		var logs []types.Log
+		request, _ := BuildRequestGetLogs(q)
+		if err := c.Call(ctx, request, &logs); err != nil {
+			return nil, err
+		}
+		return logs, nil
+	}
+
+	from, err := resolveBlockNumber(ctx, c, q.FromBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve fromBlock: %w", err)
+	}
+	to, err := resolveBlockNumber(ctx, c, q.ToBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve toBlock: %w", err)
+	}
+	if from > to {
+		return nil, fmt.Errorf("fromBlock %d is after toBlock %d", from, to)
+	}
+
+	var pending []blockRange
+	if opts.ChunkSize == 0 {
+		pending = append(pending, blockRange{from, to})
+	} else {
+		for start := from; start <= to; start += opts.ChunkSize {
+			end := start + opts.ChunkSize - 1
+			if end > to || end < start {
+				end = to
+			}
+			pending = append(pending, blockRange{start, end})
+			if end == to {
+				break
+			}
+		}
+	}
+
+	var logs []types.Log
+	for len(pending) > 0 {
+		n := len(pending)
+		if n > opts.Concurrency {
+			n = opts.Concurrency
+		}
+		batch := pending[:n]
+		pending = pending[n:]
+
+		requests := make([]*Request, len(batch))
+		for i, r := range batch {
+			chunk := *q
+			chunk.NumberRange(r.from, r.to)
+			requests[i], _ = BuildRequestGetLogs(&chunk)
+		}
+		responses, err := c.CallBatch(ctx, requests)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, response := range responses {
+			r := batch[i]
+			if response.Error != nil {
+				if isRangeLimitError(response.Error) && r.from < r.to {
+					mid := r.from + (r.to-r.from)/2
+					pending = append(pending, blockRange{r.from, mid}, blockRange{mid + 1, r.to})
+					continue
+				}
+				return nil, fmt.Errorf("eth_getLogs [%d, %d]: %w", r.from, r.to, response.Error)
+			}
+			var chunkLogs []types.Log
+			if err := json.Unmarshal(response.Result, &chunkLogs); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal logs [%d, %d]: %w", r.from, r.to, err)
+			}
+			logs = append(logs, chunkLogs...)
+		}
+	}
+
+	sort.Slice(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
+	})
+	return logs, nil
+}
+
+// resolveBlockNumber returns the number of block, asking the node for tags
+func resolveBlockNumber(ctx context.Context, c *Client, block BlockRef) (uint64, error) {
+	if number := block.Number(); number != nil {
+		if !number.IsUint64() {
+			return 0, fmt.Errorf("block number %s out of range", number)
+		}
+		return number.Uint64(), nil
+	}
+	switch block.Tag() {
+	case BlockEarliest:
+		return 0, nil
+	case BlockLatest, BlockPending:
+		var head hexutil.Uint64
+		if err := c.Call(ctx, BuildRequestGetBlockNumber(), &head); err != nil {
+			return 0, err
+		}
+		return uint64(head), nil
+	}
+	header, err := c.BlockByRef(ctx, block, false)
+	if err != nil {
+		return 0, err
+	}
+	if header == nil {
+		return 0, fmt.Errorf("block %s not found", block)
+	}
+	return header.Header.Number.Uint64(), nil
+}
@@ -0,0 +1,137 @@
+package wsClient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestFilterQueryJSON(t *testing.T) {
+	pair := common.HexToAddress("0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc")
+	swap := common.HexToHash("0xd78ad95fa46c994b6551d0da85fc275fe613ce37657fb8d5e3d130840159d822")
+	sync := common.HexToHash("0x1c411e9a96e071241c2f21f7726b17ae89e3cab4c78be50e062b03a9fffbbad1")
+	sender := common.HexToHash("0x01")
+	hash := common.HexToHash("0x02")
+
+	tests := []struct {
+		name    string
+		query   *FilterQuery
+		want    string
+		wantErr bool
+	}{
+		{"latest", NewFilterQuery(), `{"fromBlock":"latest","toBlock":"latest"}`, false},
+		{"range", NewFilterQuery().NumberRange(100, 200).Address(pair),
+			`{"address":["` + strings.ToLower(pair.Hex()) + `"],"fromBlock":"0x64","toBlock":"0xc8"}`, false},
+		{"topics or", NewFilterQuery().Topic(0, swap, sync),
+			`{"fromBlock":"latest","toBlock":"latest","topics":[["` + swap.Hex() + `","` + sync.Hex() + `"]]}`, false},
+		{"topic wildcard", NewFilterQuery().Topic(2, sender),
+			`{"fromBlock":"latest","toBlock":"latest","topics":[null,null,["` + sender.Hex() + `"]]}`, false},
+		{"tags", NewFilterQuery().Range(BlockRefFromTag(BlockFinalized), BlockRefFromTag(BlockLatest)),
+			`{"fromBlock":"finalized","toBlock":"latest"}`, false},
+		{"block hash", NewFilterQuery().NumberRange(1, 2).AtBlockHash(hash), `{"blockHash":"` + hash.Hex() + `"}`, false},
+		{"reversed", NewFilterQuery().NumberRange(2, 1), "", true},
+		{"hash range", NewFilterQuery().Range(BlockRefFromHash(hash, false), BlockRef{}), "", true},
+		{"bad tag", NewFilterQuery().Range(BlockRefFromTag("head"), BlockRef{}), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := json.Marshal(tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && string(out) != tt.want {
+				t.Errorf("marshal = %s, want %s", out, tt.want)
+			}
+			if _, err := BuildRequestGetLogs(tt.query); (err != nil) != tt.wantErr {
+				t.Errorf("BuildRequestGetLogs err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsRangeLimitError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&RPCError{Code: -32005, Message: "query returned more than 10000 results"}, true},
+		{&RPCError{Code: -32000, Message: "exceed maximum block range: 5000"}, true},
+		{&RPCError{Code: -32602, Message: "Log response size exceeded. You can make eth_getLogs requests with up to a 2K block range"}, true},
+		{&RPCError{Code: -32000, Message: "query timeout exceeded"}, true},
+		// rate limits share the code of Infura's result limit
+		{&RPCError{Code: CodeLimitExceeded, Message: "request rejected"}, false},
+		{&RPCError{Code: CodeLimitExceeded, Message: "project ID request rate exceeded"}, false},
+		{&RPCError{Code: -32000, Message: "limit exceeded"}, false},
+		{&RPCError{Code: -32000, Message: "header not found"}, false},
+		{fmt.Errorf("boom"), false},
+	}
+	for _, tt := range tests {
+		if got := isRangeLimitError(tt.err); got != tt.want {
+			t.Errorf("isRangeLimitError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestGetLogsSplitsRange(t *testing.T) {
+	// the node returns one log per block and rejects ranges over 4 blocks
+	node := newStubNode(t, func(request *Request, write func(string)) {
+		params := request.Params.([]interface{})
+		filter := params[0].(map[string]interface{})
+		from, _ := strconv.ParseUint(filter["fromBlock"].(string)[2:], 16, 64)
+		to, _ := strconv.ParseUint(filter["toBlock"].(string)[2:], 16, 64)
+		if to-from >= 4 {
+			write(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"error":{"code":-32005,"message":"query returned more than 10000 results"}}`, request.ID))
+			return
+		}
+		logs := "["
+		for block := from; block <= to; block++ {
+			if block > from {
+				logs += ","
+			}
+			logs += fmt.Sprintf(`{"address":"0x0000000000000000000000000000000000000001","topics":[],"data":"0x","blockNumber":"0x%x","transactionHash":"0x%064x","transactionIndex":"0x0","blockHash":"0x%064x","logIndex":"0x0","removed":false}`, block, block, block)
+		}
+		write(result(request.ID, logs+"]"))
+	})
+	client := node.dial(t)
+
+	logs, err := GetLogs(context.Background(), client, NewFilterQuery().NumberRange(10, 30), LogsOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 21 {
+		t.Fatalf("got %d logs, want 21", len(logs))
+	}
+	for i, log := range logs {
+		if log.BlockNumber != uint64(10+i) {
+			t.Errorf("log %d is from block %d", i, log.BlockNumber)
+		}
+	}
+	if pending := client.PendingCounter(); pending != 0 {
+		t.Errorf("PendingCounter = %d", pending)
+	}
+}
+
+func TestGetLogsRateLimited(t *testing.T) {
+	var requests int32
+	node := newStubNode(t, func(request *Request, write func(string)) {
+		atomic.AddInt32(&requests, 1)
+		write(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"error":{"code":-32005,"message":"daily request count exceeded, request rate limited"}}`, request.ID))
+	})
+	client := node.dial(t)
+
+	_, err := GetLogs(context.Background(), client, NewFilterQuery().NumberRange(10, 30), LogsOptions{})
+	if !errors.Is(err, ErrLimitExceeded) || !IsRetryable(err) {
+		t.Fatalf("GetLogs err = %v, want a retryable rate limit", err)
+	}
+	// the range is not split into more requests
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("sent %d requests, want 1", n)
+	}
+}
@@ -0,0 +1,62 @@
+package helper
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BuildAaveV3CallData creates properly wrapped call data for any Aave V3 pool function
+func BuildAaveV3CallData(functionName string, args ...interface{}) (hexutil.Bytes, error) {
+	data, err := packABI(AaveABIName, functionName, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s: %w", functionName, err)
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// BuildAaveV3GetUserAccountDataCallData creates wrapped call data for getUserAccountData
+func BuildAaveV3GetUserAccountDataCallData(user common.Address) (hexutil.Bytes, error) {
+	return BuildAaveV3CallData("getUserAccountData", user)
+}
+
+// BuildAaveV3GetReserveDataCallData creates wrapped call data for getReserveData
+func BuildAaveV3GetReserveDataCallData(asset common.Address) (hexutil.Bytes, error) {
+	return BuildAaveV3CallData("getReserveData", asset)
+}
+
+// BuildAaveV3SupplyCallData creates wrapped call data for supply
+func BuildAaveV3SupplyCallData(asset common.Address, amount *big.Int, onBehalfOf common.Address, referralCode uint16) (hexutil.Bytes, error) {
+	return BuildAaveV3CallData("supply", asset, amount, onBehalfOf, referralCode)
+}
+
+// BuildAaveV3WithdrawCallData creates wrapped call data for withdraw
+func BuildAaveV3WithdrawCallData(asset common.Address, amount *big.Int, to common.Address) (hexutil.Bytes, error) {
+	return BuildAaveV3CallData("withdraw", asset, amount, to)
+}
+
+// BuildAaveV3BorrowCallData creates wrapped call data for borrow, interestRateMode 2 is variable
+func BuildAaveV3BorrowCallData(asset common.Address, amount *big.Int, interestRateMode *big.Int, referralCode uint16, onBehalfOf common.Address) (hexutil.Bytes, error) {
+	return BuildAaveV3CallData("borrow", asset, amount, interestRateMode, referralCode, onBehalfOf)
+}
+
+// BuildAaveV3RepayCallData creates wrapped call data for repay
+func BuildAaveV3RepayCallData(asset common.Address, amount *big.Int, interestRateMode *big.Int, onBehalfOf common.Address) (hexutil.Bytes, error) {
+	return BuildAaveV3CallData("repay", asset, amount, interestRateMode, onBehalfOf)
+}
+
+// AaveV3UserAccountData is the result of getUserAccountData, amounts are in the base
+// currency of the price oracle
+type AaveV3UserAccountData = AaveGetUserAccountDataOutput
+
+// DecodeAaveV3GetUserAccountData decodes the return data of getUserAccountData
+func DecodeAaveV3GetUserAccountData(data []byte) (AaveV3UserAccountData, error) {
+	return Unpack_aave_getUserAccountData(data)
+}
+
+// DecodeAaveV3GetReserveData decodes the return data of getReserveData
+func DecodeAaveV3GetReserveData(data []byte) (ReserveData, error) {
+	return Unpack_aave_getReserveData(data)
+}
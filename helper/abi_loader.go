@@ -1,63 +1,204 @@
 package helper
 
 import (
-	"bytes"
 	"embed"
 	"fmt"
+	"math/big"
+	"reflect"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
+//go:generate go run ./internal/abigen -abi abi -out . -rename pairinfo=PairInfo
+
 //go:embed abi/*.json
 var abiFiles embed.FS
 
-// loadABI loads an ABI from the embedded JSON files
-func loadABI(filename string) (abi.ABI, error) {
-	data, err := abiFiles.ReadFile(fmt.Sprintf("abi/%s.json", filename))
-	if err != nil {
-		return abi.ABI{}, fmt.Errorf("failed to read ABI file %s: %w", filename, err)
+// defaultRegistry holds the embedded ABIs and the ones registered at runtime
+var (
+	defaultRegistry = NewABIRegistry()
+	embeddedABIErr  error
+)
+
+func init() {
+	embeddedABIErr = defaultRegistry.RegisterFS(abiFiles, "abi/*.json")
+}
+
+// Registry returns the registry used by the Build_* helpers
+// Register a new router ABI under RouterMoudleABIName to target a new router version.
+func Registry() *ABIRegistry {
+	return defaultRegistry
+}
+
+// EmbeddedABIError returns the error met while loading the embedded ABIs, if any
+func EmbeddedABIError() error {
+	return embeddedABIErr
+}
+
+// RegisterABI parses a JSON ABI and registers it in the default registry under name
+func RegisterABI(name string, data []byte) error {
+	return defaultRegistry.RegisterJSON(name, data)
+}
+
+// loadABI returns the ABI registered under name in the default registry
+func loadABI(name string) (*abi.ABI, error) {
+	contractABI, err := defaultRegistry.ABI(name)
+	if err != nil && embeddedABIErr != nil {
+		return nil, fmt.Errorf("%w (embedded ABIs: %v)", err, embeddedABIErr)
 	}
+	return contractABI, err
+}
 
-	parsedABI, err := abi.JSON(bytes.NewReader(data))
-	if err != nil {
-		return abi.ABI{}, fmt.Errorf("failed to parse ABI for %s: %w", filename, err)
+// packABI encodes a call to functionName of the ABI registered under name
+func packABI(name, functionName string, args ...interface{}) ([]byte, error) {
+	if _, err := loadABI(name); err != nil {
+		return nil, err
 	}
+	return defaultRegistry.Pack(name, functionName, args...)
+}
 
-	return parsedABI, nil
+// unpackABI decodes the return data of the function with signature sig of the ABI
+// registered under name; outputs is the number of return values the caller expects,
+// an ABI registered at runtime may not match the generated decoders
+func unpackABI(name, sig string, data []byte, outputs int) ([]interface{}, error) {
+	overloads, err := defaultRegistry.Overloads(name, sig)
+	if err != nil {
+		if _, loadErr := loadABI(name); loadErr != nil {
+			return nil, loadErr
+		}
+		return nil, err
+	}
+	values, err := overloads[0].Outputs.Unpack(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack %s: %w", sig, err)
+	}
+	if len(values) != outputs {
+		return nil, fmt.Errorf("failed to unpack %s: %d return values, want %d", sig, len(values), outputs)
+	}
+	return values, nil
 }
 
-// Initialize all ABIs
-var (
-	erc20ABI            abi.ABI
-	routerMoudleABI     abi.ABI
-	swapMoudleABI       abi.ABI
-	calculatorMoudleABI abi.ABI
-)
+// convertOutput converts return value i of sig, as returned by unpackABI, to T
+func convertOutput[T any](sig string, values []interface{}, i int) (result T, err error) {
+	if i >= len(values) {
+		return result, fmt.Errorf("failed to unpack %s: no return value %d", sig, i)
+	}
+	if value, ok := values[i].(T); ok {
+		return value, nil
+	}
+	// abi.ConvertType panics, or recurses forever, on values it cannot set into T
+	if values[i] == nil || !settable(reflect.TypeOf(&result).Elem(), reflect.TypeOf(values[i])) {
+		return result, fmt.Errorf("failed to unpack %s: return value %d is %T, want %T", sig, i, values[i], result)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to unpack %s: return value %d: %v", sig, i, r)
+		}
+	}()
+	converted, ok := abi.ConvertType(values[i], new(T)).(*T)
+	if !ok {
+		return result, fmt.Errorf("failed to unpack %s: return value %d is %T, want %T", sig, i, values[i], result)
+	}
+	return *converted, nil
+}
 
-func init() {
-	var err error
+// settable reports whether abi.ConvertType can set a src value into dst, e.g. the
+// anonymous tuple structs of go-ethereum into the generated structs
+func settable(dst, src reflect.Type) bool {
+	switch {
+	case src.AssignableTo(dst):
+		return true
+	case dst.Kind() == reflect.Ptr && dst.Elem() != reflect.TypeOf(big.Int{}):
+		return settable(dst.Elem(), src)
+	case dst.Kind() == reflect.Slice && src.Kind() == reflect.Slice,
+		dst.Kind() == reflect.Array && src.Kind() == reflect.Array:
+		return settable(dst.Elem(), src.Elem())
+	case dst.Kind() == reflect.Struct && src.Kind() == reflect.Struct:
+		if src.NumField() > dst.NumField() {
+			return false
+		}
+		for i := 0; i < src.NumField(); i++ {
+			if !settable(dst.Field(i).Type, src.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
 
-	// Load ERC20 ABI
-	erc20ABI, err = loadABI("erc20")
+// unpackEvent decodes log into out, the indexed fields from the topics
+func unpackEvent(name, eventName string, log types.Log, out interface{}) error {
+	contractABI, err := loadABI(name)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to load ERC20 ABI: %v", err))
+		return err
 	}
-
-	// Load calculatorMoudle ABI
-	calculatorMoudleABI, err = loadABI("calculatorMoudle")
+	event, ok := contractABI.Events[eventName]
+	if !ok {
+		return fmt.Errorf("event %s not found in %s", eventName, name)
+	}
+	indexed, topics, err := eventTopics(&event, log)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to load calculatorMoudle ABI: %v", err))
+		return err
 	}
-
-	// Load routerMoudle ABI
-	routerMoudleABI, err = loadABI("routerMoudle")
+	values, err := event.Inputs.Unpack(log.Data)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to load routerMoudle ABI: %v", err))
+		return fmt.Errorf("failed to unpack %s: %w", eventName, err)
+	}
+	if err := event.Inputs.Copy(out, values); err != nil {
+		return fmt.Errorf("failed to unpack %s: %w", eventName, err)
+	}
+	if err := abi.ParseTopics(out, indexed, topics); err != nil {
+		return fmt.Errorf("failed to unpack %s topics: %w", eventName, err)
 	}
+	return nil
+}
 
-	// Load swapMoudle ABI
-	swapMoudleABI, err = loadABI("swapMoudle")
+// unpackEventMap decodes log into out by argument name
+func unpackEventMap(event Event, log types.Log, out map[string]interface{}) error {
+	indexed, topics, err := eventTopics(event.Event, log)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to load swapMoudle ABI: %v", err))
+		return err
+	}
+	if err := event.Inputs.UnpackIntoMap(out, log.Data); err != nil {
+		return fmt.Errorf("failed to unpack %s: %w", event.Name, err)
+	}
+	if err := abi.ParseTopicsIntoMap(out, indexed, topics); err != nil {
+		return fmt.Errorf("failed to unpack %s topics: %w", event.Name, err)
+	}
+	return nil
+}
+
+// eventTopics checks log is an event and returns its indexed arguments and topics
+func eventTopics(event *abi.Event, log types.Log) (abi.Arguments, []common.Hash, error) {
+	if !event.Anonymous && (len(log.Topics) == 0 || log.Topics[0] != event.ID) {
+		return nil, nil, fmt.Errorf("log is not a %s event", event.Name)
+	}
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	topics := log.Topics
+	if !event.Anonymous {
+		topics = topics[1:]
+	}
+	if len(topics) != len(indexed) {
+		return nil, nil, fmt.Errorf("%s log has %d indexed topics, expected %d", event.Name, len(topics), len(indexed))
+	}
+	return indexed, topics, nil
+}
+
+// moduleABIs returns the module ABIs custom errors are decoded against
+func moduleABIs() []*abi.ABI {
+	var abis []*abi.ABI
+	for _, name := range []string{RouterMoudleABIName, SwapMoudleABIName, CalculatorMoudleABIName} {
+		if contractABI, err := defaultRegistry.ABI(name); err == nil {
+			abis = append(abis, contractABI)
+		}
 	}
+	return abis
 }
@@ -0,0 +1,76 @@
+package helper
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestUnpackGetReserves(t *testing.T) {
+	// getReserves() of a pair holding 1000 and 2000 at timestamp 1700000000
+	data := hexutil.MustDecode(fmt.Sprintf("0x%064x%064x%064x", 1000, 2000, 1700000000))
+	reserves, err := Unpack_uniswapv2_getReserves(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reserves.Reserve0.Int64() != 1000 || reserves.Reserve1.Int64() != 2000 || reserves.BlockTimestampLast != 1700000000 {
+		t.Errorf("reserves = %+v", reserves)
+	}
+
+	if _, err := Unpack_uniswapv2_getReserves(data[:64]); err == nil {
+		t.Error("short return data decoded")
+	}
+}
+
+func TestUnpackTuple(t *testing.T) {
+	// getReserveData returns a 15 word tuple, word i holds i+1
+	data := "0x"
+	for i := 1; i <= 15; i++ {
+		data += fmt.Sprintf("%064x", i)
+	}
+	reserve, err := Unpack_aave_getReserveData(hexutil.MustDecode(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reserve.Configuration.Data.Int64() != 1 || reserve.Id != 8 ||
+		reserve.ATokenAddress != common.HexToAddress("0x09") || reserve.IsolationModeTotalDebt.Int64() != 15 {
+		t.Errorf("reserve = %+v", reserve)
+	}
+}
+
+func TestUnpackMismatchedABI(t *testing.T) {
+	// an ABI registered at runtime with fewer outputs than the generated decoder expects,
+	// in a registry of its own so the default one is left as the other tests expect
+	registry := defaultRegistry
+	defaultRegistry = NewABIRegistry()
+	t.Cleanup(func() { defaultRegistry = registry })
+	if err := RegisterABI("test_mismatch", []byte(`[{"type":"function","name":"getReserves","inputs":[],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"}]`)); err != nil {
+		t.Fatal(err)
+	}
+	data := hexutil.MustDecode(fmt.Sprintf("0x%064x", 1))
+	if _, err := unpackABI("test_mismatch", "getReserves()", data, 3); err == nil {
+		t.Error("1 return value accepted for 3")
+	}
+	values, err := unpackABI("test_mismatch", "getReserves()", data, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		convert func() error
+		wantErr bool
+	}{
+		{"same type", func() error { _, err := convertOutput[*big.Int]("getReserves()", values, 0); return err }, false},
+		{"wrong type", func() error { _, err := convertOutput[common.Address]("getReserves()", values, 0); return err }, true},
+		{"missing value", func() error { _, err := convertOutput[*big.Int]("getReserves()", values, 1); return err }, true},
+	}
+	for _, tt := range tests {
+		if err := tt.convert(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+// Code generated by internal/abigen from abi/algebra.json. DO NOT EDIT.
+
+package helper
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// AlgebraABIName is the registry name of abi/algebra.json
+const AlgebraABIName = "algebra"
+
+// Pack_algebra_globalState packs a call to globalState()
+func Pack_algebra_globalState() (hexutil.Bytes, error) {
+	data, err := packABI(AlgebraABIName, "globalState()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// AlgebraGlobalStateOutput holds the return values of globalState()
+type AlgebraGlobalStateOutput struct {
+	Price              *big.Int
+	Tick               *big.Int
+	Fee                uint16
+	TimepointIndex     uint16
+	CommunityFeeToken0 uint8
+	CommunityFeeToken1 uint8
+	Unlocked           bool
+}
+
+// Unpack_algebra_globalState decodes the return data of globalState()
+func Unpack_algebra_globalState(data []byte) (AlgebraGlobalStateOutput, error) {
+	var result AlgebraGlobalStateOutput
+	values, err := unpackABI(AlgebraABIName, "globalState()", data, 7)
+	if err != nil {
+		return result, err
+	}
+	if result.Price, err = convertOutput[*big.Int]("globalState()", values, 0); err != nil {
+		return result, err
+	}
+	if result.Tick, err = convertOutput[*big.Int]("globalState()", values, 1); err != nil {
+		return result, err
+	}
+	if result.Fee, err = convertOutput[uint16]("globalState()", values, 2); err != nil {
+		return result, err
+	}
+	if result.TimepointIndex, err = convertOutput[uint16]("globalState()", values, 3); err != nil {
+		return result, err
+	}
+	if result.CommunityFeeToken0, err = convertOutput[uint8]("globalState()", values, 4); err != nil {
+		return result, err
+	}
+	if result.CommunityFeeToken1, err = convertOutput[uint8]("globalState()", values, 5); err != nil {
+		return result, err
+	}
+	if result.Unlocked, err = convertOutput[bool]("globalState()", values, 6); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// Pack_algebra_liquidity packs a call to liquidity()
+func Pack_algebra_liquidity() (hexutil.Bytes, error) {
+	data, err := packABI(AlgebraABIName, "liquidity()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_algebra_liquidity decodes the return data of liquidity()
+func Unpack_algebra_liquidity(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(AlgebraABIName, "liquidity()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("liquidity()", values, 0)
+}
+
+// Pack_algebra_tickSpacing packs a call to tickSpacing()
+func Pack_algebra_tickSpacing() (hexutil.Bytes, error) {
+	data, err := packABI(AlgebraABIName, "tickSpacing()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_algebra_tickSpacing decodes the return data of tickSpacing()
+func Unpack_algebra_tickSpacing(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(AlgebraABIName, "tickSpacing()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("tickSpacing()", values, 0)
+}
+
+// Pack_algebra_tickTable packs a call to tickTable(int16)
+func Pack_algebra_tickTable(arg0 int16) (hexutil.Bytes, error) {
+	data, err := packABI(AlgebraABIName, "tickTable(int16)", arg0)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_algebra_tickTable decodes the return data of tickTable(int16)
+func Unpack_algebra_tickTable(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(AlgebraABIName, "tickTable(int16)", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("tickTable(int16)", values, 0)
+}
+
+// Pack_algebra_ticks packs a call to ticks(int24)
+func Pack_algebra_ticks(arg0 *big.Int) (hexutil.Bytes, error) {
+	data, err := packABI(AlgebraABIName, "ticks(int24)", arg0)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// AlgebraTicksOutput holds the return values of ticks(int24)
+type AlgebraTicksOutput struct {
+	LiquidityTotal           *big.Int
+	LiquidityDelta           *big.Int
+	OuterFeeGrowth0Token     *big.Int
+	OuterFeeGrowth1Token     *big.Int
+	OuterTickCumulative      *big.Int
+	OuterSecondsPerLiquidity *big.Int
+	OuterSecondsSpent        uint32
+	Initialized              bool
+}
+
+// Unpack_algebra_ticks decodes the return data of ticks(int24)
+func Unpack_algebra_ticks(data []byte) (AlgebraTicksOutput, error) {
+	var result AlgebraTicksOutput
+	values, err := unpackABI(AlgebraABIName, "ticks(int24)", data, 8)
+	if err != nil {
+		return result, err
+	}
+	if result.LiquidityTotal, err = convertOutput[*big.Int]("ticks(int24)", values, 0); err != nil {
+		return result, err
+	}
+	if result.LiquidityDelta, err = convertOutput[*big.Int]("ticks(int24)", values, 1); err != nil {
+		return result, err
+	}
+	if result.OuterFeeGrowth0Token, err = convertOutput[*big.Int]("ticks(int24)", values, 2); err != nil {
+		return result, err
+	}
+	if result.OuterFeeGrowth1Token, err = convertOutput[*big.Int]("ticks(int24)", values, 3); err != nil {
+		return result, err
+	}
+	if result.OuterTickCumulative, err = convertOutput[*big.Int]("ticks(int24)", values, 4); err != nil {
+		return result, err
+	}
+	if result.OuterSecondsPerLiquidity, err = convertOutput[*big.Int]("ticks(int24)", values, 5); err != nil {
+		return result, err
+	}
+	if result.OuterSecondsSpent, err = convertOutput[uint32]("ticks(int24)", values, 6); err != nil {
+		return result, err
+	}
+	if result.Initialized, err = convertOutput[bool]("ticks(int24)", values, 7); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// Pack_algebra_token0 packs a call to token0()
+func Pack_algebra_token0() (hexutil.Bytes, error) {
+	data, err := packABI(AlgebraABIName, "token0()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_algebra_token0 decodes the return data of token0()
+func Unpack_algebra_token0(data []byte) (common.Address, error) {
+	var result common.Address
+	values, err := unpackABI(AlgebraABIName, "token0()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[common.Address]("token0()", values, 0)
+}
+
+// Pack_algebra_token1 packs a call to token1()
+func Pack_algebra_token1() (hexutil.Bytes, error) {
+	data, err := packABI(AlgebraABIName, "token1()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_algebra_token1 decodes the return data of token1()
+func Unpack_algebra_token1(data []byte) (common.Address, error) {
+	var result common.Address
+	values, err := unpackABI(AlgebraABIName, "token1()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[common.Address]("token1()", values, 0)
+}
+
+// AlgebraFeeEvent is the Fee(uint16) event
+type AlgebraFeeEvent struct {
+	Fee uint16
+	Raw types.Log
+}
+
+// Unpack_algebra_FeeEvent decodes a Fee log
+func Unpack_algebra_FeeEvent(log types.Log) (*AlgebraFeeEvent, error) {
+	event := &AlgebraFeeEvent{Raw: log}
+	if err := unpackEvent(AlgebraABIName, "Fee", log, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// AlgebraSwapEvent is the Swap(address,address,int256,int256,uint160,uint128,int24,uint24,uint24) event
+type AlgebraSwapEvent struct {
+	Sender      common.Address
+	Recipient   common.Address
+	Amount0     *big.Int
+	Amount1     *big.Int
+	Price       *big.Int
+	Liquidity   *big.Int
+	Tick        *big.Int
+	OverrideFee *big.Int
+	PluginFee   *big.Int
+	Raw         types.Log
+}
+
+// Unpack_algebra_SwapEvent decodes a Swap log
+func Unpack_algebra_SwapEvent(log types.Log) (*AlgebraSwapEvent, error) {
+	event := &AlgebraSwapEvent{Raw: log}
+	if err := unpackEvent(AlgebraABIName, "Swap", log, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func init() {
+	registerEventDecoder(AlgebraABIName, "Fee", func(log types.Log) (interface{}, error) { return Unpack_algebra_FeeEvent(log) })
+	registerEventDecoder(AlgebraABIName, "Swap", func(log types.Log) (interface{}, error) { return Unpack_algebra_SwapEvent(log) })
+}
@@ -0,0 +1,202 @@
+// Code generated by internal/abigen from abi/balancer.json. DO NOT EDIT.
+
+package helper
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// BalancerABIName is the registry name of abi/balancer.json
+const BalancerABIName = "balancer"
+
+// Pack_balancer_getAmplificationParameter packs a call to getAmplificationParameter()
+func Pack_balancer_getAmplificationParameter() (hexutil.Bytes, error) {
+	data, err := packABI(BalancerABIName, "getAmplificationParameter()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// BalancerGetAmplificationParameterOutput holds the return values of getAmplificationParameter()
+type BalancerGetAmplificationParameterOutput struct {
+	Value      *big.Int
+	IsUpdating bool
+	Precision  *big.Int
+}
+
+// Unpack_balancer_getAmplificationParameter decodes the return data of getAmplificationParameter()
+func Unpack_balancer_getAmplificationParameter(data []byte) (BalancerGetAmplificationParameterOutput, error) {
+	var result BalancerGetAmplificationParameterOutput
+	values, err := unpackABI(BalancerABIName, "getAmplificationParameter()", data, 3)
+	if err != nil {
+		return result, err
+	}
+	if result.Value, err = convertOutput[*big.Int]("getAmplificationParameter()", values, 0); err != nil {
+		return result, err
+	}
+	if result.IsUpdating, err = convertOutput[bool]("getAmplificationParameter()", values, 1); err != nil {
+		return result, err
+	}
+	if result.Precision, err = convertOutput[*big.Int]("getAmplificationParameter()", values, 2); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// Pack_balancer_getBptIndex packs a call to getBptIndex()
+func Pack_balancer_getBptIndex() (hexutil.Bytes, error) {
+	data, err := packABI(BalancerABIName, "getBptIndex()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_balancer_getBptIndex decodes the return data of getBptIndex()
+func Unpack_balancer_getBptIndex(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(BalancerABIName, "getBptIndex()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("getBptIndex()", values, 0)
+}
+
+// Pack_balancer_getNormalizedWeights packs a call to getNormalizedWeights()
+func Pack_balancer_getNormalizedWeights() (hexutil.Bytes, error) {
+	data, err := packABI(BalancerABIName, "getNormalizedWeights()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_balancer_getNormalizedWeights decodes the return data of getNormalizedWeights()
+func Unpack_balancer_getNormalizedWeights(data []byte) ([]*big.Int, error) {
+	var result []*big.Int
+	values, err := unpackABI(BalancerABIName, "getNormalizedWeights()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[[]*big.Int]("getNormalizedWeights()", values, 0)
+}
+
+// Pack_balancer_getPoolId packs a call to getPoolId()
+func Pack_balancer_getPoolId() (hexutil.Bytes, error) {
+	data, err := packABI(BalancerABIName, "getPoolId()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_balancer_getPoolId decodes the return data of getPoolId()
+func Unpack_balancer_getPoolId(data []byte) ([32]byte, error) {
+	var result [32]byte
+	values, err := unpackABI(BalancerABIName, "getPoolId()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[[32]byte]("getPoolId()", values, 0)
+}
+
+// Pack_balancer_getPoolTokens packs a call to getPoolTokens(bytes32)
+func Pack_balancer_getPoolTokens(poolId [32]byte) (hexutil.Bytes, error) {
+	data, err := packABI(BalancerABIName, "getPoolTokens(bytes32)", poolId)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// BalancerGetPoolTokensOutput holds the return values of getPoolTokens(bytes32)
+type BalancerGetPoolTokensOutput struct {
+	Tokens          []common.Address
+	Balances        []*big.Int
+	LastChangeBlock *big.Int
+}
+
+// Unpack_balancer_getPoolTokens decodes the return data of getPoolTokens(bytes32)
+func Unpack_balancer_getPoolTokens(data []byte) (BalancerGetPoolTokensOutput, error) {
+	var result BalancerGetPoolTokensOutput
+	values, err := unpackABI(BalancerABIName, "getPoolTokens(bytes32)", data, 3)
+	if err != nil {
+		return result, err
+	}
+	if result.Tokens, err = convertOutput[[]common.Address]("getPoolTokens(bytes32)", values, 0); err != nil {
+		return result, err
+	}
+	if result.Balances, err = convertOutput[[]*big.Int]("getPoolTokens(bytes32)", values, 1); err != nil {
+		return result, err
+	}
+	if result.LastChangeBlock, err = convertOutput[*big.Int]("getPoolTokens(bytes32)", values, 2); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// Pack_balancer_getScalingFactors packs a call to getScalingFactors()
+func Pack_balancer_getScalingFactors() (hexutil.Bytes, error) {
+	data, err := packABI(BalancerABIName, "getScalingFactors()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_balancer_getScalingFactors decodes the return data of getScalingFactors()
+func Unpack_balancer_getScalingFactors(data []byte) ([]*big.Int, error) {
+	var result []*big.Int
+	values, err := unpackABI(BalancerABIName, "getScalingFactors()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[[]*big.Int]("getScalingFactors()", values, 0)
+}
+
+// Pack_balancer_getSwapFeePercentage packs a call to getSwapFeePercentage()
+func Pack_balancer_getSwapFeePercentage() (hexutil.Bytes, error) {
+	data, err := packABI(BalancerABIName, "getSwapFeePercentage()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_balancer_getSwapFeePercentage decodes the return data of getSwapFeePercentage()
+func Unpack_balancer_getSwapFeePercentage(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(BalancerABIName, "getSwapFeePercentage()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("getSwapFeePercentage()", values, 0)
+}
+
+// BalancerSwapEvent is the Swap(bytes32,address,address,uint256,uint256) event
+type BalancerSwapEvent struct {
+	PoolId    [32]byte
+	TokenIn   common.Address
+	TokenOut  common.Address
+	AmountIn  *big.Int
+	AmountOut *big.Int
+	Raw       types.Log
+}
+
+// Unpack_balancer_SwapEvent decodes a Swap log
+func Unpack_balancer_SwapEvent(log types.Log) (*BalancerSwapEvent, error) {
+	event := &BalancerSwapEvent{Raw: log}
+	if err := unpackEvent(BalancerABIName, "Swap", log, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func init() {
+	registerEventDecoder(BalancerABIName, "Swap", func(log types.Log) (interface{}, error) { return Unpack_balancer_SwapEvent(log) })
+}
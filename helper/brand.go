@@ -0,0 +1,374 @@
+package helper
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Brand identifies the DEX of a pair for the contracts, PairInfo.Brand
+type Brand uint8
+
+// BrandUniswapV4 pairs are Uniswap V4 pools, their tokens are the pool key currencies
+// and their Data the pool key, see PoolKeyOf
+const BrandUniswapV4 Brand = 22
+
+// ErrUnknownBrand is returned when looking up a brand that is not registered, and when a
+// strict registry builds the PairInfo of one
+var ErrUnknownBrand = errors.New("unknown brand")
+
+// Family is the DEX family of a brand, it decides how the contracts swap the pair
+type Family uint8
+
+const (
+	FamilyUniswapV2 Family = iota + 1
+	FamilyUniswapV3
+	FamilyAlgebra
+	FamilySmardex
+	FamilyCurve
+	FamilyBalancer
+	FamilyDodo
+	FamilyUniswapV4
+)
+
+func (f Family) String() string {
+	switch f {
+	case FamilyUniswapV2:
+		return "uniswapv2"
+	case FamilyUniswapV3:
+		return "uniswapv3"
+	case FamilyAlgebra:
+		return "algebra"
+	case FamilySmardex:
+		return "smardex"
+	case FamilyCurve:
+		return "curve"
+	case FamilyBalancer:
+		return "balancer"
+	case FamilyDodo:
+		return "dodo"
+	case FamilyUniswapV4:
+		return "uniswapv4"
+	}
+	return fmt.Sprintf("Family(%d)", uint8(f))
+}
+
+func (f Family) valid() bool {
+	return f >= FamilyUniswapV2 && f <= FamilyUniswapV4
+}
+
+// FeeKind tells how the contracts read the fee of a pair
+type FeeKind uint8
+
+const (
+	// FeePips pairs swap with PairInfo.Fee in hundredths of a bip, 3000 is 0.3%
+	FeePips FeeKind = iota
+	// FeeFromPool pairs read the fee from the pool, e.g. Algebra or Curve, and
+	// PairInfo.Fee is not used
+	FeeFromPool
+)
+
+// maxFeePips is the FeePips of a 100% fee
+const maxFeePips = 1_000_000
+
+// BrandInfo describes how the pairs of a brand are encoded
+type BrandInfo struct {
+	Brand  Brand
+	Name   string
+	Family Family
+	Fee    FeeKind
+	// EncodeData returns PairInfo.Data, nil encodes the Data of the family
+	EncodeData func(pair PairInfoInterface) ([]byte, error)
+}
+
+// LoanPoolType returns LoanPool.Types of the pools of the brand, ok is false when the
+// contracts cannot flash loan from them
+func (b BrandInfo) LoanPoolType() (uint8, bool) {
+	switch b.Family {
+	case FamilyDodo:
+		return 0, true
+	case FamilyBalancer:
+		return 1, true
+	case FamilyUniswapV3:
+		return 2, true
+	}
+	return 0, false
+}
+
+// FlashLoan reports whether the contracts can flash loan from the pools of the brand
+func (b BrandInfo) FlashLoan() bool {
+	_, ok := b.LoanPoolType()
+	return ok
+}
+
+// PairInfo encodes pair for the contracts
+func (b BrandInfo) PairInfo(pair PairInfoInterface) (PairInfo, error) {
+	if b.Family == FamilyUniswapV4 {
+		return b.uniswapV4PairInfo(pair)
+	}
+	if b.Fee == FeePips && pair.GetFee() >= maxFeePips {
+		return PairInfo{}, fmt.Errorf("pair %s: fee %d of %s is not below %d", pair.GetPairHex().Hex(), pair.GetFee(), b.Name, maxFeePips)
+	}
+	encode := b.EncodeData
+	if encode == nil {
+		encode = b.Family.encodeData
+	}
+	data, err := encode(pair)
+	if err != nil {
+		return PairInfo{}, fmt.Errorf("pair %s: failed to encode %s data: %w", pair.GetPairHex().Hex(), b.Name, err)
+	}
+	return PairInfo{
+		PairAddr: pair.GetPairHex(),
+		Token0:   pair.GetT0Hex(),
+		Token1:   pair.GetT1Hex(),
+		Fee:      pair.GetFee(),
+		Brand:    uint8(b.Brand),
+		Data:     data,
+	}, nil
+}
+
+// uniswapV4PairInfo encodes a Uniswap V4 pair: the tokens are the pool key currencies,
+// address zero for native ETH, the fee is the key fee and Data is abi.encode(key).
+// Pairs without a pool key keep their currencies, or tokens, fee and Data as they are.
+func (b BrandInfo) uniswapV4PairInfo(pair PairInfoInterface) (PairInfo, error) {
+	key, err := PoolKeyOf(pair)
+	if errors.Is(err, ErrNoPoolKey) {
+		return b.uniswapV4PairInfoWithoutKey(pair)
+	}
+	if err != nil {
+		return PairInfo{}, fmt.Errorf("pair %s: invalid %s pool key: %w", pair.GetPairHex().Hex(), b.Name, err)
+	}
+	var data []byte
+	if b.EncodeData != nil {
+		data, err = b.EncodeData(pair)
+	} else {
+		data, err = key.Encode()
+	}
+	if err != nil {
+		return PairInfo{}, fmt.Errorf("pair %s: failed to encode %s data: %w", pair.GetPairHex().Hex(), b.Name, err)
+	}
+	return PairInfo{
+		PairAddr: pair.GetPairHex(),
+		Token0:   key.Currency0,
+		Token1:   key.Currency1,
+		Fee:      key.Fee,
+		Brand:    uint8(b.Brand),
+		Data:     data,
+	}, nil
+}
+
+// uniswapV4PairInfoWithoutKey encodes a Uniswap V4 pair that carries no pool key: the
+// tokens are GetCurrency0 and GetCurrency1, or the pair tokens when unset, with GetFee
+// and GetData
+func (b BrandInfo) uniswapV4PairInfoWithoutKey(pair PairInfoInterface) (PairInfo, error) {
+	token0, token1 := pair.GetT0Hex(), pair.GetT1Hex()
+	if currency0 := pair.GetCurrency0(); currency0 != nil {
+		token0 = *currency0
+	}
+	if currency1 := pair.GetCurrency1(); currency1 != nil {
+		token1 = *currency1
+	}
+	encode := b.EncodeData
+	if encode == nil {
+		encode = pairData
+	}
+	data, err := encode(pair)
+	if err != nil {
+		return PairInfo{}, fmt.Errorf("pair %s: failed to encode %s data: %w", pair.GetPairHex().Hex(), b.Name, err)
+	}
+	return PairInfo{
+		PairAddr: pair.GetPairHex(),
+		Token0:   token0,
+		Token1:   token1,
+		Fee:      pair.GetFee(),
+		Brand:    uint8(b.Brand),
+		Data:     data,
+	}, nil
+}
+
+// CurvePair is implemented by pairs that know the coin indexes of their Curve pool,
+// encoded as Data abi.encode(int128 i, int128 j)
+type CurvePair interface {
+	GetCurveIndexes() (i, j int)
+}
+
+// BalancerPair is implemented by pairs that know the id of their Balancer pool, encoded
+// as the 32 bytes of Data
+type BalancerPair interface {
+	GetPoolID() common.Hash
+}
+
+// encodeData returns the Data of pair for the family: the indexes of Curve pairs and the
+// pool id of Balancer pairs, or GetData checked for the family
+func (f Family) encodeData(pair PairInfoInterface) ([]byte, error) {
+	switch f {
+	case FamilyCurve:
+		if curve, ok := pair.(CurvePair); ok {
+			i, j := curve.GetCurveIndexes()
+			int128, err := abi.NewType("int128", "", nil)
+			if err != nil {
+				return nil, err
+			}
+			return abi.Arguments{{Type: int128}, {Type: int128}}.Pack(big.NewInt(int64(i)), big.NewInt(int64(j)))
+		}
+		data, err := pairData(pair)
+		if err != nil {
+			return nil, err
+		}
+		// without indexes the contracts look the coins up in the pool
+		if len(data) != 0 && len(data) != 64 {
+			return nil, fmt.Errorf("%d bytes of data, want the coin indexes", len(data))
+		}
+		return data, nil
+	case FamilyBalancer:
+		if balancer, ok := pair.(BalancerPair); ok {
+			id := balancer.GetPoolID()
+			return id[:], nil
+		}
+		data, err := pairData(pair)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) != common.HashLength {
+			return nil, fmt.Errorf("%d bytes of data, want the pool id", len(data))
+		}
+		// a pool id starts with the pool address
+		if common.BytesToAddress(data[:common.AddressLength]) != pair.GetPairHex() {
+			return nil, fmt.Errorf("pool id %x is not of pool %s", data, pair.GetPairHex().Hex())
+		}
+		return data, nil
+	}
+	return pairData(pair)
+}
+
+// pairData decodes the hex of GetData
+func pairData(pair PairInfoInterface) ([]byte, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(pair.GetData(), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid data hex: %w", err)
+	}
+	return data, nil
+}
+
+// BrandRegistry holds the brands the contracts were deployed with. Brand numbers are
+// set by the contracts' brand dispatch, register them at startup. Pairs of a brand that
+// is not registered keep their tokens, fee and Data as they are, unless the registry is
+// strict. BrandRegistry is safe for concurrent use.
+//
+//	err := helper.Brands().Register(helper.BrandInfo{Brand: brandSushiswap, Name: "sushiswap", Family: helper.FamilyUniswapV2})
+type BrandRegistry struct {
+	mu     sync.RWMutex
+	brands map[Brand]BrandInfo
+	strict bool
+}
+
+// NewBrandRegistry creates an empty registry
+func NewBrandRegistry() *BrandRegistry {
+	return &BrandRegistry{brands: make(map[Brand]BrandInfo)}
+}
+
+// Register adds info, replacing the brand with the same number
+func (r *BrandRegistry) Register(info BrandInfo) error {
+	if info.Name == "" {
+		return fmt.Errorf("brand %d has no name", info.Brand)
+	}
+	if !info.Family.valid() {
+		return fmt.Errorf("brand %s: invalid family %s", info.Name, info.Family)
+	}
+	if info.Fee != FeePips && info.Fee != FeeFromPool {
+		return fmt.Errorf("brand %s: invalid fee kind %d", info.Name, info.Fee)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.brands[info.Brand] = info
+	return nil
+}
+
+// Lookup returns the registered brand
+func (r *BrandRegistry) Lookup(brand Brand) (BrandInfo, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	info, ok := r.brands[brand]
+	if !ok {
+		return BrandInfo{}, fmt.Errorf("%w: %d", ErrUnknownBrand, brand)
+	}
+	return info, nil
+}
+
+// All returns the registered brands ordered by number
+func (r *BrandRegistry) All() []BrandInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	brands := make([]BrandInfo, 0, len(r.brands))
+	for _, info := range r.brands {
+		brands = append(brands, info)
+	}
+	sort.Slice(brands, func(i, j int) bool { return brands[i].Brand < brands[j].Brand })
+	return brands
+}
+
+// SetStrict makes PairInfo fail with ErrUnknownBrand for the brands that are not
+// registered instead of passing their pairs through
+func (r *BrandRegistry) SetStrict(strict bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.strict = strict
+}
+
+// PairInfo encodes pair with its registered brand. The pairs of other brands keep
+// GetT0Hex, GetT1Hex, GetFee and the GetData bytes, or fail when the registry is strict.
+func (r *BrandRegistry) PairInfo(pair PairInfoInterface) (PairInfo, error) {
+	r.mu.RLock()
+	info, ok := r.brands[Brand(pair.GetBrand())]
+	strict := r.strict
+	r.mu.RUnlock()
+	if ok {
+		return info.PairInfo(pair)
+	}
+	if strict {
+		return PairInfo{}, fmt.Errorf("pair %s: %w: %d", pair.GetPairHex().Hex(), ErrUnknownBrand, pair.GetBrand())
+	}
+	data, err := pairData(pair)
+	if err != nil {
+		return PairInfo{}, fmt.Errorf("pair %s: %w", pair.GetPairHex().Hex(), err)
+	}
+	return PairInfo{
+		PairAddr: pair.GetPairHex(),
+		Token0:   pair.GetT0Hex(),
+		Token1:   pair.GetT1Hex(),
+		Fee:      pair.GetFee(),
+		Brand:    pair.GetBrand(),
+		Data:     data,
+	}, nil
+}
+
+// defaultBrands knows the brands defined by this package
+var defaultBrands = &BrandRegistry{brands: map[Brand]BrandInfo{
+	BrandUniswapV4: {Brand: BrandUniswapV4, Name: "uniswapv4", Family: FamilyUniswapV4},
+}}
+
+// Brands returns the registry the Build_* helpers encode pairs with
+func Brands() *BrandRegistry {
+	return defaultBrands
+}
+
+// NewLoanPoolFromPair creates the LoanPool to flash loan token from pair, the pair
+// brand must be a flash loan source
+func NewLoanPoolFromPair(pair PairInfoInterface, token common.Address, isWrappedAave bool) (*LoanPool, error) {
+	info, err := defaultBrands.Lookup(Brand(pair.GetBrand()))
+	if err != nil {
+		return nil, err
+	}
+	types, ok := info.LoanPoolType()
+	if !ok {
+		return nil, fmt.Errorf("brand %s of %s cannot flash loan", info.Name, pair.GetPairHex().Hex())
+	}
+	return NewLoanPool(pair.GetPairHex(), token, types, isWrappedAave), nil
+}
@@ -0,0 +1,281 @@
+package helper
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// testPair is a pair as the bot passes it to the Build_* helpers
+type testPair struct {
+	pair, token0, token1 common.Address
+	fee                  uint32
+	brand                Brand
+	data                 string
+	currency0, currency1 *common.Address
+}
+
+func (p testPair) GetPairHex() common.Address    { return p.pair }
+func (p testPair) GetT0Hex() common.Address      { return p.token0 }
+func (p testPair) GetT1Hex() common.Address      { return p.token1 }
+func (p testPair) GetFee() uint32                { return p.fee }
+func (p testPair) GetBrand() uint8               { return uint8(p.brand) }
+func (p testPair) GetData() string               { return p.data }
+func (p testPair) GetCurrency0() *common.Address { return p.currency0 }
+func (p testPair) GetCurrency1() *common.Address { return p.currency1 }
+
+// curveTestPair knows the coin indexes of its pool
+type curveTestPair struct {
+	testPair
+	i, j int
+}
+
+func (p curveTestPair) GetCurveIndexes() (int, int) { return p.i, p.j }
+
+var (
+	testWETH = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	testUSDC = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+)
+
+// brands of the test registry
+const (
+	testBrandV2 Brand = iota + 1
+	testBrandV3
+	testBrandAlgebra
+	testBrandCurve
+	testBrandBalancer
+	testBrandDodo
+)
+
+func testBrandRegistry(t *testing.T) *BrandRegistry {
+	t.Helper()
+	registry := NewBrandRegistry()
+	for _, info := range []BrandInfo{
+		{Brand: testBrandV2, Name: "uniswapv2", Family: FamilyUniswapV2},
+		{Brand: testBrandV3, Name: "uniswapv3", Family: FamilyUniswapV3},
+		{Brand: testBrandAlgebra, Name: "algebra", Family: FamilyAlgebra, Fee: FeeFromPool},
+		{Brand: testBrandCurve, Name: "curve", Family: FamilyCurve, Fee: FeeFromPool},
+		{Brand: testBrandBalancer, Name: "balancer", Family: FamilyBalancer, Fee: FeeFromPool},
+		{Brand: testBrandDodo, Name: "dodo", Family: FamilyDodo, Fee: FeeFromPool},
+	} {
+		if err := registry.Register(info); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return registry
+}
+
+func TestBrandRegistry(t *testing.T) {
+	registry := testBrandRegistry(t)
+	balancerPool := common.HexToAddress("0x96646936b91d6B9D7D0c47C496AfBF3D6ec7B6f8")
+	balancerID := "96646936b91d6b9d7d0c47c496afbf3d6ec7b6f8000200000000000000000019"
+	tests := []struct {
+		name     string
+		pair     PairInfoInterface
+		wantData string
+		wantLoan bool
+	}{
+		{"uniswapv2", testPair{pair: common.HexToAddress("0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc"), token0: testUSDC, token1: testWETH, fee: 3000, brand: testBrandV2, data: "0x"}, "", false},
+		{"uniswapv3", testPair{pair: common.HexToAddress("0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640"), token0: testUSDC, token1: testWETH, fee: 500, brand: testBrandV3}, "", true},
+		{"algebra", testPair{pair: common.HexToAddress("0xa1"), token0: testUSDC, token1: testWETH, brand: testBrandAlgebra}, "", false},
+		{"curve", curveTestPair{testPair{pair: common.HexToAddress("0xbEbc44782C7dB0a1A60Cb6fe97d0b483032FF1C7"), token0: testUSDC, token1: testWETH, brand: testBrandCurve}, 1, 2},
+			"0000000000000000000000000000000000000000000000000000000000000001" +
+				"0000000000000000000000000000000000000000000000000000000000000002", false},
+		{"balancer", testPair{pair: balancerPool, token0: testUSDC, token1: testWETH, brand: testBrandBalancer, data: balancerID}, balancerID, true},
+		{"dodo", testPair{pair: common.HexToAddress("0xd0"), token0: testUSDC, token1: testWETH, brand: testBrandDodo}, "", true},
+		// brands that are not registered pass their data through
+		{"unregistered", testPair{pair: common.HexToAddress("0x01"), token0: testUSDC, token1: testWETH, fee: 30, brand: 200, data: "0xc0ffee"}, "c0ffee", false},
+	}
+	for _, tt := range tests {
+		if info, err := registry.Lookup(Brand(tt.pair.GetBrand())); err == nil {
+			if info.Name != tt.name || info.FlashLoan() != tt.wantLoan {
+				t.Errorf("brand %d = %s, flash loan %t, want %s, %t", info.Brand, info.Name, info.FlashLoan(), tt.name, tt.wantLoan)
+			}
+		} else if !errors.Is(err, ErrUnknownBrand) || tt.name != "unregistered" {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		pairInfo, err := registry.PairInfo(tt.pair)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		want := PairInfo{
+			PairAddr: tt.pair.GetPairHex(),
+			Token0:   tt.pair.GetT0Hex(),
+			Token1:   tt.pair.GetT1Hex(),
+			Fee:      tt.pair.GetFee(),
+			Brand:    tt.pair.GetBrand(),
+		}
+		if pairInfo.PairAddr != want.PairAddr || pairInfo.Token0 != want.Token0 || pairInfo.Token1 != want.Token1 ||
+			pairInfo.Fee != want.Fee || pairInfo.Brand != want.Brand || common.Bytes2Hex(pairInfo.Data) != tt.wantData {
+			t.Errorf("%s: PairInfo = %+v, data %x, want data %s", tt.name, pairInfo, pairInfo.Data, tt.wantData)
+		}
+	}
+
+	if _, err := registry.PairInfo(testPair{pair: common.HexToAddress("0x01"), brand: testBrandV2, fee: maxFeePips}); err == nil {
+		t.Error("a 100% fee was encoded")
+	}
+	if _, err := registry.PairInfo(testPair{pair: balancerPool, brand: testBrandBalancer, data: "0x01"}); err == nil {
+		t.Error("a balancer pair without its pool id was encoded")
+	}
+
+	// a strict registry rejects the brands that are not registered
+	registry.SetStrict(true)
+	if _, err := registry.PairInfo(testPair{pair: common.HexToAddress("0x01"), brand: 200}); !errors.Is(err, ErrUnknownBrand) {
+		t.Errorf("unregistered brand of a strict registry err = %v", err)
+	}
+	if _, err := registry.PairInfo(testPair{pair: common.HexToAddress("0x01"), brand: testBrandV2}); err != nil {
+		t.Errorf("registered brand of a strict registry err = %v", err)
+	}
+}
+
+func TestBuildersEncodePairs(t *testing.T) {
+	// the brands are not registered in Brands(), their pairs pass through
+	v2 := testPair{pair: common.HexToAddress("0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc"), token0: testUSDC, token1: testWETH, fee: 3000, brand: 1, data: "0x01"}
+	v3 := testPair{pair: common.HexToAddress("0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640"), token0: testUSDC, token1: testWETH, fee: 500, brand: 2}
+	pairInfos := []PairInfo{
+		{PairAddr: v2.pair, Token0: testUSDC, Token1: testWETH, Fee: 3000, Brand: 1, Data: []byte{1}},
+		{PairAddr: v3.pair, Token0: testUSDC, Token1: testWETH, Fee: 500, Brand: 2, Data: []byte{}},
+	}
+	amountIn := big.NewInt(1e6)
+
+	tests := []struct {
+		name  string
+		build func() (hexutil.Bytes, error)
+		want  func() (hexutil.Bytes, error)
+	}{
+		{"multiSwap", func() (hexutil.Bytes, error) {
+			return Build_swap_MultiSwap(testUSDC, amountIn, big.NewInt(0), big.NewInt(0), v2, v3)
+		}, func() (hexutil.Bytes, error) {
+			return Pack_swapMoudle_multiSwap(testUSDC, amountIn, big.NewInt(0), big.NewInt(0), pairInfos)
+		}},
+		{"getBaseBalance", func() (hexutil.Bytes, error) {
+			return Build_calculatorModule_GetBaseBalance(testWETH, v2)
+		}, func() (hexutil.Bytes, error) {
+			return Pack_calculatorMoudle_getBaseBalance(testWETH, pairInfos[0])
+		}},
+		{"simulateFlashSwap", func() (hexutil.Bytes, error) {
+			return Build_router_SimulateFlashSwap(testUSDC, amountIn, []PairInfoInterface{v2, v3}, nil)
+		}, func() (hexutil.Bytes, error) {
+			return BuildRouterCallData("simulateFlashSwap", true, testUSDC, amountIn, pairInfos, []PairInfo{})
+		}},
+	}
+	for _, tt := range tests {
+		got, err := tt.build()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		want, err := tt.want()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s = %s, want %s", tt.name, got, want)
+		}
+	}
+}
+
+// v4TestPair knows the key of its pool
+type v4TestPair struct {
+	testPair
+	key PoolKey
+}
+
+func (p v4TestPair) GetPoolKey() PoolKey { return p.key }
+
+var testPoolManager = common.HexToAddress("0x000000000004444c5dc75cB358380D2e3dE08A90")
+
+func TestUniswapV4PairInfo(t *testing.T) {
+	native, usdc := NativeCurrency, testUSDC
+	// abi.encode of the ETH/USDC 0.05% pool key with a tick spacing of 10
+	keyHex := "0000000000000000000000000000000000000000000000000000000000000000" +
+		"000000000000000000000000a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48" +
+		"00000000000000000000000000000000000000000000000000000000000001f4" +
+		"000000000000000000000000000000000000000000000000000000000000000a" +
+		"0000000000000000000000000000000000000000000000000000000000000000"
+	pair := testPair{pair: testPoolManager, token0: testWETH, token1: testUSDC, fee: 3000, brand: BrandUniswapV4, data: "0x" + keyHex, currency0: &native, currency1: &usdc}
+
+	key, err := PoolKeyOf(pair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id, err := key.ID(); err != nil || id != common.HexToHash("0x21c67e77068de97969ba93d4aab21826d33ca12bb9f565d8496e8fda8a82ca27") {
+		t.Errorf("pool id = %s, %v", id.Hex(), err)
+	}
+
+	// getBaseBalance(WETH, pair): the tokens are the key currencies, native ETH is
+	// address zero, the fee is the key fee and Data the encoded key
+	data, err := Build_calculatorModule_GetBaseBalance(testWETH, pair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "0xf595961e" +
+		"000000000000000000000000c02aaa39b223fe8d0a0e5c4f27ead9083c756cc2" +
+		"0000000000000000000000000000000000000000000000000000000000000040" +
+		"000000000000000000000000000000000004444c5dc75cb358380d2e3de08a90" +
+		"0000000000000000000000000000000000000000000000000000000000000000" +
+		"000000000000000000000000a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48" +
+		"00000000000000000000000000000000000000000000000000000000000001f4" +
+		"0000000000000000000000000000000000000000000000000000000000000016" +
+		"00000000000000000000000000000000000000000000000000000000000000c0" +
+		"00000000000000000000000000000000000000000000000000000000000000a0" +
+		keyHex
+	if data.String() != want {
+		t.Errorf("getBaseBalance = %s, want %s", data, want)
+	}
+
+	// a pair knowing its key encodes the same key whatever GetData holds
+	keyed := v4TestPair{testPair{pair: testPoolManager, brand: BrandUniswapV4, data: "0xdeadbeef"}, key}
+	keyed.key.Fee, keyed.key.TickSpacing = 3000, 60
+	pairInfo, err := newPairInfo(keyed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id, _ := keyed.key.ID(); id != common.HexToHash("0xdce6394339af00981949f5f3baf27e3610c76326a700af57e4b3e3ae4977f78d") {
+		t.Errorf("pool id = %s", id.Hex())
+	}
+	if pairInfo.Token0 != NativeCurrency || pairInfo.Token1 != testUSDC || pairInfo.Fee != 3000 || len(pairInfo.Data) != 160 {
+		t.Errorf("keyed PairInfo = %+v", pairInfo)
+	}
+
+	mismatched := pair
+	mismatched.currency0 = &testWETH
+	if _, err := newPairInfo(mismatched); err == nil || errors.Is(err, ErrNoPoolKey) {
+		t.Errorf("currency0 not matching the key err = %v", err)
+	}
+}
+
+func TestUniswapV4PairInfoWithoutKey(t *testing.T) {
+	native, usdc := NativeCurrency, testUSDC
+	tests := []struct {
+		name string
+		pair testPair
+		want PairInfo
+	}{
+		{"currencies and opaque data",
+			testPair{pair: testPoolManager, token0: testWETH, token1: testUSDC, fee: 500, brand: BrandUniswapV4, data: "deadbeef", currency0: &native, currency1: &usdc},
+			PairInfo{PairAddr: testPoolManager, Token0: NativeCurrency, Token1: testUSDC, Fee: 500, Brand: 22, Data: []byte{0xde, 0xad, 0xbe, 0xef}}},
+		{"currencies without data",
+			testPair{pair: testPoolManager, token0: testWETH, token1: testUSDC, fee: 3000, brand: BrandUniswapV4, currency0: &native, currency1: &usdc},
+			PairInfo{PairAddr: testPoolManager, Token0: NativeCurrency, Token1: testUSDC, Fee: 3000, Brand: 22, Data: []byte{}}},
+		{"tokens without currencies",
+			testPair{pair: testPoolManager, token0: testUSDC, token1: testWETH, fee: 100, brand: BrandUniswapV4, data: "0x01"},
+			PairInfo{PairAddr: testPoolManager, Token0: testUSDC, Token1: testWETH, Fee: 100, Brand: 22, Data: []byte{1}}},
+	}
+	for _, tt := range tests {
+		if _, err := PoolKeyOf(tt.pair); !errors.Is(err, ErrNoPoolKey) {
+			t.Errorf("%s: PoolKeyOf err = %v", tt.name, err)
+		}
+		got, err := newPairInfo(tt.pair)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got.PairAddr != tt.want.PairAddr || got.Token0 != tt.want.Token0 || got.Token1 != tt.want.Token1 ||
+			got.Fee != tt.want.Fee || got.Brand != tt.want.Brand || !bytes.Equal(got.Data, tt.want.Data) {
+			t.Errorf("%s: PairInfo = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -10,9 +10,14 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
-// BuildCalculatorCallData creates properly wrapped call data for any calculatorMoudle function
+// BuildCalculatorCallData creates properly wrapped call data for any calculatorMoudle function, pairs
+// passed as PairInfoInterface or []PairInfoInterface are encoded with Brands()
 func BuildCalculatorCallData(functionName string, args ...interface{}) (hexutil.Bytes, error) {
-	data, err := calculatorMoudleABI.Pack(functionName, args...)
+	args, err := encodePairArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	data, err := packABI(CalculatorMoudleABIName, functionName, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to pack %s: %w", functionName, err)
 	}
@@ -25,15 +30,15 @@ func Build_calculatorModule_BalanceCheck(base, pair common.Address, brand uint8)
 	return BuildCalculatorCallData("BalanceCheck", base, pair, brand)
 }
 func Build_calculatorModule_GetBaseBalance(base common.Address, pair PairInfoInterface) (hexutil.Bytes, error) {
-	return BuildCalculatorCallData("getBaseBalance", base, newPairInfo(pair))
+	return BuildCalculatorCallData("getBaseBalance", base, pair)
 }
 
 func Build_calculatorModule_GetAmountOut(tokenIn common.Address, amountIn *big.Int, pairs ...PairInfoInterface) (hexutil.Bytes, error) {
-	return BuildCalculatorCallData("getAmountOut", tokenIn, amountIn, newPairInfoList(pairs...))
+	return BuildCalculatorCallData("getAmountOut", tokenIn, amountIn, pairs)
 }
 
 func Build_calculatorModule_GetAmountOutLoop(tokenIn common.Address, amountIn *big.Int, pairs ...PairInfoInterface) (hexutil.Bytes, error) {
-	return BuildCalculatorCallData("getAmountOutLoop", tokenIn, amountIn, newPairInfoList(pairs...))
+	return BuildCalculatorCallData("getAmountOutLoop", tokenIn, amountIn, pairs)
 }
 
 // Build_calculModule_GetMultiPrice to build the data for the getMultiPrice function
@@ -45,7 +50,7 @@ func Build_calculModule_GetMultiPrice(tokenIn common.Address, amountsList map[ui
 		amountsIn[i] = amountsList[uint8(i+1)]
 	}
 
-	return BuildCalculatorCallData("getMultiPrice", tokenIn, amountsIn, newPairInfoList(pairs...))
+	return BuildCalculatorCallData("getMultiPrice", tokenIn, amountsIn, pairs)
 }
 
 // decode functions
@@ -59,6 +64,10 @@ func decode_calculatorModule(functionName, rawData string) ([]*big.Int, error) {
 	}
 
 	// Find the "getAmountOut" method
+	calculatorMoudleABI, err := loadABI(CalculatorMoudleABIName)
+	if err != nil {
+		return nil, err
+	}
 	method, exists := calculatorMoudleABI.Methods[functionName]
 	if !exists {
 		return nil, fmt.Errorf("method '%s' not found in ABI", functionName)
@@ -0,0 +1,868 @@
+// Code generated by internal/abigen from abi/calculatorMoudle.json. DO NOT EDIT.
+
+package helper
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// CalculatorMoudleABIName is the registry name of abi/calculatorMoudle.json
+const CalculatorMoudleABIName = "calculatorMoudle"
+
+// Pack_calculatorMoudle_BalanceCheck packs a call to BalanceCheck(address,address,uint8)
+func Pack_calculatorMoudle_BalanceCheck(base common.Address, pair common.Address, brand uint8) (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "BalanceCheck(address,address,uint8)", base, pair, brand)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_BalanceCheck decodes the return data of BalanceCheck(address,address,uint8)
+func Unpack_calculatorMoudle_BalanceCheck(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(CalculatorMoudleABIName, "BalanceCheck(address,address,uint8)", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("BalanceCheck(address,address,uint8)", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_AAVE_DEPOSIT_FAILED packs a call to ERR_AAVE_DEPOSIT_FAILED()
+func Pack_calculatorMoudle_ERR_AAVE_DEPOSIT_FAILED() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_AAVE_DEPOSIT_FAILED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_AAVE_DEPOSIT_FAILED decodes the return data of ERR_AAVE_DEPOSIT_FAILED()
+func Unpack_calculatorMoudle_ERR_AAVE_DEPOSIT_FAILED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_AAVE_DEPOSIT_FAILED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_AAVE_DEPOSIT_FAILED()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_AAVE_WITHDRAWAL_FAILED packs a call to ERR_AAVE_WITHDRAWAL_FAILED()
+func Pack_calculatorMoudle_ERR_AAVE_WITHDRAWAL_FAILED() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_AAVE_WITHDRAWAL_FAILED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_AAVE_WITHDRAWAL_FAILED decodes the return data of ERR_AAVE_WITHDRAWAL_FAILED()
+func Unpack_calculatorMoudle_ERR_AAVE_WITHDRAWAL_FAILED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_AAVE_WITHDRAWAL_FAILED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_AAVE_WITHDRAWAL_FAILED()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_ADD_USERS_FAILED packs a call to ERR_ADD_USERS_FAILED()
+func Pack_calculatorMoudle_ERR_ADD_USERS_FAILED() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_ADD_USERS_FAILED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_ADD_USERS_FAILED decodes the return data of ERR_ADD_USERS_FAILED()
+func Unpack_calculatorMoudle_ERR_ADD_USERS_FAILED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_ADD_USERS_FAILED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_ADD_USERS_FAILED()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_ALGEBRA_GLOBAL_STATE packs a call to ERR_ALGEBRA_GLOBAL_STATE()
+func Pack_calculatorMoudle_ERR_ALGEBRA_GLOBAL_STATE() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_ALGEBRA_GLOBAL_STATE()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_ALGEBRA_GLOBAL_STATE decodes the return data of ERR_ALGEBRA_GLOBAL_STATE()
+func Unpack_calculatorMoudle_ERR_ALGEBRA_GLOBAL_STATE(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_ALGEBRA_GLOBAL_STATE()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_ALGEBRA_GLOBAL_STATE()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_CANT_PAY_BID_AMOUNT packs a call to ERR_CANT_PAY_BID_AMOUNT()
+func Pack_calculatorMoudle_ERR_CANT_PAY_BID_AMOUNT() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_CANT_PAY_BID_AMOUNT()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_CANT_PAY_BID_AMOUNT decodes the return data of ERR_CANT_PAY_BID_AMOUNT()
+func Unpack_calculatorMoudle_ERR_CANT_PAY_BID_AMOUNT(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_CANT_PAY_BID_AMOUNT()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_CANT_PAY_BID_AMOUNT()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_CAN_NOT_LOAN_FROM_THIS_PAIR packs a call to ERR_CAN_NOT_LOAN_FROM_THIS_PAIR()
+func Pack_calculatorMoudle_ERR_CAN_NOT_LOAN_FROM_THIS_PAIR() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_CAN_NOT_LOAN_FROM_THIS_PAIR()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_CAN_NOT_LOAN_FROM_THIS_PAIR decodes the return data of ERR_CAN_NOT_LOAN_FROM_THIS_PAIR()
+func Unpack_calculatorMoudle_ERR_CAN_NOT_LOAN_FROM_THIS_PAIR(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_CAN_NOT_LOAN_FROM_THIS_PAIR()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_CAN_NOT_LOAN_FROM_THIS_PAIR()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_CAN_NOT_WITHDRAW_FROM_AAVE packs a call to ERR_CAN_NOT_WITHDRAW_FROM_AAVE()
+func Pack_calculatorMoudle_ERR_CAN_NOT_WITHDRAW_FROM_AAVE() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_CAN_NOT_WITHDRAW_FROM_AAVE()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_CAN_NOT_WITHDRAW_FROM_AAVE decodes the return data of ERR_CAN_NOT_WITHDRAW_FROM_AAVE()
+func Unpack_calculatorMoudle_ERR_CAN_NOT_WITHDRAW_FROM_AAVE(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_CAN_NOT_WITHDRAW_FROM_AAVE()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_CAN_NOT_WITHDRAW_FROM_AAVE()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_CURVE packs a call to ERR_CURVE()
+func Pack_calculatorMoudle_ERR_CURVE() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_CURVE()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_CURVE decodes the return data of ERR_CURVE()
+func Unpack_calculatorMoudle_ERR_CURVE(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_CURVE()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_CURVE()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_FIRST_PAIR_NOT_SUPPORTED packs a call to ERR_FIRST_PAIR_NOT_SUPPORTED()
+func Pack_calculatorMoudle_ERR_FIRST_PAIR_NOT_SUPPORTED() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_FIRST_PAIR_NOT_SUPPORTED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_FIRST_PAIR_NOT_SUPPORTED decodes the return data of ERR_FIRST_PAIR_NOT_SUPPORTED()
+func Unpack_calculatorMoudle_ERR_FIRST_PAIR_NOT_SUPPORTED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_FIRST_PAIR_NOT_SUPPORTED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_FIRST_PAIR_NOT_SUPPORTED()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_FLASHLOAN_IFCURRENCY_FAILED packs a call to ERR_FLASHLOAN_IFCURRENCY_FAILED()
+func Pack_calculatorMoudle_ERR_FLASHLOAN_IFCURRENCY_FAILED() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_FLASHLOAN_IFCURRENCY_FAILED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_FLASHLOAN_IFCURRENCY_FAILED decodes the return data of ERR_FLASHLOAN_IFCURRENCY_FAILED()
+func Unpack_calculatorMoudle_ERR_FLASHLOAN_IFCURRENCY_FAILED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_FLASHLOAN_IFCURRENCY_FAILED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_FLASHLOAN_IFCURRENCY_FAILED()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_FLASHLOAN_WITHDRAWALL_FAILED packs a call to ERR_FLASHLOAN_WITHDRAWALL_FAILED()
+func Pack_calculatorMoudle_ERR_FLASHLOAN_WITHDRAWALL_FAILED() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_FLASHLOAN_WITHDRAWALL_FAILED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_FLASHLOAN_WITHDRAWALL_FAILED decodes the return data of ERR_FLASHLOAN_WITHDRAWALL_FAILED()
+func Unpack_calculatorMoudle_ERR_FLASHLOAN_WITHDRAWALL_FAILED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_FLASHLOAN_WITHDRAWALL_FAILED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_FLASHLOAN_WITHDRAWALL_FAILED()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_GET_RESERVES packs a call to ERR_GET_RESERVES()
+func Pack_calculatorMoudle_ERR_GET_RESERVES() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_GET_RESERVES()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_GET_RESERVES decodes the return data of ERR_GET_RESERVES()
+func Unpack_calculatorMoudle_ERR_GET_RESERVES(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_GET_RESERVES()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_GET_RESERVES()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_INSUFFICIENT_INPUT packs a call to ERR_INSUFFICIENT_INPUT()
+func Pack_calculatorMoudle_ERR_INSUFFICIENT_INPUT() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_INSUFFICIENT_INPUT()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_INSUFFICIENT_INPUT decodes the return data of ERR_INSUFFICIENT_INPUT()
+func Unpack_calculatorMoudle_ERR_INSUFFICIENT_INPUT(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_INSUFFICIENT_INPUT()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_INSUFFICIENT_INPUT()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_INSUFFICIENT_INPUT_AMOUNT packs a call to ERR_INSUFFICIENT_INPUT_AMOUNT()
+func Pack_calculatorMoudle_ERR_INSUFFICIENT_INPUT_AMOUNT() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_INSUFFICIENT_INPUT_AMOUNT()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_INSUFFICIENT_INPUT_AMOUNT decodes the return data of ERR_INSUFFICIENT_INPUT_AMOUNT()
+func Unpack_calculatorMoudle_ERR_INSUFFICIENT_INPUT_AMOUNT(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_INSUFFICIENT_INPUT_AMOUNT()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_INSUFFICIENT_INPUT_AMOUNT()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_INSUFFICIENT_LIQUIDITY packs a call to ERR_INSUFFICIENT_LIQUIDITY()
+func Pack_calculatorMoudle_ERR_INSUFFICIENT_LIQUIDITY() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_INSUFFICIENT_LIQUIDITY()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_INSUFFICIENT_LIQUIDITY decodes the return data of ERR_INSUFFICIENT_LIQUIDITY()
+func Unpack_calculatorMoudle_ERR_INSUFFICIENT_LIQUIDITY(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_INSUFFICIENT_LIQUIDITY()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_INSUFFICIENT_LIQUIDITY()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_INSUFFICIENT_OUTPUT packs a call to ERR_INSUFFICIENT_OUTPUT()
+func Pack_calculatorMoudle_ERR_INSUFFICIENT_OUTPUT() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_INSUFFICIENT_OUTPUT()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_INSUFFICIENT_OUTPUT decodes the return data of ERR_INSUFFICIENT_OUTPUT()
+func Unpack_calculatorMoudle_ERR_INSUFFICIENT_OUTPUT(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_INSUFFICIENT_OUTPUT()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_INSUFFICIENT_OUTPUT()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_LENGTH packs a call to ERR_LENGTH()
+func Pack_calculatorMoudle_ERR_LENGTH() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_LENGTH()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_LENGTH decodes the return data of ERR_LENGTH()
+func Unpack_calculatorMoudle_ERR_LENGTH(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_LENGTH()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_LENGTH()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_LOAN_POOL_NOT_SUPPORTED packs a call to ERR_LOAN_POOL_NOT_SUPPORTED()
+func Pack_calculatorMoudle_ERR_LOAN_POOL_NOT_SUPPORTED() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_LOAN_POOL_NOT_SUPPORTED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_LOAN_POOL_NOT_SUPPORTED decodes the return data of ERR_LOAN_POOL_NOT_SUPPORTED()
+func Unpack_calculatorMoudle_ERR_LOAN_POOL_NOT_SUPPORTED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_LOAN_POOL_NOT_SUPPORTED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_LOAN_POOL_NOT_SUPPORTED()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_MATIC_PAIRS packs a call to ERR_MATIC_PAIRS()
+func Pack_calculatorMoudle_ERR_MATIC_PAIRS() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_MATIC_PAIRS()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_MATIC_PAIRS decodes the return data of ERR_MATIC_PAIRS()
+func Unpack_calculatorMoudle_ERR_MATIC_PAIRS(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_MATIC_PAIRS()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_MATIC_PAIRS()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_MINI_OUT packs a call to ERR_MINI_OUT()
+func Pack_calculatorMoudle_ERR_MINI_OUT() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_MINI_OUT()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_MINI_OUT decodes the return data of ERR_MINI_OUT()
+func Unpack_calculatorMoudle_ERR_MINI_OUT(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_MINI_OUT()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_MINI_OUT()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_NOT_APPROVED packs a call to ERR_NOT_APPROVED()
+func Pack_calculatorMoudle_ERR_NOT_APPROVED() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_NOT_APPROVED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_NOT_APPROVED decodes the return data of ERR_NOT_APPROVED()
+func Unpack_calculatorMoudle_ERR_NOT_APPROVED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_NOT_APPROVED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_NOT_APPROVED()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_NOT_AUTHORIZED packs a call to ERR_NOT_AUTHORIZED()
+func Pack_calculatorMoudle_ERR_NOT_AUTHORIZED() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_NOT_AUTHORIZED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_NOT_AUTHORIZED decodes the return data of ERR_NOT_AUTHORIZED()
+func Unpack_calculatorMoudle_ERR_NOT_AUTHORIZED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_NOT_AUTHORIZED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_NOT_AUTHORIZED()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_NOT_OWNER packs a call to ERR_NOT_OWNER()
+func Pack_calculatorMoudle_ERR_NOT_OWNER() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_NOT_OWNER()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_NOT_OWNER decodes the return data of ERR_NOT_OWNER()
+func Unpack_calculatorMoudle_ERR_NOT_OWNER(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_NOT_OWNER()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_NOT_OWNER()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_NOT_ROUTER packs a call to ERR_NOT_ROUTER()
+func Pack_calculatorMoudle_ERR_NOT_ROUTER() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_NOT_ROUTER()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_NOT_ROUTER decodes the return data of ERR_NOT_ROUTER()
+func Unpack_calculatorMoudle_ERR_NOT_ROUTER(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_NOT_ROUTER()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_NOT_ROUTER()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_NOT_USER packs a call to ERR_NOT_USER()
+func Pack_calculatorMoudle_ERR_NOT_USER() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_NOT_USER()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_NOT_USER decodes the return data of ERR_NOT_USER()
+func Unpack_calculatorMoudle_ERR_NOT_USER(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_NOT_USER()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_NOT_USER()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_NO_PROFIT packs a call to ERR_NO_PROFIT()
+func Pack_calculatorMoudle_ERR_NO_PROFIT() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_NO_PROFIT()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_NO_PROFIT decodes the return data of ERR_NO_PROFIT()
+func Unpack_calculatorMoudle_ERR_NO_PROFIT(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_NO_PROFIT()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_NO_PROFIT()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_PAIR_NOT_SUPPORTED packs a call to ERR_PAIR_NOT_SUPPORTED()
+func Pack_calculatorMoudle_ERR_PAIR_NOT_SUPPORTED() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_PAIR_NOT_SUPPORTED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_PAIR_NOT_SUPPORTED decodes the return data of ERR_PAIR_NOT_SUPPORTED()
+func Unpack_calculatorMoudle_ERR_PAIR_NOT_SUPPORTED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_PAIR_NOT_SUPPORTED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_PAIR_NOT_SUPPORTED()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_ROUTER_NOT_DEPLOYED packs a call to ERR_ROUTER_NOT_DEPLOYED()
+func Pack_calculatorMoudle_ERR_ROUTER_NOT_DEPLOYED() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_ROUTER_NOT_DEPLOYED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_ROUTER_NOT_DEPLOYED decodes the return data of ERR_ROUTER_NOT_DEPLOYED()
+func Unpack_calculatorMoudle_ERR_ROUTER_NOT_DEPLOYED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_ROUTER_NOT_DEPLOYED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_ROUTER_NOT_DEPLOYED()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_SOLVER_CALL_UNSUCCESSFUL packs a call to ERR_SOLVER_CALL_UNSUCCESSFUL()
+func Pack_calculatorMoudle_ERR_SOLVER_CALL_UNSUCCESSFUL() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_SOLVER_CALL_UNSUCCESSFUL()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_SOLVER_CALL_UNSUCCESSFUL decodes the return data of ERR_SOLVER_CALL_UNSUCCESSFUL()
+func Unpack_calculatorMoudle_ERR_SOLVER_CALL_UNSUCCESSFUL(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_SOLVER_CALL_UNSUCCESSFUL()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_SOLVER_CALL_UNSUCCESSFUL()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_TOKEN_NOT_FOUND_IN_CURVE_POOL packs a call to ERR_TOKEN_NOT_FOUND_IN_CURVE_POOL()
+func Pack_calculatorMoudle_ERR_TOKEN_NOT_FOUND_IN_CURVE_POOL() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_TOKEN_NOT_FOUND_IN_CURVE_POOL()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_TOKEN_NOT_FOUND_IN_CURVE_POOL decodes the return data of ERR_TOKEN_NOT_FOUND_IN_CURVE_POOL()
+func Unpack_calculatorMoudle_ERR_TOKEN_NOT_FOUND_IN_CURVE_POOL(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_TOKEN_NOT_FOUND_IN_CURVE_POOL()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_TOKEN_NOT_FOUND_IN_CURVE_POOL()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_TRANSFER_FROM packs a call to ERR_TRANSFER_FROM()
+func Pack_calculatorMoudle_ERR_TRANSFER_FROM() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_TRANSFER_FROM()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_TRANSFER_FROM decodes the return data of ERR_TRANSFER_FROM()
+func Unpack_calculatorMoudle_ERR_TRANSFER_FROM(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_TRANSFER_FROM()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_TRANSFER_FROM()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_TRANSFER_HELPER_APPROVE_FAILED packs a call to ERR_TRANSFER_HELPER_APPROVE_FAILED()
+func Pack_calculatorMoudle_ERR_TRANSFER_HELPER_APPROVE_FAILED() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_TRANSFER_HELPER_APPROVE_FAILED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_TRANSFER_HELPER_APPROVE_FAILED decodes the return data of ERR_TRANSFER_HELPER_APPROVE_FAILED()
+func Unpack_calculatorMoudle_ERR_TRANSFER_HELPER_APPROVE_FAILED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_TRANSFER_HELPER_APPROVE_FAILED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_TRANSFER_HELPER_APPROVE_FAILED()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_TRANSFER_HELPER_FAILED packs a call to ERR_TRANSFER_HELPER_FAILED()
+func Pack_calculatorMoudle_ERR_TRANSFER_HELPER_FAILED() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_TRANSFER_HELPER_FAILED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_TRANSFER_HELPER_FAILED decodes the return data of ERR_TRANSFER_HELPER_FAILED()
+func Unpack_calculatorMoudle_ERR_TRANSFER_HELPER_FAILED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_TRANSFER_HELPER_FAILED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_TRANSFER_HELPER_FAILED()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_TRANSFER_HELPER_FROM_FAILED packs a call to ERR_TRANSFER_HELPER_FROM_FAILED()
+func Pack_calculatorMoudle_ERR_TRANSFER_HELPER_FROM_FAILED() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_TRANSFER_HELPER_FROM_FAILED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_TRANSFER_HELPER_FROM_FAILED decodes the return data of ERR_TRANSFER_HELPER_FROM_FAILED()
+func Unpack_calculatorMoudle_ERR_TRANSFER_HELPER_FROM_FAILED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_TRANSFER_HELPER_FROM_FAILED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_TRANSFER_HELPER_FROM_FAILED()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_WRONG_FLASHLOAN packs a call to ERR_WRONG_FLASHLOAN()
+func Pack_calculatorMoudle_ERR_WRONG_FLASHLOAN() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_WRONG_FLASHLOAN()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_WRONG_FLASHLOAN decodes the return data of ERR_WRONG_FLASHLOAN()
+func Unpack_calculatorMoudle_ERR_WRONG_FLASHLOAN(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_WRONG_FLASHLOAN()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_WRONG_FLASHLOAN()", values, 0)
+}
+
+// Pack_calculatorMoudle_ERR_WRONG_ROUTER packs a call to ERR_WRONG_ROUTER()
+func Pack_calculatorMoudle_ERR_WRONG_ROUTER() (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "ERR_WRONG_ROUTER()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_ERR_WRONG_ROUTER decodes the return data of ERR_WRONG_ROUTER()
+func Unpack_calculatorMoudle_ERR_WRONG_ROUTER(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(CalculatorMoudleABIName, "ERR_WRONG_ROUTER()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_WRONG_ROUTER()", values, 0)
+}
+
+// Pack_calculatorMoudle_GetAmountOutOnly packs a call to GetAmountOutOnly(address,uint256,(address,address,address,uint32,uint8,bytes)[])
+func Pack_calculatorMoudle_GetAmountOutOnly(tokenIn common.Address, amountIn *big.Int, pairs []PairInfo) (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "GetAmountOutOnly(address,uint256,(address,address,address,uint32,uint8,bytes)[])", tokenIn, amountIn, pairs)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_GetAmountOutOnly decodes the return data of GetAmountOutOnly(address,uint256,(address,address,address,uint32,uint8,bytes)[])
+func Unpack_calculatorMoudle_GetAmountOutOnly(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(CalculatorMoudleABIName, "GetAmountOutOnly(address,uint256,(address,address,address,uint32,uint8,bytes)[])", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("GetAmountOutOnly(address,uint256,(address,address,address,uint32,uint8,bytes)[])", values, 0)
+}
+
+// Pack_calculatorMoudle_GetAmountsOut packs a call to GetAmountsOut(address,uint256,(address,address,address,uint32,uint8,bytes)[])
+func Pack_calculatorMoudle_GetAmountsOut(tokenIn common.Address, amountIn *big.Int, pairs []PairInfo) (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "GetAmountsOut(address,uint256,(address,address,address,uint32,uint8,bytes)[])", tokenIn, amountIn, pairs)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_GetAmountsOut decodes the return data of GetAmountsOut(address,uint256,(address,address,address,uint32,uint8,bytes)[])
+func Unpack_calculatorMoudle_GetAmountsOut(data []byte) ([]*big.Int, error) {
+	var result []*big.Int
+	values, err := unpackABI(CalculatorMoudleABIName, "GetAmountsOut(address,uint256,(address,address,address,uint32,uint8,bytes)[])", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[[]*big.Int]("GetAmountsOut(address,uint256,(address,address,address,uint32,uint8,bytes)[])", values, 0)
+}
+
+// Pack_calculatorMoudle_getAlgebraFee packs a call to getAlgebraFee(address)
+func Pack_calculatorMoudle_getAlgebraFee(target common.Address) (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "getAlgebraFee(address)", target)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_getAlgebraFee decodes the return data of getAlgebraFee(address)
+func Unpack_calculatorMoudle_getAlgebraFee(data []byte) (uint16, error) {
+	var result uint16
+	values, err := unpackABI(CalculatorMoudleABIName, "getAlgebraFee(address)", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[uint16]("getAlgebraFee(address)", values, 0)
+}
+
+// Pack_calculatorMoudle_getAmountOut packs a call to getAmountOut(address,uint256,(address,address,address,uint32,uint8,bytes)[])
+func Pack_calculatorMoudle_getAmountOut(tokenIn common.Address, amountIn *big.Int, pairs []PairInfo) (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "getAmountOut(address,uint256,(address,address,address,uint32,uint8,bytes)[])", tokenIn, amountIn, pairs)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_getAmountOut decodes the return data of getAmountOut(address,uint256,(address,address,address,uint32,uint8,bytes)[])
+func Unpack_calculatorMoudle_getAmountOut(data []byte) ([]*big.Int, error) {
+	var result []*big.Int
+	values, err := unpackABI(CalculatorMoudleABIName, "getAmountOut(address,uint256,(address,address,address,uint32,uint8,bytes)[])", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[[]*big.Int]("getAmountOut(address,uint256,(address,address,address,uint32,uint8,bytes)[])", values, 0)
+}
+
+// Pack_calculatorMoudle_getAmountOutLoop packs a call to getAmountOutLoop(address,uint256,(address,address,address,uint32,uint8,bytes)[])
+func Pack_calculatorMoudle_getAmountOutLoop(tokenIn common.Address, startAmountIn *big.Int, pairs []PairInfo) (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "getAmountOutLoop(address,uint256,(address,address,address,uint32,uint8,bytes)[])", tokenIn, startAmountIn, pairs)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_getAmountOutLoop decodes the return data of getAmountOutLoop(address,uint256,(address,address,address,uint32,uint8,bytes)[])
+func Unpack_calculatorMoudle_getAmountOutLoop(data []byte) ([]*big.Int, error) {
+	var result []*big.Int
+	values, err := unpackABI(CalculatorMoudleABIName, "getAmountOutLoop(address,uint256,(address,address,address,uint32,uint8,bytes)[])", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[[]*big.Int]("getAmountOutLoop(address,uint256,(address,address,address,uint32,uint8,bytes)[])", values, 0)
+}
+
+// Pack_calculatorMoudle_getBaseBalance packs a call to getBaseBalance(address,(address,address,address,uint32,uint8,bytes))
+func Pack_calculatorMoudle_getBaseBalance(base common.Address, pair PairInfo) (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "getBaseBalance(address,(address,address,address,uint32,uint8,bytes))", base, pair)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_getBaseBalance decodes the return data of getBaseBalance(address,(address,address,address,uint32,uint8,bytes))
+func Unpack_calculatorMoudle_getBaseBalance(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(CalculatorMoudleABIName, "getBaseBalance(address,(address,address,address,uint32,uint8,bytes))", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("getBaseBalance(address,(address,address,address,uint32,uint8,bytes))", values, 0)
+}
+
+// Pack_calculatorMoudle_getMultiPrice packs a call to getMultiPrice(address,uint256[],(address,address,address,uint32,uint8,bytes)[])
+func Pack_calculatorMoudle_getMultiPrice(tokenIn common.Address, amountsIn []*big.Int, pairs []PairInfo) (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "getMultiPrice(address,uint256[],(address,address,address,uint32,uint8,bytes)[])", tokenIn, amountsIn, pairs)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_getMultiPrice decodes the return data of getMultiPrice(address,uint256[],(address,address,address,uint32,uint8,bytes)[])
+func Unpack_calculatorMoudle_getMultiPrice(data []byte) ([]*big.Int, error) {
+	var result []*big.Int
+	values, err := unpackABI(CalculatorMoudleABIName, "getMultiPrice(address,uint256[],(address,address,address,uint32,uint8,bytes)[])", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[[]*big.Int]("getMultiPrice(address,uint256[],(address,address,address,uint32,uint8,bytes)[])", values, 0)
+}
+
+// Pack_calculatorMoudle_routersAddress packs a call to routersAddress(uint24)
+func Pack_calculatorMoudle_routersAddress(arg0 *big.Int) (hexutil.Bytes, error) {
+	data, err := packABI(CalculatorMoudleABIName, "routersAddress(uint24)", arg0)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_calculatorMoudle_routersAddress decodes the return data of routersAddress(uint24)
+func Unpack_calculatorMoudle_routersAddress(data []byte) (common.Address, error) {
+	var result common.Address
+	values, err := unpackABI(CalculatorMoudleABIName, "routersAddress(uint24)", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[common.Address]("routersAddress(uint24)", values, 0)
+}
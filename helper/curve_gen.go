@@ -0,0 +1,285 @@
+// Code generated by internal/abigen from abi/curve.json. DO NOT EDIT.
+
+package helper
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// CurveABIName is the registry name of abi/curve.json
+const CurveABIName = "curve"
+
+// Pack_curve_A packs a call to A()
+func Pack_curve_A() (hexutil.Bytes, error) {
+	data, err := packABI(CurveABIName, "A()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_curve_A decodes the return data of A()
+func Unpack_curve_A(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(CurveABIName, "A()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("A()", values, 0)
+}
+
+// Pack_curve_A_precise packs a call to A_precise()
+func Pack_curve_A_precise() (hexutil.Bytes, error) {
+	data, err := packABI(CurveABIName, "A_precise()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_curve_A_precise decodes the return data of A_precise()
+func Unpack_curve_A_precise(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(CurveABIName, "A_precise()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("A_precise()", values, 0)
+}
+
+// Pack_curve_admin_fee packs a call to admin_fee()
+func Pack_curve_admin_fee() (hexutil.Bytes, error) {
+	data, err := packABI(CurveABIName, "admin_fee()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_curve_admin_fee decodes the return data of admin_fee()
+func Unpack_curve_admin_fee(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(CurveABIName, "admin_fee()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("admin_fee()", values, 0)
+}
+
+// Pack_curve_balances packs a call to balances(uint256)
+func Pack_curve_balances(i *big.Int) (hexutil.Bytes, error) {
+	data, err := packABI(CurveABIName, "balances(uint256)", i)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_curve_balances decodes the return data of balances(uint256)
+func Unpack_curve_balances(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(CurveABIName, "balances(uint256)", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("balances(uint256)", values, 0)
+}
+
+// Pack_curve_coins packs a call to coins(uint256)
+func Pack_curve_coins(i *big.Int) (hexutil.Bytes, error) {
+	data, err := packABI(CurveABIName, "coins(uint256)", i)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_curve_coins decodes the return data of coins(uint256)
+func Unpack_curve_coins(data []byte) (common.Address, error) {
+	var result common.Address
+	values, err := unpackABI(CurveABIName, "coins(uint256)", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[common.Address]("coins(uint256)", values, 0)
+}
+
+// Pack_curve_exchange packs a call to exchange(int128,int128,uint256,uint256)
+func Pack_curve_exchange(i *big.Int, j *big.Int, dx *big.Int, min_dy *big.Int) (hexutil.Bytes, error) {
+	data, err := packABI(CurveABIName, "exchange(int128,int128,uint256,uint256)", i, j, dx, min_dy)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_curve_exchange decodes the return data of exchange(int128,int128,uint256,uint256)
+func Unpack_curve_exchange(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(CurveABIName, "exchange(int128,int128,uint256,uint256)", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("exchange(int128,int128,uint256,uint256)", values, 0)
+}
+
+// Pack_curve_fee packs a call to fee()
+func Pack_curve_fee() (hexutil.Bytes, error) {
+	data, err := packABI(CurveABIName, "fee()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_curve_fee decodes the return data of fee()
+func Unpack_curve_fee(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(CurveABIName, "fee()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("fee()", values, 0)
+}
+
+// Pack_curve_future_A packs a call to future_A()
+func Pack_curve_future_A() (hexutil.Bytes, error) {
+	data, err := packABI(CurveABIName, "future_A()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_curve_future_A decodes the return data of future_A()
+func Unpack_curve_future_A(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(CurveABIName, "future_A()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("future_A()", values, 0)
+}
+
+// Pack_curve_future_A_time packs a call to future_A_time()
+func Pack_curve_future_A_time() (hexutil.Bytes, error) {
+	data, err := packABI(CurveABIName, "future_A_time()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_curve_future_A_time decodes the return data of future_A_time()
+func Unpack_curve_future_A_time(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(CurveABIName, "future_A_time()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("future_A_time()", values, 0)
+}
+
+// Pack_curve_get_dy packs a call to get_dy(int128,int128,uint256)
+func Pack_curve_get_dy(i *big.Int, j *big.Int, dx *big.Int) (hexutil.Bytes, error) {
+	data, err := packABI(CurveABIName, "get_dy(int128,int128,uint256)", i, j, dx)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_curve_get_dy decodes the return data of get_dy(int128,int128,uint256)
+func Unpack_curve_get_dy(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(CurveABIName, "get_dy(int128,int128,uint256)", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("get_dy(int128,int128,uint256)", values, 0)
+}
+
+// Pack_curve_initial_A packs a call to initial_A()
+func Pack_curve_initial_A() (hexutil.Bytes, error) {
+	data, err := packABI(CurveABIName, "initial_A()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_curve_initial_A decodes the return data of initial_A()
+func Unpack_curve_initial_A(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(CurveABIName, "initial_A()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("initial_A()", values, 0)
+}
+
+// Pack_curve_initial_A_time packs a call to initial_A_time()
+func Pack_curve_initial_A_time() (hexutil.Bytes, error) {
+	data, err := packABI(CurveABIName, "initial_A_time()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_curve_initial_A_time decodes the return data of initial_A_time()
+func Unpack_curve_initial_A_time(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(CurveABIName, "initial_A_time()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("initial_A_time()", values, 0)
+}
+
+// CurveTokenExchangeEvent is the TokenExchange(address,int128,uint256,int128,uint256) event
+type CurveTokenExchangeEvent struct {
+	Buyer        common.Address
+	SoldId       *big.Int
+	TokensSold   *big.Int
+	BoughtId     *big.Int
+	TokensBought *big.Int
+	Raw          types.Log
+}
+
+// Unpack_curve_TokenExchangeEvent decodes a TokenExchange log
+func Unpack_curve_TokenExchangeEvent(log types.Log) (*CurveTokenExchangeEvent, error) {
+	event := &CurveTokenExchangeEvent{Raw: log}
+	if err := unpackEvent(CurveABIName, "TokenExchange", log, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// CurveTokenExchange0Event is the TokenExchange(address,uint256,uint256,uint256,uint256) event
+type CurveTokenExchange0Event struct {
+	Buyer        common.Address
+	SoldId       *big.Int
+	TokensSold   *big.Int
+	BoughtId     *big.Int
+	TokensBought *big.Int
+	Raw          types.Log
+}
+
+// Unpack_curve_TokenExchange0Event decodes a TokenExchange0 log
+func Unpack_curve_TokenExchange0Event(log types.Log) (*CurveTokenExchange0Event, error) {
+	event := &CurveTokenExchange0Event{Raw: log}
+	if err := unpackEvent(CurveABIName, "TokenExchange0", log, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func init() {
+	registerEventDecoder(CurveABIName, "TokenExchange", func(log types.Log) (interface{}, error) { return Unpack_curve_TokenExchangeEvent(log) })
+	registerEventDecoder(CurveABIName, "TokenExchange0", func(log types.Log) (interface{}, error) { return Unpack_curve_TokenExchange0Event(log) })
+}
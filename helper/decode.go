@@ -0,0 +1,152 @@
+package helper
+
+import (
+	"bytes"
+	"fmt"
+
+	wsClient "github.com/0xKhennati/wsclient"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// Argument is a decoded input or output value; Value has the Go type go-ethereum
+// decodes Type to, e.g. *big.Int for uint256 and a struct for a tuple
+type Argument struct {
+	Name  string
+	Type  string
+	Value interface{}
+}
+
+// DecodedCall is calldata decoded against a registered ABI
+type DecodedCall struct {
+	Method
+	Args []Argument
+}
+
+// Arg returns the value of the argument called name
+func (c *DecodedCall) Arg(name string) (interface{}, bool) {
+	return findArgument(c.Args, name)
+}
+
+// DecodeCall decodes calldata against the registered ABIs, looking up the function by
+// its selector
+func (r *ABIRegistry) DecodeCall(data []byte) (*DecodedCall, error) {
+	if _, err := r.MethodBySelector(data); err != nil {
+		return nil, err
+	}
+	methods := r.MethodsBySelector(data)
+
+	// several contracts may share a selector, prefer the one the data re-encodes to exactly
+	var decoded *DecodedCall
+	var lastErr error
+	for _, method := range methods {
+		values, err := method.Inputs.Unpack(data[4:])
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		call := &DecodedCall{Method: method, Args: namedArguments(method.Inputs, values)}
+		if packed, err := method.Inputs.Pack(values...); err == nil && bytes.Equal(packed, data[4:]) {
+			return call, nil
+		}
+		if decoded == nil {
+			decoded = call
+		}
+	}
+	if decoded == nil {
+		return nil, fmt.Errorf("failed to unpack %s arguments: %w", methods[0].Sig, lastErr)
+	}
+	return decoded, nil
+}
+
+// DecodeReturn decodes the return data of method, whatever the number and types of
+// its outputs
+func (r *ABIRegistry) DecodeReturn(method Method, data []byte) ([]Argument, error) {
+	if method.Method == nil {
+		return nil, fmt.Errorf("method is nil")
+	}
+	values, err := method.Outputs.Unpack(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack %s return data: %w", method.Sig, err)
+	}
+	return namedArguments(method.Outputs, values), nil
+}
+
+// DecodeFunctionReturn decodes the return data of function name of contract; name is
+// resolved as in Overloads and must designate a single function
+func (r *ABIRegistry) DecodeFunctionReturn(contract, name string, data []byte) ([]Argument, error) {
+	overloads, err := r.Overloads(contract, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(overloads) > 1 {
+		return nil, fmt.Errorf("%s is overloaded in %s, use its signature", name, contract)
+	}
+	return r.DecodeReturn(Method{Contract: contract, Method: overloads[0]}, data)
+}
+
+// DecodeCall decodes calldata against the ABIs of the default registry
+//
+//	call, err := helper.DecodeCall(tx.Input)
+//	if err == nil && call.RawName == "swapExactTokensForTokens" {
+//		path, _ := call.Arg("path")
+//	}
+func DecodeCall(data []byte) (*DecodedCall, error) {
+	return defaultRegistry.DecodeCall(data)
+}
+
+// DecodeReturn decodes the return data of method, e.g. the Method of a DecodedCall
+func DecodeReturn(method Method, data []byte) ([]Argument, error) {
+	return defaultRegistry.DecodeReturn(method, data)
+}
+
+// DecodeFunctionReturn decodes the return data of function name of contract in the
+// default registry, e.g. DecodeFunctionReturn(RouterMoudleABIName, "simulateFlashSwap", data)
+func DecodeFunctionReturn(contract, name string, data []byte) ([]Argument, error) {
+	return defaultRegistry.DecodeFunctionReturn(contract, name, data)
+}
+
+// DecodeTransactionCall decodes the input of a transaction, e.g. a competitor's
+// transaction returned by eth_getTargetTx
+func DecodeTransactionCall(tx *wsClient.RPCTransaction) (*DecodedCall, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("transaction is nil")
+	}
+	call, err := DecodeCall(tx.Input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transaction %s: %w", tx.Hash.Hex(), err)
+	}
+	return call, nil
+}
+
+// ArgumentValue returns the value of the argument called name
+func ArgumentValue(args []Argument, name string) (interface{}, bool) {
+	return findArgument(args, name)
+}
+
+func findArgument(args []Argument, name string) (interface{}, bool) {
+	for _, arg := range args {
+		if arg.Name == name {
+			return arg.Value, true
+		}
+	}
+	return nil, false
+}
+
+// namedArguments pairs unpacked values with their ABI arguments
+func namedArguments(arguments abi.Arguments, values []interface{}) []Argument {
+	var nonIndexed abi.Arguments
+	for _, argument := range arguments {
+		if !argument.Indexed {
+			nonIndexed = append(nonIndexed, argument)
+		}
+	}
+	args := make([]Argument, len(values))
+	for i, value := range values {
+		args[i] = Argument{Value: value}
+		if i < len(nonIndexed) {
+			args[i].Name = nonIndexed[i].Name
+			args[i].Type = nonIndexed[i].Type.String()
+		}
+	}
+	return args
+}
@@ -0,0 +1,159 @@
+package helper
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	wsClient "github.com/0xKhennati/wsclient"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestDecodeCall(t *testing.T) {
+	pool, token := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+	loanCheck, err := Build_router_LoanCheck(&LoanPool{Pool: pool, Token: token, Types: 3}, big.NewInt(1e18))
+	if err != nil {
+		t.Fatal(err)
+	}
+	call, err := DecodeCall(loanCheck)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.Contract != RouterMoudleABIName || call.Sig != "LoanCheck(uint8,address,address,uint256)" || len(call.Args) != 4 {
+		t.Fatalf("decoded %s.%s with %d arguments", call.Contract, call.Sig, len(call.Args))
+	}
+	if types_, _ := call.Arg("types"); types_ != uint8(3) {
+		t.Errorf("types = %v", types_)
+	}
+	if amount, _ := call.Arg("amount"); amount.(*big.Int).Cmp(big.NewInt(1e18)) != 0 || call.Args[1].Type != "address" || call.Args[1].Value != pool {
+		t.Errorf("arguments = %+v", call.Args)
+	}
+	if _, ok := call.Arg("missing"); ok {
+		t.Error("an unknown argument was found")
+	}
+
+	// the router and the swap module share the callbacks, the router is registered first
+	callback, err := Registry().Pack(SwapMoudleABIName, "algebraSwapCallback", big.NewInt(-5), big.NewInt(7), []byte{0x01})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call, err := DecodeCall(callback); err != nil || call.Contract != RouterMoudleABIName {
+		t.Errorf("callback decoded as %v, %v, want routerMoudle", call, err)
+	} else if delta, _ := call.Arg("amount0Delta"); delta.(*big.Int).Int64() != -5 {
+		t.Errorf("amount0Delta = %v", delta)
+	}
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"short", "0x0902f1"},
+		{"unknown selector", "0xdeadbeef"},
+		{"truncated arguments", hexutil.Encode(loanCheck[:40])},
+	}
+	for _, tt := range tests {
+		if call, err := DecodeCall(hexutil.MustDecode(tt.data)); err == nil {
+			t.Errorf("%s: decoded %s", tt.name, call.Sig)
+		}
+	}
+}
+
+func TestDecodeCallPrefersExactEncoding(t *testing.T) {
+	// two contracts whose functions share a selector but not their arguments
+	byAddress, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"pull","inputs":[{"name":"from","type":"address"}],"outputs":[],"stateMutability":"nonpayable"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	byAmount, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"pull","inputs":[{"name":"amount","type":"uint256"}],"outputs":[],"stateMutability":"nonpayable"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	method := byAmount.Methods["pull"]
+	method.ID = byAddress.Methods["pull"].ID
+	byAmount.Methods["pull"] = method
+
+	registry := NewABIRegistry()
+	if err := registry.Register("byAddress", byAddress); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Register("byAmount", byAmount); err != nil {
+		t.Fatal(err)
+	}
+	selector := hexutil.Encode(method.ID)
+
+	tests := []struct {
+		name     string
+		word     string
+		contract string
+	}{
+		// both encode the word exactly, the first registered wins
+		{"address", fmt.Sprintf("%064x", 0x1234), "byAddress"},
+		// an address drops the high bytes, only the amount re-encodes to the data
+		{"amount", "0de0b6b3a7640000" + fmt.Sprintf("%048x", 0), "byAmount"},
+	}
+	for _, tt := range tests {
+		call, err := registry.DecodeCall(hexutil.MustDecode(selector + tt.word))
+		if err != nil || call.Contract != tt.contract {
+			t.Errorf("%s: decoded as %v, %v, want %s", tt.name, call, err, tt.contract)
+		}
+	}
+}
+
+func TestDecodeReturn(t *testing.T) {
+	data := hexutil.MustDecode(fmt.Sprintf("0x%064x%064x%064x", 1000, 2000, 1700000000))
+	args, err := DecodeFunctionReturn(Uniswapv2ABIName, "getReserves", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(args) != 3 || args[0].Name != "_reserve0" || args[0].Type != "uint112" || args[2].Value != uint32(1700000000) {
+		t.Errorf("getReserves = %+v", args)
+	}
+	if reserve1, ok := ArgumentValue(args, "_reserve1"); !ok || reserve1.(*big.Int).Int64() != 2000 {
+		t.Errorf("_reserve1 = %v", reserve1)
+	}
+
+	// the method of a decoded call decodes its return data
+	call, err := DecodeCall(hexutil.MustDecode("0x0902f1ac"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if args, err := DecodeReturn(call.Method, data); err != nil || len(args) != 3 {
+		t.Errorf("DecodeReturn = %v, %v", args, err)
+	}
+
+	tests := []struct {
+		name string
+		err  func() error
+	}{
+		{"overloaded", func() error { _, err := DecodeFunctionReturn(RouterMoudleABIName, "LoanCheck", nil); return err }},
+		{"unknown function", func() error { _, err := DecodeFunctionReturn(Uniswapv2ABIName, "slot0", data); return err }},
+		{"short data", func() error { _, err := DecodeFunctionReturn(Uniswapv2ABIName, "getReserves", data[:64]); return err }},
+		{"nil method", func() error { _, err := DecodeReturn(Method{}, data); return err }},
+	}
+	for _, tt := range tests {
+		if err := tt.err(); err == nil {
+			t.Errorf("%s: decoded", tt.name)
+		}
+	}
+}
+
+func TestDecodeTransactionCall(t *testing.T) {
+	input, err := Registry().Pack(Uniswapv2ABIName, "swap", big.NewInt(0), big.NewInt(5), common.HexToAddress("0x03"), []byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := &wsClient.RPCTransaction{Hash: common.HexToHash("0x01"), Input: input}
+	if call, err := DecodeTransactionCall(tx); err != nil || call.Contract != Uniswapv2ABIName || call.RawName != "swap" {
+		t.Errorf("DecodeTransactionCall = %v, %v", call, err)
+	}
+	tx.Input = hexutil.MustDecode("0xdeadbeef")
+	if _, err := DecodeTransactionCall(tx); err == nil || !strings.Contains(err.Error(), tx.Hash.Hex()) {
+		t.Errorf("err = %v, want the transaction hash", err)
+	}
+	if _, err := DecodeTransactionCall(nil); err == nil {
+		t.Error("a nil transaction decoded")
+	}
+}
@@ -10,7 +10,7 @@ import (
 
 // BuildERC20CallData creates properly wrapped call data for any ERC-20 function
 func BuildERC20CallData(functionName string, args ...interface{}) (hexutil.Bytes, error) {
-	data, err := erc20ABI.Pack(functionName, args...)
+	data, err := packABI(ERC20ABIName, functionName, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to pack %s: %w", functionName, err)
 	}
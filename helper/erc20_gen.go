@@ -0,0 +1,224 @@
+// Code generated by internal/abigen from abi/erc20.json. DO NOT EDIT.
+
+package helper
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ERC20ABIName is the registry name of abi/erc20.json
+const ERC20ABIName = "erc20"
+
+// Pack_erc20_allowance packs a call to allowance(address,address)
+func Pack_erc20_allowance(owner common.Address, spender common.Address) (hexutil.Bytes, error) {
+	data, err := packABI(ERC20ABIName, "allowance(address,address)", owner, spender)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_erc20_allowance decodes the return data of allowance(address,address)
+func Unpack_erc20_allowance(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(ERC20ABIName, "allowance(address,address)", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("allowance(address,address)", values, 0)
+}
+
+// Pack_erc20_approve packs a call to approve(address,uint256)
+func Pack_erc20_approve(spender common.Address, value *big.Int) (hexutil.Bytes, error) {
+	data, err := packABI(ERC20ABIName, "approve(address,uint256)", spender, value)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_erc20_approve decodes the return data of approve(address,uint256)
+func Unpack_erc20_approve(data []byte) (bool, error) {
+	var result bool
+	values, err := unpackABI(ERC20ABIName, "approve(address,uint256)", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[bool]("approve(address,uint256)", values, 0)
+}
+
+// Pack_erc20_balanceOf packs a call to balanceOf(address)
+func Pack_erc20_balanceOf(owner common.Address) (hexutil.Bytes, error) {
+	data, err := packABI(ERC20ABIName, "balanceOf(address)", owner)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_erc20_balanceOf decodes the return data of balanceOf(address)
+func Unpack_erc20_balanceOf(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(ERC20ABIName, "balanceOf(address)", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("balanceOf(address)", values, 0)
+}
+
+// Pack_erc20_decimals packs a call to decimals()
+func Pack_erc20_decimals() (hexutil.Bytes, error) {
+	data, err := packABI(ERC20ABIName, "decimals()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_erc20_decimals decodes the return data of decimals()
+func Unpack_erc20_decimals(data []byte) (uint8, error) {
+	var result uint8
+	values, err := unpackABI(ERC20ABIName, "decimals()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[uint8]("decimals()", values, 0)
+}
+
+// Pack_erc20_name packs a call to name()
+func Pack_erc20_name() (hexutil.Bytes, error) {
+	data, err := packABI(ERC20ABIName, "name()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_erc20_name decodes the return data of name()
+func Unpack_erc20_name(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(ERC20ABIName, "name()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("name()", values, 0)
+}
+
+// Pack_erc20_symbol packs a call to symbol()
+func Pack_erc20_symbol() (hexutil.Bytes, error) {
+	data, err := packABI(ERC20ABIName, "symbol()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_erc20_symbol decodes the return data of symbol()
+func Unpack_erc20_symbol(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(ERC20ABIName, "symbol()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("symbol()", values, 0)
+}
+
+// Pack_erc20_totalSupply packs a call to totalSupply()
+func Pack_erc20_totalSupply() (hexutil.Bytes, error) {
+	data, err := packABI(ERC20ABIName, "totalSupply()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_erc20_totalSupply decodes the return data of totalSupply()
+func Unpack_erc20_totalSupply(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(ERC20ABIName, "totalSupply()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("totalSupply()", values, 0)
+}
+
+// Pack_erc20_transfer packs a call to transfer(address,uint256)
+func Pack_erc20_transfer(to common.Address, value *big.Int) (hexutil.Bytes, error) {
+	data, err := packABI(ERC20ABIName, "transfer(address,uint256)", to, value)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_erc20_transfer decodes the return data of transfer(address,uint256)
+func Unpack_erc20_transfer(data []byte) (bool, error) {
+	var result bool
+	values, err := unpackABI(ERC20ABIName, "transfer(address,uint256)", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[bool]("transfer(address,uint256)", values, 0)
+}
+
+// Pack_erc20_transferFrom packs a call to transferFrom(address,address,uint256)
+func Pack_erc20_transferFrom(from common.Address, to common.Address, value *big.Int) (hexutil.Bytes, error) {
+	data, err := packABI(ERC20ABIName, "transferFrom(address,address,uint256)", from, to, value)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_erc20_transferFrom decodes the return data of transferFrom(address,address,uint256)
+func Unpack_erc20_transferFrom(data []byte) (bool, error) {
+	var result bool
+	values, err := unpackABI(ERC20ABIName, "transferFrom(address,address,uint256)", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[bool]("transferFrom(address,address,uint256)", values, 0)
+}
+
+// Erc20ApprovalEvent is the Approval(address,address,uint256) event
+type Erc20ApprovalEvent struct {
+	Owner   common.Address
+	Spender common.Address
+	Value   *big.Int
+	Raw     types.Log
+}
+
+// Unpack_erc20_ApprovalEvent decodes a Approval log
+func Unpack_erc20_ApprovalEvent(log types.Log) (*Erc20ApprovalEvent, error) {
+	event := &Erc20ApprovalEvent{Raw: log}
+	if err := unpackEvent(ERC20ABIName, "Approval", log, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Erc20TransferEvent is the Transfer(address,address,uint256) event
+type Erc20TransferEvent struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Raw   types.Log
+}
+
+// Unpack_erc20_TransferEvent decodes a Transfer log
+func Unpack_erc20_TransferEvent(log types.Log) (*Erc20TransferEvent, error) {
+	event := &Erc20TransferEvent{Raw: log}
+	if err := unpackEvent(ERC20ABIName, "Transfer", log, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func init() {
+	registerEventDecoder(ERC20ABIName, "Approval", func(log types.Log) (interface{}, error) { return Unpack_erc20_ApprovalEvent(log) })
+	registerEventDecoder(ERC20ABIName, "Transfer", func(log types.Log) (interface{}, error) { return Unpack_erc20_TransferEvent(log) })
+}
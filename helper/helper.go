@@ -4,11 +4,11 @@ package helper
 // You can import just "helper" and access all contract functions
 
 import (
-	"encoding/hex"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // ContractType represents different contract types for easier identification
@@ -19,6 +19,9 @@ const (
 	RouterModule     ContractType = "router_module"
 	SwapModule       ContractType = "swap_module"
 	CalculatorModule ContractType = "calculator_module"
+	UniswapV2Pair    ContractType = "uniswapv2_pair"
+	UniswapV3Pool    ContractType = "uniswapv3_pool"
+	AaveV3Pool       ContractType = "aave_v3_pool"
 )
 
 // ContractHelper provides a unified interface for contract interactions
@@ -35,6 +38,56 @@ func NewContractHelper(address common.Address, contractType ContractType) *Contr
 	}
 }
 
+// contractABINames maps each contract type to the name of its ABI in Registry()
+var contractABINames = map[ContractType]string{
+	ERC20Token:       ERC20ABIName,
+	RouterModule:     RouterMoudleABIName,
+	SwapModule:       SwapMoudleABIName,
+	CalculatorModule: CalculatorMoudleABIName,
+	UniswapV2Pair:    Uniswapv2ABIName,
+	UniswapV3Pool:    Uniswapv3ABIName,
+	AaveV3Pool:       AaveABIName,
+}
+
+// ABIName returns the name of the contract ABI in Registry()
+func (h *ContractHelper) ABIName() (string, error) {
+	name, ok := contractABINames[h.ContractType]
+	if !ok {
+		return "", fmt.Errorf("unknown contract type %q", h.ContractType)
+	}
+	return name, nil
+}
+
+// Pack packs a call to function of the contract, function is a name or a signature
+func (h *ContractHelper) Pack(function string, args ...interface{}) (hexutil.Bytes, error) {
+	name, err := h.ABIName()
+	if err != nil {
+		return nil, err
+	}
+	data, err := packABI(name, function, args...)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack decodes the return data of function of the contract, function is a name or a
+// signature; overloaded functions need the signature
+func (h *ContractHelper) Unpack(function string, data []byte) ([]interface{}, error) {
+	name, err := h.ABIName()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := loadABI(name); err != nil {
+		return nil, err
+	}
+	overloads, err := defaultRegistry.Overloads(name, function)
+	if err != nil {
+		return nil, err
+	}
+	return unpackABI(name, overloads[0].Sig, data, len(overloads[0].Outputs))
+}
+
 // pairInterface defines the interface for pair-related operations
 // It provides methods to access pair information in a standardized way
 type PairInfoInterface interface {
@@ -48,54 +101,38 @@ type PairInfoInterface interface {
 	GetCurrency1() *common.Address
 }
 
-// PairInfo represents the pairinfo struct
-type PairInfo struct {
-	PairAddr common.Address
-	Token0   common.Address
-	Token1   common.Address
-	Fee      uint32
-	Brand    uint8
-	Data     []byte
+// newPairInfo encodes pair with the brand registered in Brands()
+func newPairInfo(pair PairInfoInterface) (PairInfo, error) {
+	return defaultBrands.PairInfo(pair)
 }
 
-// newPairInfo creates a new PairInfo instance from any type implementing pairInterface
-func newPairInfo(pair PairInfoInterface) PairInfo {
-	padData := []byte{}
-	if pair.GetData() != "" {
-		padData, _ = hex.DecodeString(pair.GetData())
-	}
-
-	if pair.GetBrand() == 22 {
-		currency0 := pair.GetCurrency0()
-		currency1 := pair.GetCurrency1()
-
-		return PairInfo{
-			PairAddr: pair.GetPairHex(),
-			Token0:   *currency0,
-			Token1:   *currency1,
-			Fee:      uint32(pair.GetFee()),
-			Brand:    pair.GetBrand(),
-			Data:     padData,
+// encodePairArgs returns args with each PairInfoInterface and []PairInfoInterface
+// encoded by newPairInfo, so the Build_* helpers pass their pairs as they are
+func encodePairArgs(args []interface{}) ([]interface{}, error) {
+	encoded := make([]interface{}, len(args))
+	for i, arg := range args {
+		switch arg := arg.(type) {
+		case PairInfoInterface:
+			pairInfo, err := newPairInfo(arg)
+			if err != nil {
+				return nil, err
+			}
+			encoded[i] = pairInfo
+		case []PairInfoInterface:
+			pairInfos := make([]PairInfo, len(arg))
+			for j, pair := range arg {
+				pairInfo, err := newPairInfo(pair)
+				if err != nil {
+					return nil, err
+				}
+				pairInfos[j] = pairInfo
+			}
+			encoded[i] = pairInfos
+		default:
+			encoded[i] = arg
 		}
 	}
-
-	return PairInfo{
-		PairAddr: pair.GetPairHex(),
-		Token0:   pair.GetT0Hex(),
-		Token1:   pair.GetT1Hex(),
-		Fee:      uint32(pair.GetFee()),
-		Brand:    pair.GetBrand(),
-		Data:     padData,
-	}
-}
-
-// newPairInfoList creates a list of PairInfo instances from a list of pairInterface implementations
-func newPairInfoList(pairs ...PairInfoInterface) []PairInfo {
-	pairInfos := make([]PairInfo, len(pairs))
-	for i, pair := range pairs {
-		pairInfos[i] = newPairInfo(pair)
-	}
-	return pairInfos
+	return encoded, nil
 }
 
 func GetNullPairInfo() PairInfo {
@@ -109,25 +146,8 @@ func GetNullPairInfo() PairInfo {
 	}
 }
 
-// LoanPool represents the loanPool struct
-// type 0 dodo
-// type 1 balancer
-// type 2 uniV3
-type LoanPool struct {
-	Pool          common.Address
-	Token         common.Address
-	Types         uint8
-	IsWrappedAave bool
-}
-
-type BalanceCheck struct {
-	Latest *big.Int
-	Target *big.Int
-	End    *big.Int
-	Pair   common.Address
-	Token  common.Address
-	Brand  uint8
-}
+// PairInfo, LoanPool and BalanceCheck are generated from the ABIs in structs_gen.go
+// LoanPool.Types is the flash loan source: 0 dodo, 1 balancer, 2 uniV3
 
 func NewBalanceCheck(pair, token common.Address, brand uint8) *BalanceCheck {
 	return &BalanceCheck{
@@ -0,0 +1,96 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	wsClient "github.com/0xKhennati/wsclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gorilla/websocket"
+)
+
+// dialNode starts a node answering each request with the JSON result of respond,
+// errors are sent as code 3 reverts, and connects a client to it
+func dialNode(t *testing.T, respond func(method string, msg wsClient.CallMsg) (string, error)) *wsClient.Client {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var request struct {
+				ID     int64             `json:"id"`
+				Method string            `json:"method"`
+				Params []json.RawMessage `json:"params"`
+			}
+			var msg wsClient.CallMsg
+			if json.Unmarshal(message, &request) != nil || len(request.Params) == 0 || json.Unmarshal(request.Params[0], &msg) != nil {
+				return
+			}
+			response := ""
+			if result, err := respond(request.Method, msg); err != nil {
+				response = fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"error":{"code":3,"message":%q}}`, request.ID, err.Error())
+			} else {
+				response = fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":%s}`, request.ID, result)
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(response)); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := wsClient.NewClient("ws" + strings.TrimPrefix(server.URL, "http"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestContractHelper(t *testing.T) {
+	for contractType := range contractABINames {
+		name, err := NewContractHelper(common.Address{}, contractType).ABIName()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadABI(name); err != nil {
+			t.Errorf("%s: %v", contractType, err)
+		}
+	}
+
+	pair := NewContractHelper(common.HexToAddress("0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc"), UniswapV2Pair)
+	packed, err := pair.Pack("getReserves")
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated, _ := Pack_uniswapv2_getReserves()
+	if !bytes.Equal(packed, generated) || hexutil.Encode(packed) != "0x0902f1ac" {
+		t.Errorf("Pack = %s, want 0x0902f1ac", packed)
+	}
+	values, err := pair.Unpack("getReserves()", hexutil.MustDecode(fmt.Sprintf("0x%064x%064x%064x", 1, 2, 3)))
+	if err != nil || len(values) != 3 {
+		t.Fatalf("Unpack = %v, %v", values, err)
+	}
+
+	token := NewContractHelper(common.Address{}, ERC20Token)
+	if packed, err := token.Pack("balanceOf", common.HexToAddress("0x01")); err != nil || hexutil.Encode(packed[:4]) != "0x70a08231" {
+		t.Errorf("balanceOf = %s, %v", packed, err)
+	}
+
+	if _, err := NewContractHelper(common.Address{}, "unknown").Pack("getReserves"); err == nil {
+		t.Error("unknown contract type packed")
+	}
+}
@@ -0,0 +1,538 @@
+// Command abigen generates typed helpers from the JSON ABIs of helper/abi:
+// a pack function per contract function, a decoder per function with outputs,
+// a struct and a decoder per event, and a Go struct per Solidity struct.
+//
+// It is run by go generate in the helper package:
+//
+//	go run ./internal/abigen -abi abi -out . -rename pairinfo=PairInfo
+//
+// For an ABI file name.json it writes name_gen.go, structs go to structs_gen.go.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// jsonArg is an argument as written in the JSON ABI, with the internalType that
+// go-ethereum does not keep
+type jsonArg struct {
+	Name         string    `json:"name"`
+	Type         string    `json:"type"`
+	InternalType string    `json:"internalType"`
+	Components   []jsonArg `json:"components"`
+	Indexed      bool      `json:"indexed"`
+}
+
+// jsonEntry is a function, event or error of the JSON ABI
+type jsonEntry struct {
+	Type            string    `json:"type"`
+	Name            string    `json:"name"`
+	Inputs          []jsonArg `json:"inputs"`
+	Outputs         []jsonArg `json:"outputs"`
+	StateMutability string    `json:"stateMutability"`
+	Anonymous       bool      `json:"anonymous"`
+}
+
+// goStruct is a Go struct generated for a Solidity struct
+type goStruct struct {
+	Name   string
+	Source string // Solidity name, e.g. IStruct.pairinfo
+	Fields []goField
+}
+
+type goField struct {
+	Name string
+	Type string
+}
+
+type generator struct {
+	renames map[string]string
+	structs map[string]*goStruct
+	imports map[string]bool
+}
+
+func main() {
+	abiDir := flag.String("abi", "abi", "directory of the JSON ABIs")
+	outDir := flag.String("out", ".", "output directory")
+	pkg := flag.String("package", "helper", "package of the generated files")
+	renames := flag.String("rename", "", "comma separated Solidity=Go struct names, e.g. pairinfo=PairInfo")
+	flag.Parse()
+
+	g := &generator{
+		renames: make(map[string]string),
+		structs: make(map[string]*goStruct),
+	}
+	for _, rename := range strings.Split(*renames, ",") {
+		if rename == "" {
+			continue
+		}
+		from, to, ok := strings.Cut(rename, "=")
+		if !ok {
+			log.Fatalf("invalid rename %q", rename)
+		}
+		g.renames[from] = to
+	}
+
+	files, err := filepath.Glob(filepath.Join(*abiDir, "*.json"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".json")
+		source, err := g.contract(*pkg, name, file)
+		if err != nil {
+			log.Fatalf("%s: %v", file, err)
+		}
+		if err := write(filepath.Join(*outDir, name+"_gen.go"), source); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err := write(filepath.Join(*outDir, "structs_gen.go"), g.structsFile(*pkg)); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// write formats source and writes it to path
+func write(path string, source []byte) error {
+	formatted, err := format.Source(source)
+	if err != nil {
+		return fmt.Errorf("failed to format %s: %w\n%s", path, err, source)
+	}
+	return os.WriteFile(path, formatted, 0o644)
+}
+
+// contract generates the file of one ABI
+func (g *generator) contract(pkg, name, file string) ([]byte, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := abi.JSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	var entries []jsonEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	g.imports = make(map[string]bool)
+	var body bytes.Buffer
+	constName := exported(name) + "ABIName"
+	if name == "erc20" {
+		constName = "ERC20ABIName"
+	}
+
+	// go-ethereum's names disambiguate overloads, index the methods by signature
+	bySig := make(map[string]abi.Method)
+	for _, method := range parsed.Methods {
+		bySig[method.Sig] = method
+	}
+	eventsBySig := make(map[string]abi.Event)
+	for _, event := range parsed.Events {
+		eventsBySig[event.Sig] = event
+	}
+
+	functions := filterEntries(entries, "function")
+	for _, entry := range functions {
+		sig, err := signature(entry.Name, entry.Inputs)
+		if err != nil {
+			return nil, err
+		}
+		method, ok := bySig[sig]
+		if !ok {
+			return nil, fmt.Errorf("function %s not found in the parsed ABI", sig)
+		}
+		if err := g.function(&body, name, constName, method, entry); err != nil {
+			return nil, fmt.Errorf("%s: %w", sig, err)
+		}
+	}
+	var events []string
+	for _, entry := range filterEntries(entries, "event") {
+		sig, err := signature(entry.Name, entry.Inputs)
+		if err != nil {
+			return nil, err
+		}
+		event, ok := eventsBySig[sig]
+		if !ok {
+			return nil, fmt.Errorf("event %s not found in the parsed ABI", sig)
+		}
+		if err := g.event(&body, name, constName, event, entry); err != nil {
+			return nil, fmt.Errorf("%s: %w", sig, err)
+		}
+		events = append(events, event.Name)
+	}
+	if len(events) > 0 {
+		// DecodeLog returns the typed events
+		body.WriteString("func init() {\n")
+		for _, event := range events {
+			fmt.Fprintf(&body, "\tregisterEventDecoder(%s, %q, func(log types.Log) (interface{}, error) { return Unpack_%s_%sEvent(log) })\n", constName, event, name, event)
+		}
+		body.WriteString("}\n")
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by internal/abigen from abi/%s.json. DO NOT EDIT.\n\n", name)
+	fmt.Fprintf(&out, "package %s\n\n", pkg)
+	writeImports(&out, g.imports)
+	fmt.Fprintf(&out, "// %s is the registry name of abi/%s.json\nconst %s = %q\n\n", constName, name, constName, name)
+	out.Write(body.Bytes())
+	return out.Bytes(), nil
+}
+
+// filterEntries returns the entries of kind sorted by name, overloads keep their order
+func filterEntries(entries []jsonEntry, kind string) []jsonEntry {
+	var filtered []jsonEntry
+	for _, entry := range entries {
+		if entry.Type == kind {
+			filtered = append(filtered, entry)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].Name < filtered[j].Name })
+	return filtered
+}
+
+func writeImports(out *bytes.Buffer, imports map[string]bool) {
+	if len(imports) == 0 {
+		return
+	}
+	paths := make([]string, 0, len(imports))
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	// standard library first
+	sort.Slice(paths, func(i, j int) bool {
+		iStd, jStd := !strings.Contains(paths[i], "."), !strings.Contains(paths[j], ".")
+		if iStd != jStd {
+			return iStd
+		}
+		return paths[i] < paths[j]
+	})
+	out.WriteString("import (\n")
+	for i, path := range paths {
+		if i > 0 && !strings.Contains(paths[i-1], ".") && strings.Contains(path, ".") {
+			out.WriteString("\n")
+		}
+		fmt.Fprintf(out, "\t%q\n", path)
+	}
+	out.WriteString(")\n\n")
+}
+
+// function generates the pack function and the output decoder of a function
+func (g *generator) function(out *bytes.Buffer, contract, constName string, method abi.Method, entry jsonEntry) error {
+	g.imports["github.com/ethereum/go-ethereum/common/hexutil"] = true
+
+	params, names, err := g.params(entry.Inputs)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "// Pack_%s_%s packs a call to %s\n", contract, method.Name, method.Sig)
+	fmt.Fprintf(out, "func Pack_%s_%s(%s) (hexutil.Bytes, error) {\n", contract, method.Name, strings.Join(params, ", "))
+	args := ""
+	if len(names) > 0 {
+		args = ", " + strings.Join(names, ", ")
+	}
+	fmt.Fprintf(out, "\tdata, err := packABI(%s, %q%s)\n", constName, method.Sig, args)
+	out.WriteString("\tif err != nil {\n\t\treturn nil, err\n\t}\n\treturn hexutil.Bytes(data), nil\n}\n\n")
+
+	if len(entry.Outputs) == 0 {
+		return nil
+	}
+	decoder := fmt.Sprintf("Unpack_%s_%s", contract, method.Name)
+	if len(entry.Outputs) == 1 {
+		typ, err := g.goType(entry.Outputs[0])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "// %s decodes the return data of %s\n", decoder, method.Sig)
+		fmt.Fprintf(out, "func %s(data []byte) (%s, error) {\n", decoder, typ)
+		fmt.Fprintf(out, "\tvar result %s\n", typ)
+		fmt.Fprintf(out, "\tvalues, err := unpackABI(%s, %q, data, 1)\n", constName, method.Sig)
+		out.WriteString("\tif err != nil {\n\t\treturn result, err\n\t}\n")
+		fmt.Fprintf(out, "\treturn convertOutput[%s](%q, values, 0)\n}\n\n", typ, method.Sig)
+		return nil
+	}
+
+	outputType := exported(contract) + exported(method.Name) + "Output"
+	fields, err := g.fields(entry.Outputs, "Out")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "// %s holds the return values of %s\n", outputType, method.Sig)
+	fmt.Fprintf(out, "type %s struct {\n", outputType)
+	for _, field := range fields {
+		fmt.Fprintf(out, "\t%s %s\n", field.Name, field.Type)
+	}
+	out.WriteString("}\n\n")
+	fmt.Fprintf(out, "// %s decodes the return data of %s\n", decoder, method.Sig)
+	fmt.Fprintf(out, "func %s(data []byte) (%s, error) {\n", decoder, outputType)
+	fmt.Fprintf(out, "\tvar result %s\n", outputType)
+	fmt.Fprintf(out, "\tvalues, err := unpackABI(%s, %q, data, %d)\n", constName, method.Sig, len(fields))
+	out.WriteString("\tif err != nil {\n\t\treturn result, err\n\t}\n")
+	for i, field := range fields {
+		fmt.Fprintf(out, "\tif result.%s, err = convertOutput[%s](%q, values, %d); err != nil {\n\t\treturn result, err\n\t}\n", field.Name, field.Type, method.Sig, i)
+	}
+	out.WriteString("\treturn result, nil\n}\n\n")
+	return nil
+}
+
+// event generates the struct and the decoder of an event
+func (g *generator) event(out *bytes.Buffer, contract, constName string, event abi.Event, entry jsonEntry) error {
+	g.imports["github.com/ethereum/go-ethereum/core/types"] = true
+	typeName := exported(contract) + exported(event.Name) + "Event"
+	fields, err := g.fields(entry.Inputs, "Arg")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "// %s is the %s event\n", typeName, event.Sig)
+	fmt.Fprintf(out, "type %s struct {\n", typeName)
+	for i, field := range fields {
+		typ := field.Type
+		if entry.Inputs[i].Indexed && isHashedTopic(entry.Inputs[i].Type) {
+			// indexed dynamic values are only available as their hash
+			typ = "common.Hash"
+			g.imports["github.com/ethereum/go-ethereum/common"] = true
+		}
+		fmt.Fprintf(out, "\t%s %s\n", field.Name, typ)
+	}
+	out.WriteString("\tRaw types.Log\n}\n\n")
+
+	decoder := fmt.Sprintf("Unpack_%s_%sEvent", contract, event.Name)
+	fmt.Fprintf(out, "// %s decodes a %s log\n", decoder, event.Name)
+	fmt.Fprintf(out, "func %s(log types.Log) (*%s, error) {\n", decoder, typeName)
+	fmt.Fprintf(out, "\tevent := &%s{Raw: log}\n", typeName)
+	fmt.Fprintf(out, "\tif err := unpackEvent(%s, %q, log, event); err != nil {\n\t\treturn nil, err\n\t}\n", constName, event.Name)
+	out.WriteString("\treturn event, nil\n}\n\n")
+	return nil
+}
+
+// isHashedTopic reports whether an indexed value of type is stored as its keccak256
+func isHashedTopic(typ string) bool {
+	return typ == "string" || typ == "bytes" || strings.HasSuffix(typ, "]") || strings.HasPrefix(typ, "tuple")
+}
+
+// params returns the parameter list and argument names of a function
+func (g *generator) params(inputs []jsonArg) ([]string, []string, error) {
+	var params, names []string
+	used := make(map[string]bool)
+	for i, input := range inputs {
+		typ, err := g.goType(input)
+		if err != nil {
+			return nil, nil, err
+		}
+		name := paramName(input.Name, i)
+		for used[name] {
+			name += "_"
+		}
+		used[name] = true
+		params = append(params, name+" "+typ)
+		names = append(names, name)
+	}
+	return params, names, nil
+}
+
+// fields returns the struct fields of outputs or event inputs, unnamed values get prefix + index
+func (g *generator) fields(args []jsonArg, prefix string) ([]goField, error) {
+	var fields []goField
+	used := make(map[string]bool)
+	for i, arg := range args {
+		typ, err := g.goType(arg)
+		if err != nil {
+			return nil, err
+		}
+		name := abi.ToCamelCase(arg.Name)
+		if name == "" {
+			name = fmt.Sprintf("%s%d", prefix, i)
+		}
+		for used[name] || name == "Raw" {
+			name += "_"
+		}
+		used[name] = true
+		fields = append(fields, goField{name, typ})
+	}
+	return fields, nil
+}
+
+var arraySuffix = regexp.MustCompile(`\[\d*\]$`)
+
+// goType returns the Go type go-ethereum packs and unpacks for arg, registering structs
+func (g *generator) goType(arg jsonArg) (string, error) {
+	if suffix := arraySuffix.FindString(arg.Type); suffix != "" {
+		elem := arg
+		elem.Type = strings.TrimSuffix(arg.Type, suffix)
+		elem.InternalType = strings.TrimSuffix(arg.InternalType, suffix)
+		elemType, err := g.goType(elem)
+		if err != nil {
+			return "", err
+		}
+		return "[" + strings.Trim(suffix, "[]") + "]" + elemType, nil
+	}
+
+	switch {
+	case arg.Type == "tuple":
+		return g.tuple(arg)
+	case arg.Type == "address":
+		g.imports["github.com/ethereum/go-ethereum/common"] = true
+		return "common.Address", nil
+	case arg.Type == "bool":
+		return "bool", nil
+	case arg.Type == "string":
+		return "string", nil
+	case arg.Type == "bytes":
+		return "[]byte", nil
+	case arg.Type == "function":
+		return "[24]byte", nil
+	case strings.HasPrefix(arg.Type, "bytes"):
+		return "[" + strings.TrimPrefix(arg.Type, "bytes") + "]byte", nil
+	case strings.HasPrefix(arg.Type, "uint"), strings.HasPrefix(arg.Type, "int"):
+		typ, err := abi.NewType(arg.Type, "", nil)
+		if err != nil {
+			return "", err
+		}
+		prefix := "uint"
+		if strings.HasPrefix(arg.Type, "int") {
+			prefix = "int"
+		}
+		switch typ.Size {
+		case 8, 16, 32, 64:
+			return fmt.Sprintf("%s%d", prefix, typ.Size), nil
+		}
+		g.imports["math/big"] = true
+		return "*big.Int", nil
+	}
+	return "", fmt.Errorf("unsupported type %s", arg.Type)
+}
+
+// tuple returns the name of the struct generated for a tuple
+func (g *generator) tuple(arg jsonArg) (string, error) {
+	source := strings.TrimPrefix(arg.InternalType, "struct ")
+	if source == "" || source == arg.InternalType {
+		return "", fmt.Errorf("tuple %s has no struct internalType", arg.Name)
+	}
+	solidityName := source[strings.LastIndex(source, ".")+1:]
+	name, ok := g.renames[solidityName]
+	if !ok {
+		name = exported(solidityName)
+	}
+
+	var fields []goField
+	for _, component := range arg.Components {
+		typ, err := g.goType(component)
+		if err != nil {
+			return "", err
+		}
+		fields = append(fields, goField{abi.ToCamelCase(component.Name), typ})
+	}
+	if existing, ok := g.structs[name]; ok {
+		if fmt.Sprint(existing.Fields) != fmt.Sprint(fields) {
+			return "", fmt.Errorf("struct %s is declared with different fields by %s and %s", name, existing.Source, source)
+		}
+		return name, nil
+	}
+	g.structs[name] = &goStruct{Name: name, Source: source, Fields: fields}
+	return name, nil
+}
+
+// structsFile generates the file holding every struct
+func (g *generator) structsFile(pkg string) []byte {
+	names := make([]string, 0, len(g.structs))
+	imports := make(map[string]bool)
+	for name, s := range g.structs {
+		names = append(names, name)
+		for _, field := range s.Fields {
+			if strings.Contains(field.Type, "common.") {
+				imports["github.com/ethereum/go-ethereum/common"] = true
+			}
+			if strings.Contains(field.Type, "big.") {
+				imports["math/big"] = true
+			}
+		}
+	}
+	sort.Strings(names)
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by internal/abigen from abi/*.json. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", pkg)
+	writeImports(&out, imports)
+	for _, name := range names {
+		s := g.structs[name]
+		fmt.Fprintf(&out, "// %s represents the %s struct\n", s.Name, s.Source)
+		fmt.Fprintf(&out, "type %s struct {\n", s.Name)
+		for _, field := range s.Fields {
+			fmt.Fprintf(&out, "\t%s %s\n", field.Name, field.Type)
+		}
+		out.WriteString("}\n\n")
+	}
+	return out.Bytes()
+}
+
+// signature returns the canonical signature of an entry, as go-ethereum computes it
+func signature(name string, inputs []jsonArg) (string, error) {
+	types := make([]string, len(inputs))
+	for i, input := range inputs {
+		typ, err := abi.NewType(input.Type, input.InternalType, toArgumentMarshaling(input.Components))
+		if err != nil {
+			return "", err
+		}
+		types[i] = typ.String()
+	}
+	return name + "(" + strings.Join(types, ",") + ")", nil
+}
+
+func toArgumentMarshaling(components []jsonArg) []abi.ArgumentMarshaling {
+	marshaling := make([]abi.ArgumentMarshaling, len(components))
+	for i, component := range components {
+		marshaling[i] = abi.ArgumentMarshaling{
+			Name:         component.Name,
+			Type:         component.Type,
+			InternalType: component.InternalType,
+			Components:   toArgumentMarshaling(component.Components),
+			Indexed:      component.Indexed,
+		}
+	}
+	return marshaling
+}
+
+// exported capitalizes the first letter of name
+func exported(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+var goKeywords = map[string]bool{
+	"break": true, "case": true, "chan": true, "const": true, "continue": true, "default": true,
+	"defer": true, "else": true, "fallthrough": true, "for": true, "func": true, "go": true,
+	"goto": true, "if": true, "import": true, "interface": true, "map": true, "package": true,
+	"range": true, "return": true, "select": true, "struct": true, "switch": true, "type": true,
+	"var": true, "data": true, "err": true,
+	// imported packages
+	"abi": true, "big": true, "common": true, "hexutil": true, "types": true,
+}
+
+// paramName returns a Go parameter name for an ABI input
+func paramName(name string, index int) string {
+	name = strings.TrimLeft(name, "_")
+	if name == "" {
+		return fmt.Sprintf("arg%d", index)
+	}
+	name = strings.ToLower(name[:1]) + name[1:]
+	if goKeywords[name] {
+		name += "_"
+	}
+	return name
+}
@@ -0,0 +1,128 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrUnknownEvent is returned by DecodeLog for a log no registered ABI declares
+var ErrUnknownEvent = errors.New("unknown event")
+
+// DecodedLog is a log of an event without generated bindings, e.g. from an ABI
+// registered at runtime
+type DecodedLog struct {
+	Event
+	Args []Argument
+	Raw  types.Log
+}
+
+// Arg returns the value of the argument called name
+func (l *DecodedLog) Arg(name string) (interface{}, bool) {
+	return findArgument(l.Args, name)
+}
+
+// eventDecoders holds the generated decoders by ABI name and event name
+var (
+	eventDecodersMu sync.RWMutex
+	eventDecoders   = make(map[string]map[string]func(types.Log) (interface{}, error))
+)
+
+// registerEventDecoder is called by the generated files
+func registerEventDecoder(contract, event string, decode func(types.Log) (interface{}, error)) {
+	eventDecodersMu.Lock()
+	defer eventDecodersMu.Unlock()
+	if eventDecoders[contract] == nil {
+		eventDecoders[contract] = make(map[string]func(types.Log) (interface{}, error))
+	}
+	eventDecoders[contract][event] = decode
+}
+
+func eventDecoder(contract, event string) func(types.Log) (interface{}, error) {
+	eventDecodersMu.RLock()
+	defer eventDecodersMu.RUnlock()
+	return eventDecoders[contract][event]
+}
+
+// DecodeLog decodes a log against the ABIs of the default registry. Events with
+// generated bindings are returned typed, e.g. *Uniswapv2SyncEvent or *Erc20TransferEvent,
+// others as *DecodedLog. Events sharing a topic, like the ERC-20 and ERC-721 Transfer,
+// are told apart by their indexed topics and data.
+//
+// Algebra V1 pools emit the UniswapV3 Swap event and decode as *Uniswapv3SwapEvent,
+// *AlgebraSwapEvent is the Swap of Algebra Integral.
+func DecodeLog(log *types.Log) (interface{}, error) {
+	if log == nil {
+		return nil, fmt.Errorf("log is nil")
+	}
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("%w: anonymous log", ErrUnknownEvent)
+	}
+	events := defaultRegistry.EventsByTopic(log.Topics[0])
+	if len(events) == 0 {
+		return nil, fmt.Errorf("%w: topic %s", ErrUnknownEvent, log.Topics[0].Hex())
+	}
+	var lastErr error
+	for _, event := range events {
+		var decoded interface{}
+		var err error
+		if decode := eventDecoder(event.Contract, event.Name); decode != nil {
+			decoded, err = decode(*log)
+		} else {
+			decoded, err = decodeLogArguments(event, *log)
+		}
+		if err == nil {
+			return decoded, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// decodeLogArguments decodes a log into its arguments in declaration order
+func decodeLogArguments(event Event, log types.Log) (*DecodedLog, error) {
+	values := make(map[string]interface{})
+	if err := unpackEventMap(event, log, values); err != nil {
+		return nil, err
+	}
+	decoded := &DecodedLog{Event: event, Raw: log}
+	for _, input := range event.Inputs {
+		decoded.Args = append(decoded.Args, Argument{Name: input.Name, Type: input.Type.String(), Value: values[input.Name]})
+	}
+	return decoded, nil
+}
+
+// DecodeLogs decodes the logs returned by eth_getPengingBlockLog, eth_getTransactionLog
+// or eth_getLogs, skipping the logs DecodeLog cannot decode
+//
+//	for _, event := range helper.DecodeLogs(logs) {
+//		switch event := event.(type) {
+//		case *helper.Uniswapv2SyncEvent:
+//			// event.Raw.Address has reserves event.Reserve0, event.Reserve1
+//		case *helper.Uniswapv3SwapEvent:
+//		}
+//	}
+func DecodeLogs(logs []*types.Log) []interface{} {
+	var events []interface{}
+	for _, log := range logs {
+		if event, err := DecodeLog(log); err == nil {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// LatestSyncs returns the last UniswapV2 Sync of each pair in logs, i.e. the reserves
+// after the logged transactions
+func LatestSyncs(logs []*types.Log) map[common.Address]*Uniswapv2SyncEvent {
+	syncs := make(map[common.Address]*Uniswapv2SyncEvent)
+	for _, event := range DecodeLogs(logs) {
+		if sync, ok := event.(*Uniswapv2SyncEvent); ok {
+			syncs[sync.Raw.Address] = sync
+		}
+	}
+	return syncs
+}
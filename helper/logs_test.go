@@ -0,0 +1,155 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	syncTopic     = crypto.Keccak256Hash([]byte("Sync(uint112,uint112)"))
+	transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	logFrom       = common.HexToAddress("0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045")
+	logTo         = common.HexToAddress("0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D")
+)
+
+// erc721ABI declares the ERC-721 Transfer, whose topic is the one of the ERC-20 Transfer
+// with the token id indexed instead of the value in the data
+const erc721ABI = `[{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"tokenId","type":"uint256","indexed":true}]}]`
+
+// withERC721 makes the default registry hold the embedded ABIs and then erc721ABI
+// for the duration of the test
+func withERC721(t *testing.T) {
+	t.Helper()
+	registry := NewABIRegistry()
+	if err := registry.RegisterFS(abiFiles, "abi/*.json"); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.RegisterJSON("erc721", []byte(erc721ABI)); err != nil {
+		t.Fatal(err)
+	}
+	previous := defaultRegistry
+	defaultRegistry = registry
+	t.Cleanup(func() { defaultRegistry = previous })
+}
+
+func syncLog(pair common.Address, reserve0, reserve1 int64) *types.Log {
+	return &types.Log{
+		Address: pair,
+		Topics:  []common.Hash{syncTopic},
+		Data:    hexutil.MustDecode(fmt.Sprintf("0x%064x%064x", reserve0, reserve1)),
+	}
+}
+
+// erc20Transfer is a Transfer of value, erc721Transfer one of token id
+func erc20Transfer(value int64) *types.Log {
+	return &types.Log{
+		Topics: []common.Hash{transferTopic, common.BytesToHash(logFrom.Bytes()), common.BytesToHash(logTo.Bytes())},
+		Data:   hexutil.MustDecode(fmt.Sprintf("0x%064x", value)),
+	}
+}
+
+func erc721Transfer(tokenID int64) *types.Log {
+	return &types.Log{
+		Topics: []common.Hash{transferTopic, common.BytesToHash(logFrom.Bytes()), common.BytesToHash(logTo.Bytes()), common.BigToHash(big.NewInt(tokenID))},
+	}
+}
+
+func TestDecodeLog(t *testing.T) {
+	pair := common.HexToAddress("0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc")
+	decoded, err := DecodeLog(syncLog(pair, 1000, 2000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sync, ok := decoded.(*Uniswapv2SyncEvent); !ok || sync.Reserve0.Int64() != 1000 || sync.Reserve1.Int64() != 2000 || sync.Raw.Address != pair {
+		t.Errorf("Sync = %#v", decoded)
+	}
+	decoded, err = DecodeLog(erc20Transfer(5))
+	if transfer, ok := decoded.(*Erc20TransferEvent); err != nil || !ok || transfer.From != logFrom || transfer.To != logTo || transfer.Value.Int64() != 5 {
+		t.Errorf("Transfer = %#v, %v", decoded, err)
+	}
+
+	tests := []struct {
+		name    string
+		log     *types.Log
+		unknown bool
+	}{
+		{"nil", nil, false},
+		{"anonymous", &types.Log{Data: hexutil.MustDecode("0x01")}, true},
+		{"unknown topic", &types.Log{Topics: []common.Hash{common.HexToHash("0x01")}}, true},
+		// a known topic that no declaration decodes
+		{"erc721 without the ABI", erc721Transfer(7), false},
+		{"short data", &types.Log{Topics: []common.Hash{syncTopic}, Data: hexutil.MustDecode("0x01")}, false},
+	}
+	for _, tt := range tests {
+		decoded, err := DecodeLog(tt.log)
+		if err == nil {
+			t.Errorf("%s: decoded %#v", tt.name, decoded)
+			continue
+		}
+		if errors.Is(err, ErrUnknownEvent) != tt.unknown {
+			t.Errorf("%s: err = %v, ErrUnknownEvent %t", tt.name, err, tt.unknown)
+		}
+	}
+}
+
+func TestDecodeLogSharedTopic(t *testing.T) {
+	withERC721(t)
+
+	// the embedded ERC-20 declaration is tried first and keeps its typed decoding
+	decoded, err := DecodeLog(erc20Transfer(5))
+	if transfer, ok := decoded.(*Erc20TransferEvent); err != nil || !ok || transfer.Value.Int64() != 5 {
+		t.Errorf("ERC-20 Transfer = %#v, %v", decoded, err)
+	}
+
+	// an ERC-721 Transfer has a topic too many for it and falls through to the ERC-721 ABI
+	decoded, err = DecodeLog(erc721Transfer(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	nft, ok := decoded.(*DecodedLog)
+	if !ok || nft.Contract != "erc721" || len(nft.Args) != 3 {
+		t.Fatalf("ERC-721 Transfer = %#v", decoded)
+	}
+	if tokenID, _ := nft.Arg("tokenId"); tokenID.(*big.Int).Int64() != 7 {
+		t.Errorf("tokenId = %v", tokenID)
+	}
+	if from, _ := nft.Arg("from"); from != logFrom || nft.Args[0].Name != "from" || nft.Args[2].Type != "uint256" {
+		t.Errorf("arguments = %+v", nft.Args)
+	}
+
+	// a log matching neither returns the error of the last declaration tried
+	broken := erc20Transfer(5)
+	broken.Topics = broken.Topics[:2]
+	if _, err := DecodeLog(broken); err == nil || errors.Is(err, ErrUnknownEvent) {
+		t.Errorf("malformed Transfer err = %v", err)
+	}
+}
+
+func TestDecodeLogs(t *testing.T) {
+	pair0 := common.HexToAddress("0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc")
+	pair1 := common.HexToAddress("0x0d4a11d5EEaaC28EC3F61d100daF4d40471f1852")
+	logs := []*types.Log{
+		syncLog(pair0, 1, 2),
+		erc20Transfer(5),
+		{Topics: []common.Hash{common.HexToHash("0x01")}},
+		syncLog(pair1, 3, 4),
+		syncLog(pair0, 5, 6),
+		nil,
+	}
+	if events := DecodeLogs(logs); len(events) != 4 {
+		t.Errorf("DecodeLogs decoded %d logs, want 4", len(events))
+	}
+
+	// the last Sync of each pair wins
+	syncs := LatestSyncs(logs)
+	if len(syncs) != 2 || syncs[pair0].Reserve0.Int64() != 5 || syncs[pair1].Reserve1.Int64() != 4 {
+		t.Errorf("LatestSyncs = %v", syncs)
+	}
+}
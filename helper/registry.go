@@ -0,0 +1,341 @@
+package helper
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Selector is a 4-byte function or error selector
+type Selector [4]byte
+
+// Method is a function of a registered contract ABI
+type Method struct {
+	Contract string
+	*abi.Method
+}
+
+// Event is an event of a registered contract ABI
+type Event struct {
+	Contract string
+	*abi.Event
+}
+
+// Error is a custom error of a registered contract ABI
+type Error struct {
+	Contract string
+	*abi.Error
+}
+
+// ABIRegistry holds contract ABIs by name, indexed by function selector, event topic
+// and error selector. Registering a name again replaces the previous ABI, so a new router
+// version can be loaded at runtime. ABIRegistry is safe for concurrent use.
+type ABIRegistry struct {
+	mu      sync.RWMutex
+	abis    map[string]*abi.ABI
+	methods map[Selector][]Method
+	events  map[common.Hash][]Event
+	errors  map[Selector][]Error
+}
+
+// NewABIRegistry creates an empty registry
+func NewABIRegistry() *ABIRegistry {
+	return &ABIRegistry{
+		abis:    make(map[string]*abi.ABI),
+		methods: make(map[Selector][]Method),
+		events:  make(map[common.Hash][]Event),
+		errors:  make(map[Selector][]Error),
+	}
+}
+
+// Register adds contractABI under name, replacing any ABI with the same name
+func (r *ABIRegistry) Register(name string, contractABI abi.ABI) error {
+	if name == "" {
+		return fmt.Errorf("ABI name is empty")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.abis[name]; ok {
+		r.unindex(name)
+	}
+	r.abis[name] = &contractABI
+	r.index(name, &contractABI)
+	return nil
+}
+
+// RegisterJSON parses a JSON ABI and adds it under name
+func (r *ABIRegistry) RegisterJSON(name string, data []byte) error {
+	parsed, err := abi.JSON(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to parse ABI for %s: %w", name, err)
+	}
+	return r.Register(name, parsed)
+}
+
+// RegisterFile adds the JSON ABI at filename, named after the file without extension
+func (r *ABIRegistry) RegisterFile(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("failed to read ABI file %s: %w", filename, err)
+	}
+	return r.RegisterJSON(strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename)), data)
+}
+
+// RegisterFS adds every JSON ABI of fsys matching pattern (e.g. "abi/*.json"), each named
+// after its file without extension; all files are tried and the errors joined
+func (r *ABIRegistry) RegisterFS(fsys fs.FS, pattern string) error {
+	matches, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return fmt.Errorf("invalid ABI pattern %q: %w", pattern, err)
+	}
+	if len(matches) == 0 {
+		return fmt.Errorf("no ABI file matches %q", pattern)
+	}
+	var errs []string
+	for _, match := range matches {
+		data, err := fs.ReadFile(fsys, match)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("failed to read ABI file %s: %v", match, err))
+			continue
+		}
+		if err := r.RegisterJSON(strings.TrimSuffix(path.Base(match), path.Ext(match)), data); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// index adds the methods, events and errors of contractABI, caller holds the lock
+func (r *ABIRegistry) index(name string, contractABI *abi.ABI) {
+	for _, method := range contractABI.Methods {
+		method := method
+		selector := Selector(method.ID)
+		r.methods[selector] = append(r.methods[selector], Method{name, &method})
+	}
+	for _, event := range contractABI.Events {
+		event := event
+		r.events[event.ID] = append(r.events[event.ID], Event{name, &event})
+	}
+	for _, customErr := range contractABI.Errors {
+		customErr := customErr
+		selector := Selector(customErr.ID[:4])
+		r.errors[selector] = append(r.errors[selector], Error{name, &customErr})
+	}
+}
+
+// unindex removes the entries of name, caller holds the lock
+func (r *ABIRegistry) unindex(name string) {
+	for selector, methods := range r.methods {
+		if methods = removeContract(methods, name, func(m Method) string { return m.Contract }); len(methods) == 0 {
+			delete(r.methods, selector)
+		} else {
+			r.methods[selector] = methods
+		}
+	}
+	for topic, events := range r.events {
+		if events = removeContract(events, name, func(e Event) string { return e.Contract }); len(events) == 0 {
+			delete(r.events, topic)
+		} else {
+			r.events[topic] = events
+		}
+	}
+	for selector, errs := range r.errors {
+		if errs = removeContract(errs, name, func(e Error) string { return e.Contract }); len(errs) == 0 {
+			delete(r.errors, selector)
+		} else {
+			r.errors[selector] = errs
+		}
+	}
+}
+
+func removeContract[T any](entries []T, name string, contract func(T) string) []T {
+	kept := entries[:0:0]
+	for _, entry := range entries {
+		if contract(entry) != name {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+// ABI returns the ABI registered under name
+func (r *ABIRegistry) ABI(name string) (*abi.ABI, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	contractABI, ok := r.abis[name]
+	if !ok {
+		return nil, fmt.Errorf("ABI %s is not registered", name)
+	}
+	return contractABI, nil
+}
+
+// Names returns the registered ABI names, sorted
+func (r *ABIRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.namesLocked()
+}
+
+// ABIs returns every registered ABI, e.g. to decode reverts against all of them
+func (r *ABIRegistry) ABIs() []*abi.ABI {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	abis := make([]*abi.ABI, 0, len(r.abis))
+	for _, name := range r.namesLocked() {
+		abis = append(abis, r.abis[name])
+	}
+	return abis
+}
+
+func (r *ABIRegistry) namesLocked() []string {
+	names := make([]string, 0, len(r.abis))
+	for name := range r.abis {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MethodsBySelector returns the functions with the given selector, data may be full calldata
+// The same selector usually means the same signature across contracts.
+func (r *ABIRegistry) MethodsBySelector(data []byte) []Method {
+	if len(data) < 4 {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]Method(nil), r.methods[Selector(data[:4])]...)
+}
+
+// MethodBySelector returns a function with the given selector, data may be full calldata
+func (r *ABIRegistry) MethodBySelector(data []byte) (Method, error) {
+	methods := r.MethodsBySelector(data)
+	if len(methods) == 0 {
+		if len(data) < 4 {
+			return Method{}, fmt.Errorf("calldata is shorter than a selector")
+		}
+		return Method{}, fmt.Errorf("no registered function with selector %#x", data[:4])
+	}
+	return methods[0], nil
+}
+
+// EventsByTopic returns the events with the given topic 0
+func (r *ABIRegistry) EventsByTopic(topic common.Hash) []Event {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]Event(nil), r.events[topic]...)
+}
+
+// EventByTopic returns an event with the given topic 0
+func (r *ABIRegistry) EventByTopic(topic common.Hash) (Event, error) {
+	events := r.EventsByTopic(topic)
+	if len(events) == 0 {
+		return Event{}, fmt.Errorf("no registered event with topic %s", topic.Hex())
+	}
+	return events[0], nil
+}
+
+// ErrorBySelector returns a custom error with the given selector, data may be full revert data
+func (r *ABIRegistry) ErrorBySelector(data []byte) (Error, error) {
+	if len(data) < 4 {
+		return Error{}, fmt.Errorf("revert data is shorter than a selector")
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	errs := r.errors[Selector(data[:4])]
+	if len(errs) == 0 {
+		return Error{}, fmt.Errorf("no registered error with selector %#x", data[:4])
+	}
+	return errs[0], nil
+}
+
+// Overloads returns the functions of contract named name, e.g. both LoanCheck overloads
+// of routerMoudle. name is the Solidity name, go-ethereum's deduplicated name
+// ("LoanCheck0") or a full signature ("LoanCheck(uint8,address,address,uint256)").
+func (r *ABIRegistry) Overloads(contract, name string) ([]*abi.Method, error) {
+	contractABI, err := r.ABI(contract)
+	if err != nil {
+		return nil, err
+	}
+	if strings.Contains(name, "(") {
+		for _, method := range contractABI.Methods {
+			if method.Sig == name {
+				method := method
+				return []*abi.Method{&method}, nil
+			}
+		}
+		return nil, fmt.Errorf("function %s not found in %s", name, contract)
+	}
+	if method, ok := contractABI.Methods[name]; ok && method.Name != method.RawName {
+		return []*abi.Method{&method}, nil
+	}
+	var overloads []*abi.Method
+	for _, method := range contractABI.Methods {
+		if method.RawName == name {
+			method := method
+			overloads = append(overloads, &method)
+		}
+	}
+	if len(overloads) == 0 {
+		return nil, fmt.Errorf("function %s not found in %s", name, contract)
+	}
+	// the undecorated name first, then go-ethereum's numbering
+	sort.Slice(overloads, func(i, j int) bool {
+		if len(overloads[i].Name) != len(overloads[j].Name) {
+			return len(overloads[i].Name) < len(overloads[j].Name)
+		}
+		return overloads[i].Name < overloads[j].Name
+	})
+	return overloads, nil
+}
+
+// ResolveMethod returns the overload of name in contract that accepts args
+func (r *ABIRegistry) ResolveMethod(contract, name string, args ...interface{}) (*abi.Method, error) {
+	overloads, err := r.Overloads(contract, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(overloads) == 1 {
+		return overloads[0], nil
+	}
+	var lastErr error
+	for _, method := range overloads {
+		if len(method.Inputs) != len(args) {
+			continue
+		}
+		if _, err := method.Inputs.Pack(args...); err != nil {
+			lastErr = err
+			continue
+		}
+		return method, nil
+	}
+	if lastErr != nil {
+		return nil, fmt.Errorf("no overload of %s in %s accepts the arguments: %w", name, contract, lastErr)
+	}
+	return nil, fmt.Errorf("no overload of %s in %s takes %d arguments", name, contract, len(args))
+}
+
+// Pack encodes a call to name of contract, resolving overloads from args
+func (r *ABIRegistry) Pack(contract, name string, args ...interface{}) ([]byte, error) {
+	method, err := r.ResolveMethod(contract, name, args...)
+	if err != nil {
+		return nil, err
+	}
+	arguments, err := method.Inputs.Pack(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s: %w", method.Sig, err)
+	}
+	return append(append([]byte{}, method.ID...), arguments...), nil
+}
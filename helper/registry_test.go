@@ -0,0 +1,203 @@
+package helper
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// contracts returns the contracts of methods, in order
+func contracts(methods []Method) string {
+	names := make([]string, len(methods))
+	for i, method := range methods {
+		names[i] = method.Contract
+	}
+	return strings.Join(names, ",")
+}
+
+func TestRegistrySelectorCollisions(t *testing.T) {
+	if err := EmbeddedABIError(); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		sig       string
+		contracts string
+	}{
+		// the pool views shared by the V2 and V3 style pools
+		{"token0()", "algebra,uniswapv2,uniswapv3"},
+		{"token1()", "algebra,uniswapv2,uniswapv3"},
+		{"tickSpacing()", "algebra,uniswapv3"},
+		{"factory()", "uniswapv2,uniswapv3"},
+		// the ERR_* views compiled into every module
+		{"ERR_NO_PROFIT()", "calculatorMoudle,routerMoudle,swapMoudle"},
+		{"ERR_MINI_OUT()", "calculatorMoudle,routerMoudle,swapMoudle"},
+		{"ERR_WRONG_ROUTER()", "calculatorMoudle,routerMoudle,swapMoudle"},
+		{"getReserves()", "uniswapv2"},
+	}
+	for _, tt := range tests {
+		selector := crypto.Keccak256([]byte(tt.sig))[:4]
+		// full calldata is looked up by its selector
+		methods := Registry().MethodsBySelector(append(selector, make([]byte, 32)...))
+		if got := contracts(methods); got != tt.contracts {
+			t.Errorf("%s is in %s, want %s", tt.sig, got, tt.contracts)
+			continue
+		}
+		for _, method := range methods {
+			if method.Sig != tt.sig {
+				t.Errorf("%s of %s has signature %s", tt.sig, method.Contract, method.Sig)
+			}
+		}
+		// the first contract registered wins
+		if method, err := Registry().MethodBySelector(selector); err != nil || method.Contract != strings.Split(tt.contracts, ",")[0] {
+			t.Errorf("MethodBySelector(%s) = %s, %v", tt.sig, method.Contract, err)
+		}
+	}
+
+	if _, err := Registry().MethodBySelector([]byte{0x01, 0x02}); err == nil {
+		t.Error("a short selector resolved")
+	}
+	if _, err := Registry().MethodBySelector(hexutil.MustDecode("0xdeadbeef")); err == nil {
+		t.Error("an unknown selector resolved")
+	}
+}
+
+func TestRegistryTopicIndex(t *testing.T) {
+	registry := NewABIRegistry()
+	transfer := `{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]}`
+	if err := registry.RegisterJSON("token", []byte(`[`+transfer+`]`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.RegisterJSON("wrapper", []byte(`[`+transfer+`,{"type":"event","name":"Deposit","anonymous":false,"inputs":[{"name":"dst","type":"address","indexed":true},{"name":"wad","type":"uint256","indexed":false}]}]`)); err != nil {
+		t.Fatal(err)
+	}
+
+	topic := crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	events := registry.EventsByTopic(topic)
+	if len(events) != 2 || events[0].Contract != "token" || events[1].Contract != "wrapper" {
+		t.Fatalf("EventsByTopic = %v", events)
+	}
+	if event, err := registry.EventByTopic(topic); err != nil || event.Contract != "token" {
+		t.Errorf("EventByTopic = %s, %v, want the first registered", event.Contract, err)
+	}
+	if _, err := registry.EventByTopic(common.Hash{}); err == nil {
+		t.Error("an unknown topic resolved")
+	}
+
+	// registering a name again replaces its entries
+	if err := registry.RegisterJSON("token", []byte(`[]`)); err != nil {
+		t.Fatal(err)
+	}
+	if events := registry.EventsByTopic(topic); len(events) != 1 || events[0].Contract != "wrapper" {
+		t.Errorf("EventsByTopic after replacing token = %v", events)
+	}
+	if names := strings.Join(registry.Names(), ","); names != "token,wrapper" || len(registry.ABIs()) != 2 {
+		t.Errorf("Names = %s", names)
+	}
+}
+
+func TestRegistryReplaceRouter(t *testing.T) {
+	registry := NewABIRegistry()
+	fsys := fstest.MapFS{
+		"abi/router.json": {Data: []byte(`[{"type":"function","name":"multiSwap","inputs":[{"name":"amountIn","type":"uint256"}],"outputs":[],"stateMutability":"nonpayable"}]`)},
+		"abi/broken.json": {Data: []byte(`{`)},
+	}
+	if err := registry.RegisterFS(fsys, "abi/*.json"); err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("RegisterFS err = %v, want the broken file", err)
+	}
+	v1 := crypto.Keccak256([]byte("multiSwap(uint256)"))[:4]
+	if _, err := registry.MethodBySelector(v1); err != nil {
+		t.Fatal(err)
+	}
+
+	// a new router version drops the selectors of the old one
+	if err := registry.RegisterJSON("router", []byte(`[{"type":"function","name":"multiSwap","inputs":[{"name":"amountIn","type":"uint256"},{"name":"minOut","type":"uint256"}],"outputs":[],"stateMutability":"nonpayable"}]`)); err != nil {
+		t.Fatal(err)
+	}
+	if methods := registry.MethodsBySelector(v1); len(methods) != 0 {
+		t.Errorf("the old selector resolves to %v", methods)
+	}
+	if method, err := registry.MethodBySelector(crypto.Keccak256([]byte("multiSwap(uint256,uint256)"))[:4]); err != nil || method.Contract != "router" {
+		t.Errorf("new selector = %v, %v", method, err)
+	}
+	if err := registry.RegisterJSON("", []byte(`[]`)); err == nil {
+		t.Error("an unnamed ABI was registered")
+	}
+}
+
+func TestOverloads(t *testing.T) {
+	const tupleSig = "LoanCheck((address,address,uint8,bool),uint256,address,(address,address,address,uint32,uint8,bytes)[])"
+	tests := []struct {
+		contract string
+		name     string
+		want     []string
+		wantErr  bool
+	}{
+		// the undecorated name first
+		{RouterMoudleABIName, "LoanCheck", []string{"LoanCheck(uint8,address,address,uint256)", tupleSig}, false},
+		{RouterMoudleABIName, "LoanCheck0", []string{tupleSig}, false},
+		{RouterMoudleABIName, tupleSig, []string{tupleSig}, false},
+		{Uniswapv3ABIName, "tickSpacing", []string{"tickSpacing()"}, false},
+		{RouterMoudleABIName, "LoanCheck(uint8)", nil, true},
+		{RouterMoudleABIName, "noSuchFunction", nil, true},
+		{"noSuchContract", "LoanCheck", nil, true},
+	}
+	for _, tt := range tests {
+		overloads, err := Registry().Overloads(tt.contract, tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Overloads(%s, %s) err = %v, wantErr %v", tt.contract, tt.name, err, tt.wantErr)
+			continue
+		}
+		sigs := make([]string, len(overloads))
+		for i, method := range overloads {
+			sigs[i] = method.Sig
+		}
+		if strings.Join(sigs, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("Overloads(%s, %s) = %v, want %v", tt.contract, tt.name, sigs, tt.want)
+		}
+	}
+}
+
+func TestResolveMethod(t *testing.T) {
+	pool, token := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+	loanPool := LoanPool{Pool: pool, Token: token, Types: 1}
+	tests := []struct {
+		name    string
+		args    []interface{}
+		want    string
+		wantErr bool
+	}{
+		// both overloads take 4 arguments, the types pick one
+		{"scalars", []interface{}{uint8(1), pool, token, big.NewInt(1)}, "LoanCheck", false},
+		{"tuples", []interface{}{loanPool, big.NewInt(1), token, []PairInfo{}}, "LoanCheck0", false},
+		{"wrong types", []interface{}{pool, pool, pool, pool}, "", true},
+		{"wrong count", []interface{}{uint8(1)}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method, err := Registry().ResolveMethod(RouterMoudleABIName, "LoanCheck", tt.args...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if method.Name != tt.want {
+				t.Errorf("resolved %s, want %s", method.Name, tt.want)
+			}
+			packed, err := Registry().Pack(RouterMoudleABIName, "LoanCheck", tt.args...)
+			if err != nil || !strings.HasPrefix(hexutil.Encode(packed), hexutil.Encode(method.ID)) {
+				t.Errorf("Pack = %x, %v, want the selector of %s", packed, err, method.Sig)
+			}
+		})
+	}
+
+	// a single overload is returned without checking the arguments
+	if method, err := Registry().ResolveMethod(Uniswapv2ABIName, "getReserves", uint8(1)); err != nil || method.Sig != "getReserves()" {
+		t.Errorf("ResolveMethod(getReserves) = %v, %v", method, err)
+	}
+}
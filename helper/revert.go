@@ -0,0 +1,94 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	wsClient "github.com/0xKhennati/wsclient"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DecodeRevert decodes a failed call error, resolving custom errors against
+// the router, swap and calculator module ABIs
+//
+//	if revertErr, ok := helper.DecodeRevert(resp.Error); ok && errors.Is(revertErr, wsClient.ErrNoProfit) {
+//		// skip this opportunity
+//	}
+func DecodeRevert(err error) (*wsClient.RevertError, bool) {
+	return wsClient.DecodeRevert(err, moduleABIs()...)
+}
+
+// DecodeRevertData decodes raw revert data against the module ABIs
+func DecodeRevertData(data []byte) *wsClient.RevertError {
+	return wsClient.DecodeRevertData(data, moduleABIs()...)
+}
+
+// LoadRevertReasons calls the ERR_*() views of the module deployed at address, whose ABI
+// is registered under contract (RouterMoudleABIName, SwapMoudleABIName or
+// CalculatorMoudleABIName), and registers the strings they return with
+// wsClient.RegisterRevertReason, so a deployment reverting with other strings than the
+// constant names still decodes to the sentinels. It returns the reasons by constant name;
+// the views that fail are skipped and their errors joined.
+func LoadRevertReasons(ctx context.Context, c *wsClient.Client, contract string, address common.Address, block wsClient.BlockRef) (map[string]string, error) {
+	contractABI, err := Registry().ABI(contract)
+	if err != nil {
+		return nil, err
+	}
+	var views []abi.Method
+	for _, method := range contractABI.Methods {
+		if strings.HasPrefix(method.RawName, "ERR_") && len(method.Inputs) == 0 &&
+			len(method.Outputs) == 1 && method.Outputs[0].Type.T == abi.StringTy {
+			views = append(views, method)
+		}
+	}
+	if len(views) == 0 {
+		return nil, fmt.Errorf("%s has no ERR_* view", contract)
+	}
+	sort.Slice(views, func(i, j int) bool { return views[i].RawName < views[j].RawName })
+
+	requests := make([]*wsClient.Request, len(views))
+	for i, view := range views {
+		request, err := wsClient.Build_eth_call_request_at(0, wsClient.CallMsg{To: &address, Data: view.ID}, nil, block)
+		if err != nil {
+			return nil, err
+		}
+		requests[i] = request
+	}
+	responses, err := c.CallBatch(ctx, requests)
+	if err != nil {
+		return nil, err
+	}
+
+	reasons := make(map[string]string, len(views))
+	var errs []string
+	for i, response := range responses {
+		name := views[i].RawName
+		if response.Error != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", name, response.Error))
+			continue
+		}
+		var data hexutil.Bytes
+		if err := data.UnmarshalJSON(response.Result); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		values, err := views[i].Outputs.Unpack(data)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: failed to unpack: %v", name, err))
+			continue
+		}
+		reason := values[0].(string)
+		reasons[name] = reason
+		if sentinel := wsClient.RevertSentinel(name); sentinel != nil {
+			wsClient.RegisterRevertReason(reason, sentinel)
+		}
+	}
+	if len(errs) > 0 {
+		return reasons, fmt.Errorf("failed to load the revert reasons of %s: %s", contract, strings.Join(errs, "; "))
+	}
+	return reasons, nil
+}
@@ -0,0 +1,62 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	wsClient "github.com/0xKhennati/wsclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// revertString is the hex ABI encoding of a string return value, or of the Error(string)
+// revert data behind its selector
+func revertString(reason string) string {
+	data := fmt.Sprintf("%x", reason)
+	return fmt.Sprintf("%064x%064x", 0x20, len(reason)) + data + strings.Repeat("0", (64-len(data)%64)%64)
+}
+
+func TestLoadRevertReasons(t *testing.T) {
+	router := common.HexToAddress("0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f")
+	// a deployment returning short codes, with an ERR_CURVE view that reverts
+	client := dialNode(t, func(method string, msg wsClient.CallMsg) (string, error) {
+		call, err := DecodeCall(msg.Data)
+		if err != nil || method != "eth_call" || *msg.To != router || !strings.HasPrefix(call.RawName, "ERR_") {
+			return "", errors.New("execution reverted")
+		}
+		if call.RawName == "ERR_CURVE" {
+			return "", errors.New("execution reverted")
+		}
+		code := "R" + strings.ToLower(strings.TrimPrefix(call.RawName, "ERR_"))
+		return `"0x` + revertString(code) + `"`, nil
+	})
+
+	reasons, err := LoadRevertReasons(context.Background(), client, RouterMoudleABIName, router, wsClient.BlockRefFromTag(wsClient.BlockLatest))
+	if err == nil || !strings.Contains(err.Error(), "ERR_CURVE") {
+		t.Errorf("err = %v, want the failure of ERR_CURVE", err)
+	}
+	if reasons["ERR_NO_PROFIT"] != "Rno_profit" || reasons["ERR_MINI_OUT"] != "Rmini_out" {
+		t.Fatalf("reasons = %v", reasons)
+	}
+	if _, ok := reasons["ERR_CURVE"]; ok {
+		t.Error("the failed view has a reason")
+	}
+
+	// the short codes now decode to the sentinels, the constant names still do
+	for reason, sentinel := range map[string]error{"Rno_profit": wsClient.ErrNoProfit, "Rmini_out": wsClient.ErrMiniOut, "ERR_NO_PROFIT": wsClient.ErrNoProfit} {
+		data := hexutil.MustDecode("0x08c379a0" + revertString(reason))
+		if revertErr := DecodeRevertData(data); revertErr.Reason != reason || !errors.Is(revertErr, sentinel) {
+			t.Errorf("revert %q = %v, want %v", reason, revertErr, sentinel)
+		}
+	}
+
+	if _, err := LoadRevertReasons(context.Background(), client, Uniswapv2ABIName, router, wsClient.BlockRefFromTag(wsClient.BlockLatest)); err == nil {
+		t.Error("loaded the reasons of a pair")
+	}
+	if _, err := LoadRevertReasons(context.Background(), client, "noSuchContract", router, wsClient.BlockRefFromTag(wsClient.BlockLatest)); err == nil {
+		t.Error("loaded the reasons of an unknown ABI")
+	}
+}
@@ -1,16 +1,24 @@
 package helper
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	wsClient "github.com/0xKhennati/wsclient"
 )
 
-// BuildRouterCallData creates properly wrapped call data for any routerMoudle function
+// BuildRouterCallData creates properly wrapped call data for any routerMoudle function, pairs
+// passed as PairInfoInterface or []PairInfoInterface are encoded with Brands()
 func BuildRouterCallData(functionName string, args ...interface{}) (hexutil.Bytes, error) {
-	data, err := routerMoudleABI.Pack(functionName, args...)
+	args, err := encodePairArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	data, err := packABI(RouterMoudleABIName, functionName, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to pack %s: %w", functionName, err)
 	}
@@ -21,42 +29,42 @@ func BuildRouterCallData(functionName string, args ...interface{}) (hexutil.Byte
 
 // Build_router_FlashSwapWithLoan_tx builds the call data for the FlashSwapWithLoan_tx function
 func Build_router_FlashSwapWithLoan_tx(balanceCheck *BalanceCheck, loanPool *LoanPool, tokenIn common.Address, amountIn *big.Int, pairs ...PairInfoInterface) (hexutil.Bytes, error) {
-	return BuildRouterCallData("FlashSwapWithLoan_tx", true, *balanceCheck, *loanPool, tokenIn, amountIn, newPairInfoList(pairs...))
+	return BuildRouterCallData("FlashSwapWithLoan_tx", true, *balanceCheck, *loanPool, tokenIn, amountIn, pairs)
 }
 
 // Build_router_FlashSwap_tx builds the call data for the FlashSwap_tx function
 func Build_router_FlashSwap_tx(balanceCheck *BalanceCheck, tokenIn common.Address, amountIn *big.Int, pairs ...PairInfoInterface) (hexutil.Bytes, error) {
-	return BuildRouterCallData("FlashSwap_tx", true, *balanceCheck, tokenIn, amountIn, newPairInfoList(pairs...))
+	return BuildRouterCallData("FlashSwap_tx", true, *balanceCheck, tokenIn, amountIn, pairs)
 }
 
 // Build_router_SimulateFlashSwapWithLoan builds the call data for the simulateFlashSwapWithLoan function
 func Build_router_SimulateFlashSwapWithLoan(loanPool *LoanPool, tokenIn common.Address, amountIn *big.Int, pairs []PairInfoInterface, maticPairs []PairInfoInterface) (hexutil.Bytes, error) {
-	return BuildRouterCallData("simulateFlashSwapWithLoan", true, *loanPool, tokenIn, amountIn, newPairInfoList(pairs...), newPairInfoList(maticPairs...))
+	return BuildRouterCallData("simulateFlashSwapWithLoan", true, *loanPool, tokenIn, amountIn, pairs, maticPairs)
 }
 
 // Build_router_AtlasFlashSwapWithLoan builds the call data for the atlasFlashSwapWithLoan function
 func Build_router_AtlasFlashSwapWithLoan(loanPool *LoanPool, tokenIn common.Address, amountIn *big.Int, bidAmount *big.Int, pairs []PairInfoInterface, maticPairs []PairInfoInterface) (hexutil.Bytes, error) {
-	return BuildRouterCallData("atlasFlashSwapWithLoan", false, *loanPool, tokenIn, amountIn, bidAmount, newPairInfoList(pairs...), newPairInfoList(maticPairs...))
+	return BuildRouterCallData("atlasFlashSwapWithLoan", false, *loanPool, tokenIn, amountIn, bidAmount, pairs, maticPairs)
 }
 
 // Build_router_StartFlashSwapWithLoan builds the call data for the startFlashSwapWithLoan function
 func Build_router_StartFlashSwapWithLoan(loanPool *LoanPool, tokenIn common.Address, amountIn *big.Int, pairs ...PairInfoInterface) (hexutil.Bytes, error) {
-	return BuildRouterCallData("startFlashSwapWithLoan", true, *loanPool, tokenIn, amountIn, newPairInfoList(pairs...))
+	return BuildRouterCallData("startFlashSwapWithLoan", true, *loanPool, tokenIn, amountIn, pairs)
 }
 
 // Build_router_SimulateFlashSwap builds the call data for the simulateFlashSwap function
 func Build_router_SimulateFlashSwap(tokenIn common.Address, amountIn *big.Int, pairs []PairInfoInterface, maticPairs []PairInfoInterface) (hexutil.Bytes, error) {
-	return BuildRouterCallData("simulateFlashSwap", true, tokenIn, amountIn, newPairInfoList(pairs...), newPairInfoList(maticPairs...))
+	return BuildRouterCallData("simulateFlashSwap", true, tokenIn, amountIn, pairs, maticPairs)
 }
 
 // Build_router_StartFlashSwap builds the call data for the startFlashSwap function
 func Build_router_StartFlashSwap(tokenIn common.Address, amountIn *big.Int, pairs ...PairInfoInterface) (hexutil.Bytes, error) {
-	return BuildRouterCallData("startFlashSwap", true, tokenIn, amountIn, newPairInfoList(pairs...))
+	return BuildRouterCallData("startFlashSwap", true, tokenIn, amountIn, pairs)
 }
 
 // Build_router_AtlasFlashSwap builds the call data for the atlasFlashSwap function
 func Build_router_AtlasFlashSwap(tokenIn common.Address, amountIn *big.Int, bidAmount *big.Int, pairs []PairInfoInterface, maticPairs []PairInfoInterface) (hexutil.Bytes, error) {
-	return BuildRouterCallData("atlasFlashSwap", false, tokenIn, amountIn, bidAmount, newPairInfoList(pairs...), newPairInfoList(maticPairs...))
+	return BuildRouterCallData("atlasFlashSwap", false, tokenIn, amountIn, bidAmount, pairs, maticPairs)
 }
 
 // Build_router_AtlasSolverCall builds the call data for the atlasSolverCall function
@@ -74,3 +82,11 @@ func Build_router_LoanCheck(loanPool *LoanPool, amountIn *big.Int) (hexutil.Byte
 	return BuildRouterCallData("LoanCheck", loanPool.Types, loanPool.Pool, loanPool.Token, amountIn)
 
 }
+
+// Optimize_router_access_list estimates a router transaction with and without the access list
+// generated by the node and returns the cheaper one; callData comes from Build_router_FlashSwap_tx,
+// Build_router_StartFlashSwapWithLoan or any other router builder
+func Optimize_router_access_list(ctx context.Context, c *wsClient.Client, from, router common.Address, callData hexutil.Bytes, block wsClient.BlockRef) (*wsClient.AccessListChoice, error) {
+	callMsg := wsClient.CallMsg{From: &from, To: &router, Data: callData}
+	return c.OptimizeAccessList(ctx, callMsg, block)
+}
@@ -0,0 +1,1284 @@
+// Code generated by internal/abigen from abi/routerMoudle.json. DO NOT EDIT.
+
+package helper
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// RouterMoudleABIName is the registry name of abi/routerMoudle.json
+const RouterMoudleABIName = "routerMoudle"
+
+// Pack_routerMoudle_DPPFlashLoanCall packs a call to DPPFlashLoanCall(address,uint256,uint256,bytes)
+func Pack_routerMoudle_DPPFlashLoanCall(sender common.Address, baseAmount *big.Int, quoteAmount *big.Int, data_ []byte) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "DPPFlashLoanCall(address,uint256,uint256,bytes)", sender, baseAmount, quoteAmount, data_)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Pack_routerMoudle_DSPFlashLoanCall packs a call to DSPFlashLoanCall(address,uint256,uint256,bytes)
+func Pack_routerMoudle_DSPFlashLoanCall(sender common.Address, baseAmount *big.Int, quoteAmount *big.Int, data_ []byte) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "DSPFlashLoanCall(address,uint256,uint256,bytes)", sender, baseAmount, quoteAmount, data_)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Pack_routerMoudle_DVMFlashLoanCall packs a call to DVMFlashLoanCall(address,uint256,uint256,bytes)
+func Pack_routerMoudle_DVMFlashLoanCall(sender common.Address, baseAmount *big.Int, quoteAmount *big.Int, data_ []byte) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "DVMFlashLoanCall(address,uint256,uint256,bytes)", sender, baseAmount, quoteAmount, data_)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Pack_routerMoudle_ERR_AAVE_DEPOSIT_FAILED packs a call to ERR_AAVE_DEPOSIT_FAILED()
+func Pack_routerMoudle_ERR_AAVE_DEPOSIT_FAILED() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_AAVE_DEPOSIT_FAILED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_AAVE_DEPOSIT_FAILED decodes the return data of ERR_AAVE_DEPOSIT_FAILED()
+func Unpack_routerMoudle_ERR_AAVE_DEPOSIT_FAILED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_AAVE_DEPOSIT_FAILED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_AAVE_DEPOSIT_FAILED()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_AAVE_WITHDRAWAL_FAILED packs a call to ERR_AAVE_WITHDRAWAL_FAILED()
+func Pack_routerMoudle_ERR_AAVE_WITHDRAWAL_FAILED() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_AAVE_WITHDRAWAL_FAILED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_AAVE_WITHDRAWAL_FAILED decodes the return data of ERR_AAVE_WITHDRAWAL_FAILED()
+func Unpack_routerMoudle_ERR_AAVE_WITHDRAWAL_FAILED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_AAVE_WITHDRAWAL_FAILED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_AAVE_WITHDRAWAL_FAILED()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_ADD_USERS_FAILED packs a call to ERR_ADD_USERS_FAILED()
+func Pack_routerMoudle_ERR_ADD_USERS_FAILED() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_ADD_USERS_FAILED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_ADD_USERS_FAILED decodes the return data of ERR_ADD_USERS_FAILED()
+func Unpack_routerMoudle_ERR_ADD_USERS_FAILED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_ADD_USERS_FAILED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_ADD_USERS_FAILED()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_ALGEBRA_GLOBAL_STATE packs a call to ERR_ALGEBRA_GLOBAL_STATE()
+func Pack_routerMoudle_ERR_ALGEBRA_GLOBAL_STATE() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_ALGEBRA_GLOBAL_STATE()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_ALGEBRA_GLOBAL_STATE decodes the return data of ERR_ALGEBRA_GLOBAL_STATE()
+func Unpack_routerMoudle_ERR_ALGEBRA_GLOBAL_STATE(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_ALGEBRA_GLOBAL_STATE()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_ALGEBRA_GLOBAL_STATE()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_CANT_PAY_BID_AMOUNT packs a call to ERR_CANT_PAY_BID_AMOUNT()
+func Pack_routerMoudle_ERR_CANT_PAY_BID_AMOUNT() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_CANT_PAY_BID_AMOUNT()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_CANT_PAY_BID_AMOUNT decodes the return data of ERR_CANT_PAY_BID_AMOUNT()
+func Unpack_routerMoudle_ERR_CANT_PAY_BID_AMOUNT(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_CANT_PAY_BID_AMOUNT()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_CANT_PAY_BID_AMOUNT()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_CAN_NOT_LOAN_FROM_THIS_PAIR packs a call to ERR_CAN_NOT_LOAN_FROM_THIS_PAIR()
+func Pack_routerMoudle_ERR_CAN_NOT_LOAN_FROM_THIS_PAIR() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_CAN_NOT_LOAN_FROM_THIS_PAIR()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_CAN_NOT_LOAN_FROM_THIS_PAIR decodes the return data of ERR_CAN_NOT_LOAN_FROM_THIS_PAIR()
+func Unpack_routerMoudle_ERR_CAN_NOT_LOAN_FROM_THIS_PAIR(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_CAN_NOT_LOAN_FROM_THIS_PAIR()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_CAN_NOT_LOAN_FROM_THIS_PAIR()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_CAN_NOT_WITHDRAW_FROM_AAVE packs a call to ERR_CAN_NOT_WITHDRAW_FROM_AAVE()
+func Pack_routerMoudle_ERR_CAN_NOT_WITHDRAW_FROM_AAVE() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_CAN_NOT_WITHDRAW_FROM_AAVE()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_CAN_NOT_WITHDRAW_FROM_AAVE decodes the return data of ERR_CAN_NOT_WITHDRAW_FROM_AAVE()
+func Unpack_routerMoudle_ERR_CAN_NOT_WITHDRAW_FROM_AAVE(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_CAN_NOT_WITHDRAW_FROM_AAVE()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_CAN_NOT_WITHDRAW_FROM_AAVE()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_CURVE packs a call to ERR_CURVE()
+func Pack_routerMoudle_ERR_CURVE() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_CURVE()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_CURVE decodes the return data of ERR_CURVE()
+func Unpack_routerMoudle_ERR_CURVE(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_CURVE()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_CURVE()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_FIRST_PAIR_NOT_SUPPORTED packs a call to ERR_FIRST_PAIR_NOT_SUPPORTED()
+func Pack_routerMoudle_ERR_FIRST_PAIR_NOT_SUPPORTED() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_FIRST_PAIR_NOT_SUPPORTED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_FIRST_PAIR_NOT_SUPPORTED decodes the return data of ERR_FIRST_PAIR_NOT_SUPPORTED()
+func Unpack_routerMoudle_ERR_FIRST_PAIR_NOT_SUPPORTED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_FIRST_PAIR_NOT_SUPPORTED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_FIRST_PAIR_NOT_SUPPORTED()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_FLASHLOAN_IFCURRENCY_FAILED packs a call to ERR_FLASHLOAN_IFCURRENCY_FAILED()
+func Pack_routerMoudle_ERR_FLASHLOAN_IFCURRENCY_FAILED() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_FLASHLOAN_IFCURRENCY_FAILED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_FLASHLOAN_IFCURRENCY_FAILED decodes the return data of ERR_FLASHLOAN_IFCURRENCY_FAILED()
+func Unpack_routerMoudle_ERR_FLASHLOAN_IFCURRENCY_FAILED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_FLASHLOAN_IFCURRENCY_FAILED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_FLASHLOAN_IFCURRENCY_FAILED()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_FLASHLOAN_WITHDRAWALL_FAILED packs a call to ERR_FLASHLOAN_WITHDRAWALL_FAILED()
+func Pack_routerMoudle_ERR_FLASHLOAN_WITHDRAWALL_FAILED() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_FLASHLOAN_WITHDRAWALL_FAILED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_FLASHLOAN_WITHDRAWALL_FAILED decodes the return data of ERR_FLASHLOAN_WITHDRAWALL_FAILED()
+func Unpack_routerMoudle_ERR_FLASHLOAN_WITHDRAWALL_FAILED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_FLASHLOAN_WITHDRAWALL_FAILED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_FLASHLOAN_WITHDRAWALL_FAILED()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_GET_RESERVES packs a call to ERR_GET_RESERVES()
+func Pack_routerMoudle_ERR_GET_RESERVES() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_GET_RESERVES()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_GET_RESERVES decodes the return data of ERR_GET_RESERVES()
+func Unpack_routerMoudle_ERR_GET_RESERVES(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_GET_RESERVES()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_GET_RESERVES()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_INSUFFICIENT_INPUT packs a call to ERR_INSUFFICIENT_INPUT()
+func Pack_routerMoudle_ERR_INSUFFICIENT_INPUT() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_INSUFFICIENT_INPUT()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_INSUFFICIENT_INPUT decodes the return data of ERR_INSUFFICIENT_INPUT()
+func Unpack_routerMoudle_ERR_INSUFFICIENT_INPUT(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_INSUFFICIENT_INPUT()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_INSUFFICIENT_INPUT()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_INSUFFICIENT_INPUT_AMOUNT packs a call to ERR_INSUFFICIENT_INPUT_AMOUNT()
+func Pack_routerMoudle_ERR_INSUFFICIENT_INPUT_AMOUNT() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_INSUFFICIENT_INPUT_AMOUNT()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_INSUFFICIENT_INPUT_AMOUNT decodes the return data of ERR_INSUFFICIENT_INPUT_AMOUNT()
+func Unpack_routerMoudle_ERR_INSUFFICIENT_INPUT_AMOUNT(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_INSUFFICIENT_INPUT_AMOUNT()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_INSUFFICIENT_INPUT_AMOUNT()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_INSUFFICIENT_LIQUIDITY packs a call to ERR_INSUFFICIENT_LIQUIDITY()
+func Pack_routerMoudle_ERR_INSUFFICIENT_LIQUIDITY() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_INSUFFICIENT_LIQUIDITY()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_INSUFFICIENT_LIQUIDITY decodes the return data of ERR_INSUFFICIENT_LIQUIDITY()
+func Unpack_routerMoudle_ERR_INSUFFICIENT_LIQUIDITY(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_INSUFFICIENT_LIQUIDITY()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_INSUFFICIENT_LIQUIDITY()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_INSUFFICIENT_OUTPUT packs a call to ERR_INSUFFICIENT_OUTPUT()
+func Pack_routerMoudle_ERR_INSUFFICIENT_OUTPUT() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_INSUFFICIENT_OUTPUT()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_INSUFFICIENT_OUTPUT decodes the return data of ERR_INSUFFICIENT_OUTPUT()
+func Unpack_routerMoudle_ERR_INSUFFICIENT_OUTPUT(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_INSUFFICIENT_OUTPUT()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_INSUFFICIENT_OUTPUT()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_LENGTH packs a call to ERR_LENGTH()
+func Pack_routerMoudle_ERR_LENGTH() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_LENGTH()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_LENGTH decodes the return data of ERR_LENGTH()
+func Unpack_routerMoudle_ERR_LENGTH(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_LENGTH()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_LENGTH()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_LOAN_POOL_NOT_SUPPORTED packs a call to ERR_LOAN_POOL_NOT_SUPPORTED()
+func Pack_routerMoudle_ERR_LOAN_POOL_NOT_SUPPORTED() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_LOAN_POOL_NOT_SUPPORTED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_LOAN_POOL_NOT_SUPPORTED decodes the return data of ERR_LOAN_POOL_NOT_SUPPORTED()
+func Unpack_routerMoudle_ERR_LOAN_POOL_NOT_SUPPORTED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_LOAN_POOL_NOT_SUPPORTED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_LOAN_POOL_NOT_SUPPORTED()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_MATIC_PAIRS packs a call to ERR_MATIC_PAIRS()
+func Pack_routerMoudle_ERR_MATIC_PAIRS() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_MATIC_PAIRS()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_MATIC_PAIRS decodes the return data of ERR_MATIC_PAIRS()
+func Unpack_routerMoudle_ERR_MATIC_PAIRS(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_MATIC_PAIRS()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_MATIC_PAIRS()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_MINI_OUT packs a call to ERR_MINI_OUT()
+func Pack_routerMoudle_ERR_MINI_OUT() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_MINI_OUT()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_MINI_OUT decodes the return data of ERR_MINI_OUT()
+func Unpack_routerMoudle_ERR_MINI_OUT(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_MINI_OUT()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_MINI_OUT()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_NOT_APPROVED packs a call to ERR_NOT_APPROVED()
+func Pack_routerMoudle_ERR_NOT_APPROVED() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_NOT_APPROVED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_NOT_APPROVED decodes the return data of ERR_NOT_APPROVED()
+func Unpack_routerMoudle_ERR_NOT_APPROVED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_NOT_APPROVED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_NOT_APPROVED()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_NOT_AUTHORIZED packs a call to ERR_NOT_AUTHORIZED()
+func Pack_routerMoudle_ERR_NOT_AUTHORIZED() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_NOT_AUTHORIZED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_NOT_AUTHORIZED decodes the return data of ERR_NOT_AUTHORIZED()
+func Unpack_routerMoudle_ERR_NOT_AUTHORIZED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_NOT_AUTHORIZED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_NOT_AUTHORIZED()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_NOT_OWNER packs a call to ERR_NOT_OWNER()
+func Pack_routerMoudle_ERR_NOT_OWNER() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_NOT_OWNER()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_NOT_OWNER decodes the return data of ERR_NOT_OWNER()
+func Unpack_routerMoudle_ERR_NOT_OWNER(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_NOT_OWNER()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_NOT_OWNER()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_NOT_ROUTER packs a call to ERR_NOT_ROUTER()
+func Pack_routerMoudle_ERR_NOT_ROUTER() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_NOT_ROUTER()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_NOT_ROUTER decodes the return data of ERR_NOT_ROUTER()
+func Unpack_routerMoudle_ERR_NOT_ROUTER(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_NOT_ROUTER()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_NOT_ROUTER()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_NOT_USER packs a call to ERR_NOT_USER()
+func Pack_routerMoudle_ERR_NOT_USER() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_NOT_USER()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_NOT_USER decodes the return data of ERR_NOT_USER()
+func Unpack_routerMoudle_ERR_NOT_USER(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_NOT_USER()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_NOT_USER()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_NO_PROFIT packs a call to ERR_NO_PROFIT()
+func Pack_routerMoudle_ERR_NO_PROFIT() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_NO_PROFIT()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_NO_PROFIT decodes the return data of ERR_NO_PROFIT()
+func Unpack_routerMoudle_ERR_NO_PROFIT(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_NO_PROFIT()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_NO_PROFIT()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_PAIR_NOT_SUPPORTED packs a call to ERR_PAIR_NOT_SUPPORTED()
+func Pack_routerMoudle_ERR_PAIR_NOT_SUPPORTED() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_PAIR_NOT_SUPPORTED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_PAIR_NOT_SUPPORTED decodes the return data of ERR_PAIR_NOT_SUPPORTED()
+func Unpack_routerMoudle_ERR_PAIR_NOT_SUPPORTED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_PAIR_NOT_SUPPORTED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_PAIR_NOT_SUPPORTED()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_ROUTER_NOT_DEPLOYED packs a call to ERR_ROUTER_NOT_DEPLOYED()
+func Pack_routerMoudle_ERR_ROUTER_NOT_DEPLOYED() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_ROUTER_NOT_DEPLOYED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_ROUTER_NOT_DEPLOYED decodes the return data of ERR_ROUTER_NOT_DEPLOYED()
+func Unpack_routerMoudle_ERR_ROUTER_NOT_DEPLOYED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_ROUTER_NOT_DEPLOYED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_ROUTER_NOT_DEPLOYED()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_SOLVER_CALL_UNSUCCESSFUL packs a call to ERR_SOLVER_CALL_UNSUCCESSFUL()
+func Pack_routerMoudle_ERR_SOLVER_CALL_UNSUCCESSFUL() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_SOLVER_CALL_UNSUCCESSFUL()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_SOLVER_CALL_UNSUCCESSFUL decodes the return data of ERR_SOLVER_CALL_UNSUCCESSFUL()
+func Unpack_routerMoudle_ERR_SOLVER_CALL_UNSUCCESSFUL(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_SOLVER_CALL_UNSUCCESSFUL()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_SOLVER_CALL_UNSUCCESSFUL()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_TOKEN_NOT_FOUND_IN_CURVE_POOL packs a call to ERR_TOKEN_NOT_FOUND_IN_CURVE_POOL()
+func Pack_routerMoudle_ERR_TOKEN_NOT_FOUND_IN_CURVE_POOL() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_TOKEN_NOT_FOUND_IN_CURVE_POOL()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_TOKEN_NOT_FOUND_IN_CURVE_POOL decodes the return data of ERR_TOKEN_NOT_FOUND_IN_CURVE_POOL()
+func Unpack_routerMoudle_ERR_TOKEN_NOT_FOUND_IN_CURVE_POOL(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_TOKEN_NOT_FOUND_IN_CURVE_POOL()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_TOKEN_NOT_FOUND_IN_CURVE_POOL()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_TRANSFER_FROM packs a call to ERR_TRANSFER_FROM()
+func Pack_routerMoudle_ERR_TRANSFER_FROM() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_TRANSFER_FROM()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_TRANSFER_FROM decodes the return data of ERR_TRANSFER_FROM()
+func Unpack_routerMoudle_ERR_TRANSFER_FROM(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_TRANSFER_FROM()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_TRANSFER_FROM()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_TRANSFER_HELPER_APPROVE_FAILED packs a call to ERR_TRANSFER_HELPER_APPROVE_FAILED()
+func Pack_routerMoudle_ERR_TRANSFER_HELPER_APPROVE_FAILED() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_TRANSFER_HELPER_APPROVE_FAILED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_TRANSFER_HELPER_APPROVE_FAILED decodes the return data of ERR_TRANSFER_HELPER_APPROVE_FAILED()
+func Unpack_routerMoudle_ERR_TRANSFER_HELPER_APPROVE_FAILED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_TRANSFER_HELPER_APPROVE_FAILED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_TRANSFER_HELPER_APPROVE_FAILED()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_TRANSFER_HELPER_FAILED packs a call to ERR_TRANSFER_HELPER_FAILED()
+func Pack_routerMoudle_ERR_TRANSFER_HELPER_FAILED() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_TRANSFER_HELPER_FAILED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_TRANSFER_HELPER_FAILED decodes the return data of ERR_TRANSFER_HELPER_FAILED()
+func Unpack_routerMoudle_ERR_TRANSFER_HELPER_FAILED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_TRANSFER_HELPER_FAILED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_TRANSFER_HELPER_FAILED()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_TRANSFER_HELPER_FROM_FAILED packs a call to ERR_TRANSFER_HELPER_FROM_FAILED()
+func Pack_routerMoudle_ERR_TRANSFER_HELPER_FROM_FAILED() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_TRANSFER_HELPER_FROM_FAILED()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_TRANSFER_HELPER_FROM_FAILED decodes the return data of ERR_TRANSFER_HELPER_FROM_FAILED()
+func Unpack_routerMoudle_ERR_TRANSFER_HELPER_FROM_FAILED(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_TRANSFER_HELPER_FROM_FAILED()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_TRANSFER_HELPER_FROM_FAILED()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_WRONG_FLASHLOAN packs a call to ERR_WRONG_FLASHLOAN()
+func Pack_routerMoudle_ERR_WRONG_FLASHLOAN() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_WRONG_FLASHLOAN()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_WRONG_FLASHLOAN decodes the return data of ERR_WRONG_FLASHLOAN()
+func Unpack_routerMoudle_ERR_WRONG_FLASHLOAN(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_WRONG_FLASHLOAN()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_WRONG_FLASHLOAN()", values, 0)
+}
+
+// Pack_routerMoudle_ERR_WRONG_ROUTER packs a call to ERR_WRONG_ROUTER()
+func Pack_routerMoudle_ERR_WRONG_ROUTER() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ERR_WRONG_ROUTER()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_ERR_WRONG_ROUTER decodes the return data of ERR_WRONG_ROUTER()
+func Unpack_routerMoudle_ERR_WRONG_ROUTER(data []byte) (string, error) {
+	var result string
+	values, err := unpackABI(RouterMoudleABIName, "ERR_WRONG_ROUTER()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[string]("ERR_WRONG_ROUTER()", values, 0)
+}
+
+// Pack_routerMoudle_FlashSwapWithLoan_tx packs a call to FlashSwapWithLoan_tx(bool,(uint256,uint256,uint256,address,address,uint8),(address,address,uint8,bool),address,uint256,(address,address,address,uint32,uint8,bytes)[])
+func Pack_routerMoudle_FlashSwapWithLoan_tx(calculateAmountOut bool, balanceCheck BalanceCheck, loanPool LoanPool, tokenIn common.Address, amountIn *big.Int, pairs []PairInfo) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "FlashSwapWithLoan_tx(bool,(uint256,uint256,uint256,address,address,uint8),(address,address,uint8,bool),address,uint256,(address,address,address,uint32,uint8,bytes)[])", calculateAmountOut, balanceCheck, loanPool, tokenIn, amountIn, pairs)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_FlashSwapWithLoan_tx decodes the return data of FlashSwapWithLoan_tx(bool,(uint256,uint256,uint256,address,address,uint8),(address,address,uint8,bool),address,uint256,(address,address,address,uint32,uint8,bytes)[])
+func Unpack_routerMoudle_FlashSwapWithLoan_tx(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(RouterMoudleABIName, "FlashSwapWithLoan_tx(bool,(uint256,uint256,uint256,address,address,uint8),(address,address,uint8,bool),address,uint256,(address,address,address,uint32,uint8,bytes)[])", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("FlashSwapWithLoan_tx(bool,(uint256,uint256,uint256,address,address,uint8),(address,address,uint8,bool),address,uint256,(address,address,address,uint32,uint8,bytes)[])", values, 0)
+}
+
+// Pack_routerMoudle_FlashSwap_tx packs a call to FlashSwap_tx(bool,(uint256,uint256,uint256,address,address,uint8),address,uint256,(address,address,address,uint32,uint8,bytes)[])
+func Pack_routerMoudle_FlashSwap_tx(calculateAmountOut bool, balanceCheck BalanceCheck, tokenIn common.Address, amountIn *big.Int, pairs []PairInfo) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "FlashSwap_tx(bool,(uint256,uint256,uint256,address,address,uint8),address,uint256,(address,address,address,uint32,uint8,bytes)[])", calculateAmountOut, balanceCheck, tokenIn, amountIn, pairs)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_FlashSwap_tx decodes the return data of FlashSwap_tx(bool,(uint256,uint256,uint256,address,address,uint8),address,uint256,(address,address,address,uint32,uint8,bytes)[])
+func Unpack_routerMoudle_FlashSwap_tx(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(RouterMoudleABIName, "FlashSwap_tx(bool,(uint256,uint256,uint256,address,address,uint8),address,uint256,(address,address,address,uint32,uint8,bytes)[])", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("FlashSwap_tx(bool,(uint256,uint256,uint256,address,address,uint8),address,uint256,(address,address,address,uint32,uint8,bytes)[])", values, 0)
+}
+
+// Pack_routerMoudle_LoanCheck packs a call to LoanCheck(uint8,address,address,uint256)
+func Pack_routerMoudle_LoanCheck(types_ uint8, pool common.Address, tokenIn common.Address, amount *big.Int) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "LoanCheck(uint8,address,address,uint256)", types_, pool, tokenIn, amount)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Pack_routerMoudle_LoanCheck0 packs a call to LoanCheck((address,address,uint8,bool),uint256,address,(address,address,address,uint32,uint8,bytes)[])
+func Pack_routerMoudle_LoanCheck0(loanPool LoanPool, amountIn *big.Int, arg2 common.Address, pairs []PairInfo) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "LoanCheck((address,address,uint8,bool),uint256,address,(address,address,address,uint32,uint8,bytes)[])", loanPool, amountIn, arg2, pairs)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Pack_routerMoudle_UserCheck packs a call to UserCheck(address)
+func Pack_routerMoudle_UserCheck(userAddress common.Address) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "UserCheck(address)", userAddress)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_UserCheck decodes the return data of UserCheck(address)
+func Unpack_routerMoudle_UserCheck(data []byte) (bool, error) {
+	var result bool
+	values, err := unpackABI(RouterMoudleABIName, "UserCheck(address)", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[bool]("UserCheck(address)", values, 0)
+}
+
+// Pack_routerMoudle__checkOwner packs a call to _checkOwner(address)
+func Pack_routerMoudle__checkOwner(caller common.Address) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "_checkOwner(address)", caller)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Pack_routerMoudle_addUsers packs a call to addUsers(address[])
+func Pack_routerMoudle_addUsers(userAddresses []common.Address) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "addUsers(address[])", userAddresses)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Pack_routerMoudle_algebraSwapCallback packs a call to algebraSwapCallback(int256,int256,bytes)
+func Pack_routerMoudle_algebraSwapCallback(amount0Delta *big.Int, amount1Delta *big.Int, data_ []byte) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "algebraSwapCallback(int256,int256,bytes)", amount0Delta, amount1Delta, data_)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Pack_routerMoudle_atlasFlashSwap packs a call to atlasFlashSwap(bool,address,uint256,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])
+func Pack_routerMoudle_atlasFlashSwap(calculateAmountOut bool, tokenIn common.Address, amountIn *big.Int, bidAmount *big.Int, pairs []PairInfo, maticPairs []PairInfo) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "atlasFlashSwap(bool,address,uint256,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])", calculateAmountOut, tokenIn, amountIn, bidAmount, pairs, maticPairs)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_atlasFlashSwap decodes the return data of atlasFlashSwap(bool,address,uint256,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])
+func Unpack_routerMoudle_atlasFlashSwap(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(RouterMoudleABIName, "atlasFlashSwap(bool,address,uint256,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("atlasFlashSwap(bool,address,uint256,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])", values, 0)
+}
+
+// Pack_routerMoudle_atlasFlashSwapWithLoan packs a call to atlasFlashSwapWithLoan(bool,(address,address,uint8,bool),address,uint256,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])
+func Pack_routerMoudle_atlasFlashSwapWithLoan(calculateAmountOut bool, loanPool LoanPool, tokenIn common.Address, amountIn *big.Int, bidAmount *big.Int, pairs []PairInfo, maticPairs []PairInfo) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "atlasFlashSwapWithLoan(bool,(address,address,uint8,bool),address,uint256,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])", calculateAmountOut, loanPool, tokenIn, amountIn, bidAmount, pairs, maticPairs)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_atlasFlashSwapWithLoan decodes the return data of atlasFlashSwapWithLoan(bool,(address,address,uint8,bool),address,uint256,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])
+func Unpack_routerMoudle_atlasFlashSwapWithLoan(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(RouterMoudleABIName, "atlasFlashSwapWithLoan(bool,(address,address,uint8,bool),address,uint256,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("atlasFlashSwapWithLoan(bool,(address,address,uint8,bool),address,uint256,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])", values, 0)
+}
+
+// Pack_routerMoudle_atlasSolverCall packs a call to atlasSolverCall(address,address,address,uint256,bytes,bytes)
+func Pack_routerMoudle_atlasSolverCall(solverOpFrom common.Address, executionEnvironment common.Address, bidToken common.Address, bidAmount *big.Int, solverOpData []byte, arg5 []byte) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "atlasSolverCall(address,address,address,uint256,bytes,bytes)", solverOpFrom, executionEnvironment, bidToken, bidAmount, solverOpData, arg5)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Pack_routerMoudle_atlasSolverCallSimulation packs a call to atlasSolverCallSimulation(uint256,bytes)
+func Pack_routerMoudle_atlasSolverCallSimulation(bidAmount *big.Int, solverOpData []byte) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "atlasSolverCallSimulation(uint256,bytes)", bidAmount, solverOpData)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// RouterMoudleAtlasSolverCallSimulationOutput holds the return values of atlasSolverCallSimulation(uint256,bytes)
+type RouterMoudleAtlasSolverCallSimulationOutput struct {
+	Gasused   *big.Int
+	MyProfite *big.Int
+}
+
+// Unpack_routerMoudle_atlasSolverCallSimulation decodes the return data of atlasSolverCallSimulation(uint256,bytes)
+func Unpack_routerMoudle_atlasSolverCallSimulation(data []byte) (RouterMoudleAtlasSolverCallSimulationOutput, error) {
+	var result RouterMoudleAtlasSolverCallSimulationOutput
+	values, err := unpackABI(RouterMoudleABIName, "atlasSolverCallSimulation(uint256,bytes)", data, 2)
+	if err != nil {
+		return result, err
+	}
+	if result.Gasused, err = convertOutput[*big.Int]("atlasSolverCallSimulation(uint256,bytes)", values, 0); err != nil {
+		return result, err
+	}
+	if result.MyProfite, err = convertOutput[*big.Int]("atlasSolverCallSimulation(uint256,bytes)", values, 1); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// Pack_routerMoudle_balance packs a call to balance(address)
+func Pack_routerMoudle_balance(token common.Address) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "balance(address)", token)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_balance decodes the return data of balance(address)
+func Unpack_routerMoudle_balance(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(RouterMoudleABIName, "balance(address)", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("balance(address)", values, 0)
+}
+
+// Pack_routerMoudle_customOwner packs a call to customOwner()
+func Pack_routerMoudle_customOwner() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "customOwner()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_customOwner decodes the return data of customOwner()
+func Unpack_routerMoudle_customOwner(data []byte) (common.Address, error) {
+	var result common.Address
+	values, err := unpackABI(RouterMoudleABIName, "customOwner()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[common.Address]("customOwner()", values, 0)
+}
+
+// Pack_routerMoudle_deployerContract packs a call to deployerContract()
+func Pack_routerMoudle_deployerContract() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "deployerContract()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_deployerContract decodes the return data of deployerContract()
+func Unpack_routerMoudle_deployerContract(data []byte) (common.Address, error) {
+	var result common.Address
+	values, err := unpackABI(RouterMoudleABIName, "deployerContract()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[common.Address]("deployerContract()", values, 0)
+}
+
+// Pack_routerMoudle_flashLoanModule packs a call to flashLoanModule()
+func Pack_routerMoudle_flashLoanModule() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "flashLoanModule()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_flashLoanModule decodes the return data of flashLoanModule()
+func Unpack_routerMoudle_flashLoanModule(data []byte) (common.Address, error) {
+	var result common.Address
+	values, err := unpackABI(RouterMoudleABIName, "flashLoanModule()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[common.Address]("flashLoanModule()", values, 0)
+}
+
+// Pack_routerMoudle_flashLoanModuleIfCurrencyStuck packs a call to flashLoanModuleIfCurrencyStuck(address)
+func Pack_routerMoudle_flashLoanModuleIfCurrencyStuck(to common.Address) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "flashLoanModuleIfCurrencyStuck(address)", to)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Pack_routerMoudle_flashLoanModuleWithdrawAll packs a call to flashLoanModuleWithdrawAll(address[])
+func Pack_routerMoudle_flashLoanModuleWithdrawAll(tokens []common.Address) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "flashLoanModuleWithdrawAll(address[])", tokens)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Pack_routerMoudle_ifCurrencyStuck packs a call to ifCurrencyStuck(address)
+func Pack_routerMoudle_ifCurrencyStuck(to common.Address) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "ifCurrencyStuck(address)", to)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Pack_routerMoudle_isUser packs a call to isUser(address)
+func Pack_routerMoudle_isUser(user common.Address) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "isUser(address)", user)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_isUser decodes the return data of isUser(address)
+func Unpack_routerMoudle_isUser(data []byte) (bool, error) {
+	var result bool
+	values, err := unpackABI(RouterMoudleABIName, "isUser(address)", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[bool]("isUser(address)", values, 0)
+}
+
+// Pack_routerMoudle_owner packs a call to owner()
+func Pack_routerMoudle_owner() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "owner()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_owner decodes the return data of owner()
+func Unpack_routerMoudle_owner(data []byte) (common.Address, error) {
+	var result common.Address
+	values, err := unpackABI(RouterMoudleABIName, "owner()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[common.Address]("owner()", values, 0)
+}
+
+// Pack_routerMoudle_routersAddress packs a call to routersAddress(uint24)
+func Pack_routerMoudle_routersAddress(arg0 *big.Int) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "routersAddress(uint24)", arg0)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_routersAddress decodes the return data of routersAddress(uint24)
+func Unpack_routerMoudle_routersAddress(data []byte) (common.Address, error) {
+	var result common.Address
+	values, err := unpackABI(RouterMoudleABIName, "routersAddress(uint24)", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[common.Address]("routersAddress(uint24)", values, 0)
+}
+
+// Pack_routerMoudle_simulateFlashSwap packs a call to simulateFlashSwap(bool,address,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])
+func Pack_routerMoudle_simulateFlashSwap(calculateAmountOut bool, tokenIn common.Address, amountIn *big.Int, pairs []PairInfo, maticPairs []PairInfo) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "simulateFlashSwap(bool,address,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])", calculateAmountOut, tokenIn, amountIn, pairs, maticPairs)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// RouterMoudleSimulateFlashSwapOutput holds the return values of simulateFlashSwap(bool,address,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])
+type RouterMoudleSimulateFlashSwapOutput struct {
+	Profit      *big.Int
+	Gasused     *big.Int
+	Profitmatic *big.Int
+}
+
+// Unpack_routerMoudle_simulateFlashSwap decodes the return data of simulateFlashSwap(bool,address,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])
+func Unpack_routerMoudle_simulateFlashSwap(data []byte) (RouterMoudleSimulateFlashSwapOutput, error) {
+	var result RouterMoudleSimulateFlashSwapOutput
+	values, err := unpackABI(RouterMoudleABIName, "simulateFlashSwap(bool,address,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])", data, 3)
+	if err != nil {
+		return result, err
+	}
+	if result.Profit, err = convertOutput[*big.Int]("simulateFlashSwap(bool,address,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])", values, 0); err != nil {
+		return result, err
+	}
+	if result.Gasused, err = convertOutput[*big.Int]("simulateFlashSwap(bool,address,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])", values, 1); err != nil {
+		return result, err
+	}
+	if result.Profitmatic, err = convertOutput[*big.Int]("simulateFlashSwap(bool,address,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])", values, 2); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// Pack_routerMoudle_simulateFlashSwapWithLoan packs a call to simulateFlashSwapWithLoan(bool,(address,address,uint8,bool),address,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])
+func Pack_routerMoudle_simulateFlashSwapWithLoan(calculateAmountOut bool, loanPool LoanPool, tokenIn common.Address, amountIn *big.Int, pairs []PairInfo, maticPairs []PairInfo) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "simulateFlashSwapWithLoan(bool,(address,address,uint8,bool),address,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])", calculateAmountOut, loanPool, tokenIn, amountIn, pairs, maticPairs)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// RouterMoudleSimulateFlashSwapWithLoanOutput holds the return values of simulateFlashSwapWithLoan(bool,(address,address,uint8,bool),address,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])
+type RouterMoudleSimulateFlashSwapWithLoanOutput struct {
+	Profit      *big.Int
+	Gasused     *big.Int
+	Profitmatic *big.Int
+}
+
+// Unpack_routerMoudle_simulateFlashSwapWithLoan decodes the return data of simulateFlashSwapWithLoan(bool,(address,address,uint8,bool),address,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])
+func Unpack_routerMoudle_simulateFlashSwapWithLoan(data []byte) (RouterMoudleSimulateFlashSwapWithLoanOutput, error) {
+	var result RouterMoudleSimulateFlashSwapWithLoanOutput
+	values, err := unpackABI(RouterMoudleABIName, "simulateFlashSwapWithLoan(bool,(address,address,uint8,bool),address,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])", data, 3)
+	if err != nil {
+		return result, err
+	}
+	if result.Profit, err = convertOutput[*big.Int]("simulateFlashSwapWithLoan(bool,(address,address,uint8,bool),address,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])", values, 0); err != nil {
+		return result, err
+	}
+	if result.Gasused, err = convertOutput[*big.Int]("simulateFlashSwapWithLoan(bool,(address,address,uint8,bool),address,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])", values, 1); err != nil {
+		return result, err
+	}
+	if result.Profitmatic, err = convertOutput[*big.Int]("simulateFlashSwapWithLoan(bool,(address,address,uint8,bool),address,uint256,(address,address,address,uint32,uint8,bytes)[],(address,address,address,uint32,uint8,bytes)[])", values, 2); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// Pack_routerMoudle_smardexSwapCallback packs a call to smardexSwapCallback(int256,int256,bytes)
+func Pack_routerMoudle_smardexSwapCallback(amount0Delta *big.Int, amount1Delta *big.Int, data_ []byte) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "smardexSwapCallback(int256,int256,bytes)", amount0Delta, amount1Delta, data_)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Pack_routerMoudle_startFlashSwap packs a call to startFlashSwap(bool,address,uint256,(address,address,address,uint32,uint8,bytes)[])
+func Pack_routerMoudle_startFlashSwap(calculateAmountOut bool, tokenIn common.Address, amountIn *big.Int, pairs []PairInfo) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "startFlashSwap(bool,address,uint256,(address,address,address,uint32,uint8,bytes)[])", calculateAmountOut, tokenIn, amountIn, pairs)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_startFlashSwap decodes the return data of startFlashSwap(bool,address,uint256,(address,address,address,uint32,uint8,bytes)[])
+func Unpack_routerMoudle_startFlashSwap(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(RouterMoudleABIName, "startFlashSwap(bool,address,uint256,(address,address,address,uint32,uint8,bytes)[])", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("startFlashSwap(bool,address,uint256,(address,address,address,uint32,uint8,bytes)[])", values, 0)
+}
+
+// Pack_routerMoudle_startFlashSwapWithLoan packs a call to startFlashSwapWithLoan(bool,(address,address,uint8,bool),address,uint256,(address,address,address,uint32,uint8,bytes)[])
+func Pack_routerMoudle_startFlashSwapWithLoan(calculateAmountOut bool, loanPool LoanPool, tokenIn common.Address, amountIn *big.Int, pairs []PairInfo) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "startFlashSwapWithLoan(bool,(address,address,uint8,bool),address,uint256,(address,address,address,uint32,uint8,bytes)[])", calculateAmountOut, loanPool, tokenIn, amountIn, pairs)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_startFlashSwapWithLoan decodes the return data of startFlashSwapWithLoan(bool,(address,address,uint8,bool),address,uint256,(address,address,address,uint32,uint8,bytes)[])
+func Unpack_routerMoudle_startFlashSwapWithLoan(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(RouterMoudleABIName, "startFlashSwapWithLoan(bool,(address,address,uint8,bool),address,uint256,(address,address,address,uint32,uint8,bytes)[])", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("startFlashSwapWithLoan(bool,(address,address,uint8,bool),address,uint256,(address,address,address,uint32,uint8,bytes)[])", values, 0)
+}
+
+// Pack_routerMoudle_startFlashSwapWithLoan_TargetBlock packs a call to startFlashSwapWithLoan_TargetBlock(bool,(address,address,uint8,bool),address,uint256,uint256,(address,address,address,uint32,uint8,bytes)[])
+func Pack_routerMoudle_startFlashSwapWithLoan_TargetBlock(calculateAmountOut bool, loanPool LoanPool, tokenIn common.Address, amountIn *big.Int, lastDigitBlock *big.Int, pairs []PairInfo) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "startFlashSwapWithLoan_TargetBlock(bool,(address,address,uint8,bool),address,uint256,uint256,(address,address,address,uint32,uint8,bytes)[])", calculateAmountOut, loanPool, tokenIn, amountIn, lastDigitBlock, pairs)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_startFlashSwapWithLoan_TargetBlock decodes the return data of startFlashSwapWithLoan_TargetBlock(bool,(address,address,uint8,bool),address,uint256,uint256,(address,address,address,uint32,uint8,bytes)[])
+func Unpack_routerMoudle_startFlashSwapWithLoan_TargetBlock(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(RouterMoudleABIName, "startFlashSwapWithLoan_TargetBlock(bool,(address,address,uint8,bool),address,uint256,uint256,(address,address,address,uint32,uint8,bytes)[])", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("startFlashSwapWithLoan_TargetBlock(bool,(address,address,uint8,bool),address,uint256,uint256,(address,address,address,uint32,uint8,bytes)[])", values, 0)
+}
+
+// Pack_routerMoudle_startFlashSwap_TargetBlock packs a call to startFlashSwap_TargetBlock(bool,address,uint256,uint256,(address,address,address,uint32,uint8,bytes)[])
+func Pack_routerMoudle_startFlashSwap_TargetBlock(calculateAmountOut bool, tokenIn common.Address, amountIn *big.Int, lastDigitBlock *big.Int, pairs []PairInfo) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "startFlashSwap_TargetBlock(bool,address,uint256,uint256,(address,address,address,uint32,uint8,bytes)[])", calculateAmountOut, tokenIn, amountIn, lastDigitBlock, pairs)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_startFlashSwap_TargetBlock decodes the return data of startFlashSwap_TargetBlock(bool,address,uint256,uint256,(address,address,address,uint32,uint8,bytes)[])
+func Unpack_routerMoudle_startFlashSwap_TargetBlock(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(RouterMoudleABIName, "startFlashSwap_TargetBlock(bool,address,uint256,uint256,(address,address,address,uint32,uint8,bytes)[])", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("startFlashSwap_TargetBlock(bool,address,uint256,uint256,(address,address,address,uint32,uint8,bytes)[])", values, 0)
+}
+
+// Pack_routerMoudle_swapCallback packs a call to swapCallback(int256,int256,bytes)
+func Pack_routerMoudle_swapCallback(deltaQty0 *big.Int, deltaQty1 *big.Int, data_ []byte) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "swapCallback(int256,int256,bytes)", deltaQty0, deltaQty1, data_)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Pack_routerMoudle_swapContract packs a call to swapContract()
+func Pack_routerMoudle_swapContract() (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "swapContract()")
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_swapContract decodes the return data of swapContract()
+func Unpack_routerMoudle_swapContract(data []byte) (common.Address, error) {
+	var result common.Address
+	values, err := unpackABI(RouterMoudleABIName, "swapContract()", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[common.Address]("swapContract()", values, 0)
+}
+
+// Pack_routerMoudle_uniswapV3SwapCallback packs a call to uniswapV3SwapCallback(int256,int256,bytes)
+func Pack_routerMoudle_uniswapV3SwapCallback(amount0Delta *big.Int, amount1Delta *big.Int, data_ []byte) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "uniswapV3SwapCallback(int256,int256,bytes)", amount0Delta, amount1Delta, data_)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Pack_routerMoudle_users packs a call to users(address)
+func Pack_routerMoudle_users(arg0 common.Address) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "users(address)", arg0)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_routerMoudle_users decodes the return data of users(address)
+func Unpack_routerMoudle_users(data []byte) (bool, error) {
+	var result bool
+	values, err := unpackABI(RouterMoudleABIName, "users(address)", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[bool]("users(address)", values, 0)
+}
+
+// Pack_routerMoudle_withdrawAll packs a call to withdrawAll(address[])
+func Pack_routerMoudle_withdrawAll(tokens []common.Address) (hexutil.Bytes, error) {
+	data, err := packABI(RouterMoudleABIName, "withdrawAll(address[])", tokens)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
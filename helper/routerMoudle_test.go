@@ -0,0 +1,78 @@
+package helper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	wsClient "github.com/0xKhennati/wsclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// dialEstimator starts a node generating list for every call and estimating the
+// router calls at without gas, or with gas when they carry the access list
+func dialEstimator(t *testing.T, from, router common.Address, callData []byte, list types.AccessList, without, with uint64) *wsClient.Client {
+	return dialNode(t, func(method string, msg wsClient.CallMsg) (string, error) {
+		switch {
+		case msg.From == nil || *msg.From != from || msg.To == nil || *msg.To != router || !bytes.Equal(msg.Data, callData):
+			return "", errors.New("execution reverted: ERR_NOT_ROUTER")
+		case method == "eth_createAccessList":
+			result, err := json.Marshal(wsClient.AccessListResult{AccessList: list, GasUsed: 50000})
+			return string(result), err
+		case msg.AccessList != nil:
+			return fmt.Sprintf(`"0x%x"`, with), nil
+		}
+		return fmt.Sprintf(`"0x%x"`, without), nil
+	})
+}
+
+func TestOptimizeRouterAccessList(t *testing.T) {
+	from := common.HexToAddress("0x7a16fF8270133F063aAb6C9977183D9e72835428")
+	router := common.HexToAddress("0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f")
+	callData, err := Build_router_AtlasSolverCallSimulation(big.NewInt(1e15), []byte{0x01})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pairs := types.AccessList{
+		{Address: common.HexToAddress("0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc"), StorageKeys: []common.Hash{common.HexToHash("0x08")}},
+		{Address: common.HexToAddress("0x0d4a11d5EEaaC28EC3F61d100daF4d40471f1852"), StorageKeys: []common.Hash{common.HexToHash("0x08")}},
+	}
+
+	tests := []struct {
+		name    string
+		list    types.AccessList
+		without uint64
+		with    uint64
+		keep    bool
+		gas     uint64
+	}{
+		// a route touching two cold pools saves on the first accesses
+		{"keep", pairs, 210000, 205600, true, 205600},
+		{"drop more expensive", pairs, 120000, 124600, false, 120000},
+		{"drop same cost", pairs, 120000, 120000, false, 120000},
+		{"drop empty list", nil, 120000, 0, false, 120000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := dialEstimator(t, from, router, callData, tt.list, tt.without, tt.with)
+			choice, err := Optimize_router_access_list(context.Background(), client, from, router, callData, wsClient.BlockRefFromTag(wsClient.BlockLatest))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if choice.UseAccessList != tt.keep || choice.Gas != tt.gas || choice.GasWithout != tt.without {
+				t.Errorf("choice = %+v", choice)
+			}
+			if got := choice.CallMsg.AccessList != nil; got != tt.keep {
+				t.Errorf("CallMsg carries the access list: %t, want %t", got, tt.keep)
+			}
+			if *choice.CallMsg.From != from || *choice.CallMsg.To != router || !bytes.Equal(choice.CallMsg.Data, callData) {
+				t.Errorf("CallMsg = %+v", choice.CallMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Code generated by internal/abigen from abi/*.json. DO NOT EDIT.
+
+package helper
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BalanceCheck represents the IStruct.balanceCheck struct
+type BalanceCheck struct {
+	Latest *big.Int
+	Target *big.Int
+	End    *big.Int
+	Pair   common.Address
+	Token  common.Address
+	Brand  uint8
+}
+
+// LoanPool represents the IStruct.loanPool struct
+type LoanPool struct {
+	Pool          common.Address
+	Token         common.Address
+	Types         uint8
+	IsWrappedAave bool
+}
+
+// PairInfo represents the IStruct.pairinfo struct
+type PairInfo struct {
+	PairAddr common.Address
+	Token0   common.Address
+	Token1   common.Address
+	Fee      uint32
+	Brand    uint8
+	Data     []byte
+}
+
+// ReserveConfigurationMap represents the DataTypes.ReserveConfigurationMap struct
+type ReserveConfigurationMap struct {
+	Data *big.Int
+}
+
+// ReserveData represents the DataTypes.ReserveData struct
+type ReserveData struct {
+	Configuration               ReserveConfigurationMap
+	LiquidityIndex              *big.Int
+	CurrentLiquidityRate        *big.Int
+	VariableBorrowIndex         *big.Int
+	CurrentVariableBorrowRate   *big.Int
+	CurrentStableBorrowRate     *big.Int
+	LastUpdateTimestamp         *big.Int
+	Id                          uint16
+	ATokenAddress               common.Address
+	StableDebtTokenAddress      common.Address
+	VariableDebtTokenAddress    common.Address
+	InterestRateStrategyAddress common.Address
+	AccruedToTreasury           *big.Int
+	Unbacked                    *big.Int
+	IsolationModeTotalDebt      *big.Int
+}
@@ -8,9 +8,14 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
-// BuildSwapV2CallData creates properly wrapped call data for any swapMoudle function
+// BuildSwapV2CallData creates properly wrapped call data for any swapMoudle function, pairs
+// passed as PairInfoInterface or []PairInfoInterface are encoded with Brands()
 func BuildSwapV2CallData(functionName string, args ...interface{}) (hexutil.Bytes, error) {
-	data, err := swapMoudleABI.Pack(functionName, args...)
+	args, err := encodePairArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	data, err := packABI(SwapMoudleABIName, functionName, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to pack %s: %w", functionName, err)
 	}
@@ -21,10 +26,10 @@ func BuildSwapV2CallData(functionName string, args ...interface{}) (hexutil.Byte
 
 // Build_swap_MultiSwap builds the call data for the multiSwap function
 func Build_swap_MultiSwap(tokenIn common.Address, amountIn, miniAmountOut, index *big.Int, pairs ...PairInfoInterface) (hexutil.Bytes, error) {
-	return BuildSwapV2CallData("multiSwap", tokenIn, amountIn, miniAmountOut, index, newPairInfoList(pairs...))
+	return BuildSwapV2CallData("multiSwap", tokenIn, amountIn, miniAmountOut, index, pairs)
 }
 
 // Build_swap_simulateSwapAllBalance builds the call data for the simulateSwapAllBalance function
 func Build_swap_simulateSwapAllBalance(tokenIn common.Address, pairs ...PairInfoInterface) (hexutil.Bytes, error) {
-	return BuildSwapV2CallData("simulateSwapAllBalance", tokenIn, newPairInfoList(pairs...))
+	return BuildSwapV2CallData("simulateSwapAllBalance", tokenIn, pairs)
 }
@@ -0,0 +1,52 @@
+package helper
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BuildUniswapV2CallData creates properly wrapped call data for any UniswapV2 pair function
+func BuildUniswapV2CallData(functionName string, args ...interface{}) (hexutil.Bytes, error) {
+	data, err := packABI(Uniswapv2ABIName, functionName, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s: %w", functionName, err)
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// BuildUniswapV2GetReservesCallData creates wrapped call data for getReserves
+func BuildUniswapV2GetReservesCallData() (hexutil.Bytes, error) {
+	return BuildUniswapV2CallData("getReserves")
+}
+
+// BuildUniswapV2Token0CallData creates wrapped call data for token0
+func BuildUniswapV2Token0CallData() (hexutil.Bytes, error) {
+	return BuildUniswapV2CallData("token0")
+}
+
+// BuildUniswapV2Token1CallData creates wrapped call data for token1
+func BuildUniswapV2Token1CallData() (hexutil.Bytes, error) {
+	return BuildUniswapV2CallData("token1")
+}
+
+// BuildUniswapV2SwapCallData creates wrapped call data for swap, data is passed to the
+// callee of a flash swap and empty otherwise
+func BuildUniswapV2SwapCallData(amount0Out, amount1Out *big.Int, to common.Address, data []byte) (hexutil.Bytes, error) {
+	return BuildUniswapV2CallData("swap", amount0Out, amount1Out, to, data)
+}
+
+// UniswapV2Reserves is the result of getReserves
+type UniswapV2Reserves = Uniswapv2GetReservesOutput
+
+// DecodeUniswapV2GetReserves decodes the return data of getReserves
+func DecodeUniswapV2GetReserves(data []byte) (UniswapV2Reserves, error) {
+	return Unpack_uniswapv2_getReserves(data)
+}
+
+// DecodeUniswapV2Token decodes the return data of token0 or token1
+func DecodeUniswapV2Token(data []byte) (common.Address, error) {
+	return Unpack_uniswapv2_token0(data)
+}
@@ -0,0 +1,76 @@
+package helper
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BuildUniswapV3CallData creates properly wrapped call data for any UniswapV3 pool function
+func BuildUniswapV3CallData(functionName string, args ...interface{}) (hexutil.Bytes, error) {
+	data, err := packABI(Uniswapv3ABIName, functionName, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s: %w", functionName, err)
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// BuildUniswapV3Slot0CallData creates wrapped call data for slot0
+func BuildUniswapV3Slot0CallData() (hexutil.Bytes, error) {
+	return BuildUniswapV3CallData("slot0")
+}
+
+// BuildUniswapV3LiquidityCallData creates wrapped call data for liquidity
+func BuildUniswapV3LiquidityCallData() (hexutil.Bytes, error) {
+	return BuildUniswapV3CallData("liquidity")
+}
+
+// BuildUniswapV3FeeCallData creates wrapped call data for fee
+func BuildUniswapV3FeeCallData() (hexutil.Bytes, error) {
+	return BuildUniswapV3CallData("fee")
+}
+
+// BuildUniswapV3Token0CallData creates wrapped call data for token0
+func BuildUniswapV3Token0CallData() (hexutil.Bytes, error) {
+	return BuildUniswapV3CallData("token0")
+}
+
+// BuildUniswapV3Token1CallData creates wrapped call data for token1
+func BuildUniswapV3Token1CallData() (hexutil.Bytes, error) {
+	return BuildUniswapV3CallData("token1")
+}
+
+// BuildUniswapV3TickSpacingCallData creates wrapped call data for tickSpacing
+func BuildUniswapV3TickSpacingCallData() (hexutil.Bytes, error) {
+	return BuildUniswapV3CallData("tickSpacing")
+}
+
+// BuildUniswapV3SwapCallData creates wrapped call data for swap, a positive
+// amountSpecified is an exact input and a negative one an exact output
+func BuildUniswapV3SwapCallData(recipient common.Address, zeroForOne bool, amountSpecified, sqrtPriceLimitX96 *big.Int, data []byte) (hexutil.Bytes, error) {
+	return BuildUniswapV3CallData("swap", recipient, zeroForOne, amountSpecified, sqrtPriceLimitX96, data)
+}
+
+// UniswapV3Slot0 is the result of slot0
+type UniswapV3Slot0 = Uniswapv3Slot0Output
+
+// DecodeUniswapV3Slot0 decodes the return data of slot0
+func DecodeUniswapV3Slot0(data []byte) (UniswapV3Slot0, error) {
+	return Unpack_uniswapv3_slot0(data)
+}
+
+// DecodeUniswapV3Liquidity decodes the return data of liquidity
+func DecodeUniswapV3Liquidity(data []byte) (*big.Int, error) {
+	return Unpack_uniswapv3_liquidity(data)
+}
+
+// DecodeUniswapV3Fee decodes the return data of fee, in hundredths of a bip
+func DecodeUniswapV3Fee(data []byte) (uint32, error) {
+	fee, err := Unpack_uniswapv3_fee(data)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(fee.Uint64()), nil
+}
@@ -0,0 +1,191 @@
+package helper
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// NativeCurrency is the currency of native ETH in Uniswap V4 pool keys
+var NativeCurrency = common.Address{}
+
+// ErrNoPoolKey is returned by PoolKeyOf for pairs that carry no pool key: they do not
+// implement UniswapV4Pair and GetData is not an encoded PoolKey
+var ErrNoPoolKey = errors.New("no pool key")
+
+const (
+	// DynamicFeeFlag is the fee of pool keys whose hooks set the fee
+	DynamicFeeFlag = 0x800000
+	// MaxTickSpacing and MinTickSpacing bound the tick spacing of a pool key
+	MaxTickSpacing = 32767
+	MinTickSpacing = 1
+)
+
+// PoolKey identifies a Uniswap V4 pool, as PoolKey of v4-core
+type PoolKey struct {
+	Currency0   common.Address
+	Currency1   common.Address
+	Fee         uint32 // in hundredths of a bip, or DynamicFeeFlag
+	TickSpacing int32
+	Hooks       common.Address
+}
+
+// NewPoolKey creates the key of the pool of tokenA and tokenB, in any order; use
+// NativeCurrency for native ETH
+func NewPoolKey(tokenA, tokenB common.Address, fee uint32, tickSpacing int32, hooks common.Address) (PoolKey, error) {
+	if bytes.Compare(tokenA[:], tokenB[:]) > 0 {
+		tokenA, tokenB = tokenB, tokenA
+	}
+	key := PoolKey{Currency0: tokenA, Currency1: tokenB, Fee: fee, TickSpacing: tickSpacing, Hooks: hooks}
+	return key, key.Validate()
+}
+
+// Validate checks the key as PoolManager.initialize does, except for the hook permissions
+func (k PoolKey) Validate() error {
+	if bytes.Compare(k.Currency0[:], k.Currency1[:]) >= 0 {
+		return fmt.Errorf("currencies %s and %s are not sorted", k.Currency0.Hex(), k.Currency1.Hex())
+	}
+	if k.TickSpacing < MinTickSpacing || k.TickSpacing > MaxTickSpacing {
+		return fmt.Errorf("tick spacing %d out of range", k.TickSpacing)
+	}
+	if k.IsDynamicFee() {
+		if k.Hookless() {
+			return fmt.Errorf("dynamic fee without hooks")
+		}
+		return nil
+	}
+	if k.Fee > 1_000_000 {
+		return fmt.Errorf("fee %d is above 100%%", k.Fee)
+	}
+	return nil
+}
+
+// IsDynamicFee reports whether the hooks of the pool set the fee
+func (k PoolKey) IsDynamicFee() bool {
+	return k.Fee == DynamicFeeFlag
+}
+
+// Hookless reports whether the pool has no hooks, its swaps follow the pool math alone
+func (k PoolKey) Hookless() bool {
+	return k.Hooks == common.Address{}
+}
+
+// poolKeyArguments is the ABI encoding of PoolKey
+func poolKeyArguments() (abi.Arguments, error) {
+	var arguments abi.Arguments
+	for _, name := range []string{"address", "address", "uint24", "int24", "address"} {
+		typ, err := abi.NewType(name, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, abi.Argument{Type: typ})
+	}
+	return arguments, nil
+}
+
+// Encode returns abi.encode(key), the PairInfo.Data of Uniswap V4 pairs
+func (k PoolKey) Encode() ([]byte, error) {
+	arguments, err := poolKeyArguments()
+	if err != nil {
+		return nil, err
+	}
+	return arguments.Pack(k.Currency0, k.Currency1, big.NewInt(int64(k.Fee)), big.NewInt(int64(k.TickSpacing)), k.Hooks)
+}
+
+// ID returns the pool id, keccak256(abi.encode(key))
+func (k PoolKey) ID() (common.Hash, error) {
+	encoded, err := k.Encode()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// DecodePoolKey decodes abi.encode(key)
+func DecodePoolKey(data []byte) (PoolKey, error) {
+	arguments, err := poolKeyArguments()
+	if err != nil {
+		return PoolKey{}, err
+	}
+	values, err := arguments.Unpack(data)
+	if err != nil {
+		return PoolKey{}, fmt.Errorf("failed to unpack pool key: %w", err)
+	}
+	key := PoolKey{
+		Currency0:   values[0].(common.Address),
+		Currency1:   values[1].(common.Address),
+		Fee:         uint32(values[2].(*big.Int).Uint64()),
+		TickSpacing: int32(values[3].(*big.Int).Int64()),
+		Hooks:       values[4].(common.Address),
+	}
+	return key, key.Validate()
+}
+
+// UniswapV4Pair is implemented by pairs that know the key of their Uniswap V4 pool
+type UniswapV4Pair interface {
+	GetPoolKey() PoolKey
+}
+
+// PoolKeyOf returns the pool key of a Uniswap V4 pair, from GetPoolKey or the pool key
+// encoded in GetData, ErrNoPoolKey when there is neither. The currencies must match
+// GetCurrency0 and GetCurrency1 when set.
+func PoolKeyOf(pair PairInfoInterface) (PoolKey, error) {
+	var key PoolKey
+	if v4, ok := pair.(UniswapV4Pair); ok {
+		key = v4.GetPoolKey()
+		if err := key.Validate(); err != nil {
+			return PoolKey{}, err
+		}
+	} else {
+		data, err := pairData(pair)
+		if err != nil {
+			return PoolKey{}, err
+		}
+		if len(data) == 0 {
+			return PoolKey{}, ErrNoPoolKey
+		}
+		if key, err = DecodePoolKey(data); err != nil {
+			return PoolKey{}, fmt.Errorf("%w: %v", ErrNoPoolKey, err)
+		}
+	}
+	if currency0 := pair.GetCurrency0(); currency0 != nil && *currency0 != key.Currency0 {
+		return PoolKey{}, fmt.Errorf("currency0 %s is not %s of the pool key", currency0.Hex(), key.Currency0.Hex())
+	}
+	if currency1 := pair.GetCurrency1(); currency1 != nil && *currency1 != key.Currency1 {
+		return PoolKey{}, fmt.Errorf("currency1 %s is not %s of the pool key", currency1.Hex(), key.Currency1.Hex())
+	}
+	return key, nil
+}
+
+// BuildUniswapV4CallData creates properly wrapped call data for any StateView function
+func BuildUniswapV4CallData(functionName string, args ...interface{}) (hexutil.Bytes, error) {
+	data, err := packABI(Uniswapv4ABIName, functionName, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s: %w", functionName, err)
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// BuildUniswapV4GetSlot0CallData creates wrapped call data for getSlot0 of pool id
+func BuildUniswapV4GetSlot0CallData(id common.Hash) (hexutil.Bytes, error) {
+	return Pack_uniswapv4_getSlot0(id)
+}
+
+// BuildUniswapV4GetLiquidityCallData creates wrapped call data for getLiquidity of pool id
+func BuildUniswapV4GetLiquidityCallData(id common.Hash) (hexutil.Bytes, error) {
+	return Pack_uniswapv4_getLiquidity(id)
+}
+
+// UniswapV4Slot0 is the price, tick and fees returned by StateView.getSlot0
+type UniswapV4Slot0 = Uniswapv4GetSlot0Output
+
+// DecodeUniswapV4Slot0 decodes the return data of getSlot0
+func DecodeUniswapV4Slot0(data []byte) (UniswapV4Slot0, error) {
+	return Unpack_uniswapv4_getSlot0(data)
+}
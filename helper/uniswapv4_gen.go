@@ -0,0 +1,192 @@
+// Code generated by internal/abigen from abi/uniswapv4.json. DO NOT EDIT.
+
+package helper
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Uniswapv4ABIName is the registry name of abi/uniswapv4.json
+const Uniswapv4ABIName = "uniswapv4"
+
+// Pack_uniswapv4_getLiquidity packs a call to getLiquidity(bytes32)
+func Pack_uniswapv4_getLiquidity(poolId [32]byte) (hexutil.Bytes, error) {
+	data, err := packABI(Uniswapv4ABIName, "getLiquidity(bytes32)", poolId)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_uniswapv4_getLiquidity decodes the return data of getLiquidity(bytes32)
+func Unpack_uniswapv4_getLiquidity(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(Uniswapv4ABIName, "getLiquidity(bytes32)", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("getLiquidity(bytes32)", values, 0)
+}
+
+// Pack_uniswapv4_getSlot0 packs a call to getSlot0(bytes32)
+func Pack_uniswapv4_getSlot0(poolId [32]byte) (hexutil.Bytes, error) {
+	data, err := packABI(Uniswapv4ABIName, "getSlot0(bytes32)", poolId)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Uniswapv4GetSlot0Output holds the return values of getSlot0(bytes32)
+type Uniswapv4GetSlot0Output struct {
+	SqrtPriceX96 *big.Int
+	Tick         *big.Int
+	ProtocolFee  *big.Int
+	LpFee        *big.Int
+}
+
+// Unpack_uniswapv4_getSlot0 decodes the return data of getSlot0(bytes32)
+func Unpack_uniswapv4_getSlot0(data []byte) (Uniswapv4GetSlot0Output, error) {
+	var result Uniswapv4GetSlot0Output
+	values, err := unpackABI(Uniswapv4ABIName, "getSlot0(bytes32)", data, 4)
+	if err != nil {
+		return result, err
+	}
+	if result.SqrtPriceX96, err = convertOutput[*big.Int]("getSlot0(bytes32)", values, 0); err != nil {
+		return result, err
+	}
+	if result.Tick, err = convertOutput[*big.Int]("getSlot0(bytes32)", values, 1); err != nil {
+		return result, err
+	}
+	if result.ProtocolFee, err = convertOutput[*big.Int]("getSlot0(bytes32)", values, 2); err != nil {
+		return result, err
+	}
+	if result.LpFee, err = convertOutput[*big.Int]("getSlot0(bytes32)", values, 3); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// Pack_uniswapv4_getTickBitmap packs a call to getTickBitmap(bytes32,int16)
+func Pack_uniswapv4_getTickBitmap(poolId [32]byte, tick int16) (hexutil.Bytes, error) {
+	data, err := packABI(Uniswapv4ABIName, "getTickBitmap(bytes32,int16)", poolId, tick)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Unpack_uniswapv4_getTickBitmap decodes the return data of getTickBitmap(bytes32,int16)
+func Unpack_uniswapv4_getTickBitmap(data []byte) (*big.Int, error) {
+	var result *big.Int
+	values, err := unpackABI(Uniswapv4ABIName, "getTickBitmap(bytes32,int16)", data, 1)
+	if err != nil {
+		return result, err
+	}
+	return convertOutput[*big.Int]("getTickBitmap(bytes32,int16)", values, 0)
+}
+
+// Pack_uniswapv4_getTickLiquidity packs a call to getTickLiquidity(bytes32,int24)
+func Pack_uniswapv4_getTickLiquidity(poolId [32]byte, tick *big.Int) (hexutil.Bytes, error) {
+	data, err := packABI(Uniswapv4ABIName, "getTickLiquidity(bytes32,int24)", poolId, tick)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Bytes(data), nil
+}
+
+// Uniswapv4GetTickLiquidityOutput holds the return values of getTickLiquidity(bytes32,int24)
+type Uniswapv4GetTickLiquidityOutput struct {
+	LiquidityGross *big.Int
+	LiquidityNet   *big.Int
+}
+
+// Unpack_uniswapv4_getTickLiquidity decodes the return data of getTickLiquidity(bytes32,int24)
+func Unpack_uniswapv4_getTickLiquidity(data []byte) (Uniswapv4GetTickLiquidityOutput, error) {
+	var result Uniswapv4GetTickLiquidityOutput
+	values, err := unpackABI(Uniswapv4ABIName, "getTickLiquidity(bytes32,int24)", data, 2)
+	if err != nil {
+		return result, err
+	}
+	if result.LiquidityGross, err = convertOutput[*big.Int]("getTickLiquidity(bytes32,int24)", values, 0); err != nil {
+		return result, err
+	}
+	if result.LiquidityNet, err = convertOutput[*big.Int]("getTickLiquidity(bytes32,int24)", values, 1); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// Uniswapv4InitializeEvent is the Initialize(bytes32,address,address,uint24,int24,address,uint160,int24) event
+type Uniswapv4InitializeEvent struct {
+	Id           [32]byte
+	Currency0    common.Address
+	Currency1    common.Address
+	Fee          *big.Int
+	TickSpacing  *big.Int
+	Hooks        common.Address
+	SqrtPriceX96 *big.Int
+	Tick         *big.Int
+	Raw          types.Log
+}
+
+// Unpack_uniswapv4_InitializeEvent decodes a Initialize log
+func Unpack_uniswapv4_InitializeEvent(log types.Log) (*Uniswapv4InitializeEvent, error) {
+	event := &Uniswapv4InitializeEvent{Raw: log}
+	if err := unpackEvent(Uniswapv4ABIName, "Initialize", log, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Uniswapv4ModifyLiquidityEvent is the ModifyLiquidity(bytes32,address,int24,int24,int256,bytes32) event
+type Uniswapv4ModifyLiquidityEvent struct {
+	Id             [32]byte
+	Sender         common.Address
+	TickLower      *big.Int
+	TickUpper      *big.Int
+	LiquidityDelta *big.Int
+	Salt           [32]byte
+	Raw            types.Log
+}
+
+// Unpack_uniswapv4_ModifyLiquidityEvent decodes a ModifyLiquidity log
+func Unpack_uniswapv4_ModifyLiquidityEvent(log types.Log) (*Uniswapv4ModifyLiquidityEvent, error) {
+	event := &Uniswapv4ModifyLiquidityEvent{Raw: log}
+	if err := unpackEvent(Uniswapv4ABIName, "ModifyLiquidity", log, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Uniswapv4SwapEvent is the Swap(bytes32,address,int128,int128,uint160,uint128,int24,uint24) event
+type Uniswapv4SwapEvent struct {
+	Id           [32]byte
+	Sender       common.Address
+	Amount0      *big.Int
+	Amount1      *big.Int
+	SqrtPriceX96 *big.Int
+	Liquidity    *big.Int
+	Tick         *big.Int
+	Fee          *big.Int
+	Raw          types.Log
+}
+
+// Unpack_uniswapv4_SwapEvent decodes a Swap log
+func Unpack_uniswapv4_SwapEvent(log types.Log) (*Uniswapv4SwapEvent, error) {
+	event := &Uniswapv4SwapEvent{Raw: log}
+	if err := unpackEvent(Uniswapv4ABIName, "Swap", log, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func init() {
+	registerEventDecoder(Uniswapv4ABIName, "Initialize", func(log types.Log) (interface{}, error) { return Unpack_uniswapv4_InitializeEvent(log) })
+	registerEventDecoder(Uniswapv4ABIName, "ModifyLiquidity", func(log types.Log) (interface{}, error) { return Unpack_uniswapv4_ModifyLiquidityEvent(log) })
+	registerEventDecoder(Uniswapv4ABIName, "Swap", func(log types.Log) (interface{}, error) { return Unpack_uniswapv4_SwapEvent(log) })
+}
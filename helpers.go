@@ -4,30 +4,34 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // Helper functions to build common Ethereum JSON-RPC requests
 
 // buildBlockNumber builds the block number for the request
 // If blockNumber slice is empty or nil, defaults to "latest"
-func buildBlockNumber(blockNumber []any) string {
+// Strings are passed through unchanged and integers formatted as hex, as they always
+// were; other values, e.g. BlockRef or *big.Int, are converted with ParseBlockRef.
+// It panics on unsupported types, use the BlockRef variants to get an error instead
+func buildBlockNumber(blockNumber []any) any {
 	// Handle empty slice case - default to "latest"
 	if len(blockNumber) == 0 {
 		return "latest"
 	}
 
-	// Handle nil case - default to "latest"
-	if blockNumber[0] == nil {
-		return "latest"
-	}
-
 	switch block := blockNumber[0].(type) {
 	case string:
 		return block
 	case int64, uint64, int32, uint32, int16, uint16, int8, uint8, int, uint:
 		return fmt.Sprintf("0x%x", block)
 	}
-	panic(fmt.Sprintf("invalid block number type: %T", blockNumber[0]))
+
+	ref, err := ParseBlockRef(blockNumber[0])
+	if err != nil {
+		panic(err.Error())
+	}
+	return ref.param()
 }
 
 // BuildGetTransactionCount creates a request to get transaction count for an address
@@ -44,18 +48,48 @@ func BuildRequestGetBalance(address common.Address, blockNumber ...any) *Request
 	return NewRequest(0, "eth_getBalance", params)
 }
 
+// BuildRequestGetTransactionCountAt creates a request to get transaction count for an address at block
+func BuildRequestGetTransactionCountAt(address common.Address, block BlockRef) (*Request, error) {
+	if err := block.Validate(); err != nil {
+		return nil, err
+	}
+	return NewRequest(0, "eth_getTransactionCount", []interface{}{address.Hex(), block.param()}), nil
+}
+
+// BuildRequestGetBalanceAt creates a request to get balance for an address at block
+func BuildRequestGetBalanceAt(address common.Address, block BlockRef) (*Request, error) {
+	if err := block.Validate(); err != nil {
+		return nil, err
+	}
+	return NewRequest(0, "eth_getBalance", []interface{}{address.Hex(), block.param()}), nil
+}
+
 // BuildGetBlockNumber creates a request to get the latest block number
 func BuildRequestGetBlockNumber() *Request {
 	return NewRequest(0, "eth_blockNumber", nil)
 }
 
 // BuildGetBlockByNumber creates a request to get block by number
+// use BuildRequestGetBlock for tags
 func BuildRequestGetBlockByNumber(number int64, fullTx bool) *Request {
 	blockNum := fmt.Sprintf("0x%x", number)
 	params := []interface{}{blockNum, fullTx} // true for full transaction objects
 	return NewRequest(0, "eth_getBlockByNumber", params)
 }
 
+// BuildRequestGetBlock creates a request to get a block by ref
+// hash refs are sent as eth_getBlockByHash, tags and numbers as eth_getBlockByNumber
+func BuildRequestGetBlock(block BlockRef, fullTx bool) (*Request, error) {
+	if hash, ok := block.Hash(); ok {
+		return NewRequest(0, "eth_getBlockByHash", []interface{}{hash.Hex(), fullTx}), nil
+	}
+	blockNum, err := block.numberParam()
+	if err != nil {
+		return nil, err
+	}
+	return NewRequest(0, "eth_getBlockByNumber", []interface{}{blockNum, fullTx}), nil
+}
+
 // BuildGetTransactionByHash creates a request to get transaction by hash
 func BuildRequestGetTransactionByHash(hash common.Hash) *Request {
 	params := []interface{}{hash.Hex()}
@@ -74,3 +108,106 @@ func BuildRequestGetTransactionReceipt(hash common.Hash) *Request {
 // 		StateDiff: make(map[string]string),
 // 	}
 // }
+
+// BuildRequestChainID creates a request to get the chain id
+func BuildRequestChainID() *Request {
+	return NewRequest(0, "eth_chainId", nil)
+}
+
+// BuildRequestGasPrice creates a request to get the legacy gas price
+func BuildRequestGasPrice() *Request {
+	return NewRequest(0, "eth_gasPrice", nil)
+}
+
+// BuildRequestMaxPriorityFeePerGas creates a request to get the suggested EIP-1559 tip
+func BuildRequestMaxPriorityFeePerGas() *Request {
+	return NewRequest(0, "eth_maxPriorityFeePerGas", nil)
+}
+
+// BuildRequestBlobBaseFee creates a request to get the EIP-4844 blob base fee
+func BuildRequestBlobBaseFee() *Request {
+	return NewRequest(0, "eth_blobBaseFee", nil)
+}
+
+// BuildRequestFeeHistory creates a request to get the fee history of blockCount blocks up to newest
+// rewardPercentiles must be monotonically increasing values in [0, 100]
+func BuildRequestFeeHistory(blockCount uint64, newest BlockRef, rewardPercentiles []float64) (*Request, error) {
+	newestBlock, err := newest.numberParam()
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 || (i > 0 && p < rewardPercentiles[i-1]) {
+			return nil, fmt.Errorf("invalid reward percentiles: %v", rewardPercentiles)
+		}
+	}
+	if rewardPercentiles == nil {
+		rewardPercentiles = []float64{}
+	}
+	params := []interface{}{hexutil.Uint64(blockCount), newestBlock, rewardPercentiles}
+	return NewRequest(0, "eth_feeHistory", params), nil
+}
+
+// BuildRequestEstimateGas creates a request to estimate the gas of callMsg at block
+// stateOverrides is optional and sent as the third param
+func BuildRequestEstimateGas(callMsg CallMsg, block BlockRef, stateOverrides map[common.Address]StateOverride) (*Request, error) {
+	if err := block.Validate(); err != nil {
+		return nil, err
+	}
+	params := []interface{}{callMsg, block.param()}
+	if stateOverrides != nil {
+		params = append(params, stateOverrides)
+	}
+	return NewRequest(0, "eth_estimateGas", params), nil
+}
+
+// BuildRequestGetCode creates a request to get the code of a contract at block
+func BuildRequestGetCode(address common.Address, block BlockRef) (*Request, error) {
+	if err := block.Validate(); err != nil {
+		return nil, err
+	}
+	return NewRequest(0, "eth_getCode", []interface{}{address.Hex(), block.param()}), nil
+}
+
+// BuildRequestGetStorageAt creates a request to get a storage slot of a contract at block
+func BuildRequestGetStorageAt(address common.Address, slot common.Hash, block BlockRef) (*Request, error) {
+	if err := block.Validate(); err != nil {
+		return nil, err
+	}
+	return NewRequest(0, "eth_getStorageAt", []interface{}{address.Hex(), slot.Hex(), block.param()}), nil
+}
+
+// BuildRequestGetBlockByHash creates a request to get block by hash
+func BuildRequestGetBlockByHash(hash common.Hash, fullTx bool) *Request {
+	return NewRequest(0, "eth_getBlockByHash", []interface{}{hash.Hex(), fullTx})
+}
+
+// BuildRequestGetBlockReceipts creates a request to get all receipts of a block
+func BuildRequestGetBlockReceipts(block BlockRef) (*Request, error) {
+	if err := block.Validate(); err != nil {
+		return nil, err
+	}
+	if hash, ok := block.Hash(); ok {
+		return NewRequest(0, "eth_getBlockReceipts", []interface{}{hash.Hex()}), nil
+	}
+	return NewRequest(0, "eth_getBlockReceipts", []interface{}{block.param()}), nil
+}
+
+// BuildRequestGetTransactionByBlockNumberAndIndex creates a request to get the transaction at index in block
+func BuildRequestGetTransactionByBlockNumberAndIndex(block BlockRef, index uint64) (*Request, error) {
+	blockNum, err := block.numberParam()
+	if err != nil {
+		return nil, err
+	}
+	return NewRequest(0, "eth_getTransactionByBlockNumberAndIndex", []interface{}{blockNum, hexutil.Uint64(index)}), nil
+}
+
+// BuildRequestSyncing creates a request to get the sync status
+func BuildRequestSyncing() *Request {
+	return NewRequest(0, "eth_syncing", nil)
+}
+
+// BuildRequestNetVersion creates a request to get the network id
+func BuildRequestNetVersion() *Request {
+	return NewRequest(0, "net_version", nil)
+}
@@ -0,0 +1,315 @@
+package wsClient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Mempool is a local view of the pending transactions keyed by sender and nonce,
+// seeded from txpool_content and kept fresh by a newPendingTransactions subscription.
+// A transaction replaces the one with the same sender and nonce; mined transactions
+// are dropped with RemoveIncluded or RemoveBelow. Mempool is safe for concurrent use.
+type Mempool struct {
+	mu       sync.RWMutex
+	bySender map[common.Address]map[uint64]*RPCTransaction
+	byHash   map[common.Hash]*RPCTransaction
+}
+
+// NewMempool creates an empty mempool
+func NewMempool() *Mempool {
+	return &Mempool{
+		bySender: make(map[common.Address]map[uint64]*RPCTransaction),
+		byHash:   make(map[common.Hash]*RPCTransaction),
+	}
+}
+
+// Seed replaces the content of the mempool with the pending and queued transactions
+// of txpool_content
+func (m *Mempool) Seed(ctx context.Context, c *Client) error {
+	content, err := c.TxPoolContent(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get txpool content: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.bySender = make(map[common.Address]map[uint64]*RPCTransaction, len(content.Pending))
+	m.byHash = make(map[common.Hash]*RPCTransaction)
+	for _, accounts := range []map[common.Address]map[uint64]*RPCTransaction{content.Pending, content.Queued} {
+		for _, txs := range accounts {
+			for _, tx := range txs {
+				m.add(tx)
+			}
+		}
+	}
+	return nil
+}
+
+// Add inserts tx, replacing the transaction with the same sender and nonce
+func (m *Mempool) Add(tx *RPCTransaction) {
+	if tx == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.add(tx)
+}
+
+func (m *Mempool) add(tx *RPCTransaction) {
+	nonces, ok := m.bySender[tx.From]
+	if !ok {
+		nonces = make(map[uint64]*RPCTransaction)
+		m.bySender[tx.From] = nonces
+	}
+	if old, ok := nonces[uint64(tx.Nonce)]; ok {
+		delete(m.byHash, old.Hash)
+	}
+	nonces[uint64(tx.Nonce)] = tx
+	m.byHash[tx.Hash] = tx
+}
+
+// Remove drops the transaction with the given hash and reports whether it was present
+func (m *Mempool) Remove(hash common.Hash) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tx, ok := m.byHash[hash]
+	if !ok {
+		return false
+	}
+	m.remove(tx)
+	return true
+}
+
+func (m *Mempool) remove(tx *RPCTransaction) {
+	delete(m.byHash, tx.Hash)
+	nonces := m.bySender[tx.From]
+	delete(nonces, uint64(tx.Nonce))
+	if len(nonces) == 0 {
+		delete(m.bySender, tx.From)
+	}
+}
+
+// RemoveBelow drops the transactions of sender with a nonce lower than nonce, i.e.
+// the ones made obsolete once the account nonce reached nonce, and returns their number
+func (m *Mempool) RemoveBelow(sender common.Address, nonce uint64) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	removed := 0
+	for txNonce, tx := range m.bySender[sender] {
+		if txNonce < nonce {
+			m.remove(tx)
+			removed++
+		}
+	}
+	return removed
+}
+
+// RemoveIncluded drops the transactions mined in block and the ones they replaced;
+// a block fetched without full transactions only drops by hash
+func (m *Mempool) RemoveIncluded(block *RPCBlock) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, hash := range block.TxHashes {
+		if tx, ok := m.byHash[hash]; ok {
+			m.remove(tx)
+		}
+	}
+	for _, mined := range block.Transactions {
+		for txNonce, tx := range m.bySender[mined.From] {
+			if txNonce <= uint64(mined.Nonce) {
+				m.remove(tx)
+			}
+		}
+	}
+}
+
+// Len returns the number of transactions
+func (m *Mempool) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.byHash)
+}
+
+// Get returns the transaction with the given hash, nil if it is unknown
+func (m *Mempool) Get(hash common.Hash) *RPCTransaction {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.byHash[hash]
+}
+
+// BySender returns the transactions of sender ordered by nonce
+func (m *Mempool) BySender(sender common.Address) []*RPCTransaction {
+	m.mu.RLock()
+	txs := make([]*RPCTransaction, 0, len(m.bySender[sender]))
+	for _, tx := range m.bySender[sender] {
+		txs = append(txs, tx)
+	}
+	m.mu.RUnlock()
+	sortTransactions(txs)
+	return txs
+}
+
+// Filter returns the transactions matching fn, ordered by sender and nonce
+// fn runs under the read lock and must not call back into the mempool
+func (m *Mempool) Filter(fn func(tx *RPCTransaction) bool) []*RPCTransaction {
+	m.mu.RLock()
+	var txs []*RPCTransaction
+	for _, tx := range m.byHash {
+		if fn(tx) {
+			txs = append(txs, tx)
+		}
+	}
+	m.mu.RUnlock()
+	sortTransactions(txs)
+	return txs
+}
+
+// Touching returns the transactions that may trade against one of pairs: the ones sent
+// to a pair and the ones whose calldata holds a pair address, either as an ABI word
+// (V2 router paths, pool arguments) or packed (V3 paths)
+func (m *Mempool) Touching(pairs ...common.Address) []*RPCTransaction {
+	return m.Filter(func(tx *RPCTransaction) bool {
+		for _, pair := range pairs {
+			if tx.To != nil && *tx.To == pair {
+				return true
+			}
+			if bytes.Contains(tx.Input, pair.Bytes()) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// TouchingHashes returns the hashes of the transactions touching one of pairs, ready
+// for Build_GetTargetTx_request
+func (m *Mempool) TouchingHashes(pairs ...common.Address) []common.Hash {
+	txs := m.Touching(pairs...)
+	hashes := make([]common.Hash, len(txs))
+	for i, tx := range txs {
+		hashes[i] = tx.Hash
+	}
+	return hashes
+}
+
+// sortTransactions orders txs by sender and nonce
+func sortTransactions(txs []*RPCTransaction) {
+	sort.Slice(txs, func(i, j int) bool {
+		if txs[i].From != txs[j].From {
+			return bytes.Compare(txs[i].From[:], txs[j].From[:]) < 0
+		}
+		return txs[i].Nonce < txs[j].Nonce
+	})
+}
+
+// Run keeps the mempool fresh with a newPendingTransactions subscription on sub until
+// ctx is done or the subscription fails.
+// Full transactions are requested; nodes that only stream hashes need lookup, which may
+// be sub itself, to fetch the transactions. Without lookup hashes are ignored.
+func (m *Mempool) Run(ctx context.Context, sub *Client, lookup *Client) error {
+	if lookup == nil {
+		return sub.Subscribe(ctx, BuildRequestSubscribe("newPendingTransactions", true), m.handleNotification(ctx, nil))
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	hashes := make(chan common.Hash, 1024)
+	done := make(chan error, 1)
+	go func() {
+		err := m.fetchTransactions(subCtx, lookup, hashes)
+		if err != nil {
+			cancel()
+		}
+		done <- err
+	}()
+
+	handle := m.handleNotification(subCtx, hashes)
+	err := sub.Subscribe(subCtx, BuildRequestSubscribe("newPendingTransactions", true), handle)
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		// the node rejected the full transactions flag, subscribe to hashes
+		err = sub.Subscribe(subCtx, BuildRequestSubscribe("newPendingTransactions"), handle)
+	}
+	close(hashes)
+	if fetchErr := <-done; fetchErr != nil {
+		return fetchErr
+	}
+	return err
+}
+
+// handleNotification adds the transaction of a notification, hashes are sent to
+// hashes for lookup
+func (m *Mempool) handleNotification(ctx context.Context, hashes chan<- common.Hash) func(json.RawMessage) error {
+	return func(result json.RawMessage) error {
+		if len(result) > 0 && result[0] == '"' {
+			var hash common.Hash
+			if err := json.Unmarshal(result, &hash); err != nil {
+				return fmt.Errorf("failed to unmarshal tx hash: %w", err)
+			}
+			if hashes == nil || m.Get(hash) != nil {
+				return nil
+			}
+			select {
+			case hashes <- hash:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			return nil
+		}
+		tx := new(RPCTransaction)
+		if err := json.Unmarshal(result, tx); err != nil {
+			return fmt.Errorf("failed to unmarshal tx: %w", err)
+		}
+		m.Add(tx)
+		return nil
+	}
+}
+
+// fetchTransactions resolves the hashes received from the subscription in batches
+func (m *Mempool) fetchTransactions(ctx context.Context, c *Client, hashes <-chan common.Hash) error {
+	const maxBatch = 64
+	for hash := range hashes {
+		requests := []*Request{BuildRequestGetTransactionByHash(hash)}
+	drain:
+		for len(requests) < maxBatch {
+			select {
+			case hash, ok := <-hashes:
+				if !ok {
+					break drain
+				}
+				requests = append(requests, BuildRequestGetTransactionByHash(hash))
+			default:
+				break drain
+			}
+		}
+
+		responses, err := c.CallBatch(ctx, requests)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to fetch pending transactions: %w", err)
+		}
+		for _, response := range responses {
+			if response.Error != nil || len(response.Result) == 0 {
+				continue
+			}
+			var tx *RPCTransaction
+			if err := json.Unmarshal(response.Result, &tx); err != nil {
+				continue
+			}
+			// a mined transaction is no longer pending
+			if tx != nil && tx.BlockHash == nil {
+				m.Add(tx)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,333 @@
+package wsClient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	alice = common.HexToAddress("0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045")
+	bob   = common.HexToAddress("0x7a16fF8270133F063aAb6C9977183D9e72835428")
+	// a Uniswap V2 pair and the router
+	mempoolPair   = common.HexToAddress("0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc")
+	mempoolRouter = common.HexToAddress("0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D")
+)
+
+// pendingTx returns the JSON of a pending transaction of from with the given nonce,
+// its hash is derived from id
+func pendingTx(id int, from common.Address, nonce uint64, to common.Address, input string) string {
+	return fmt.Sprintf(`{"blockHash":null,"blockNumber":null,"from":"%s","gas":"0x5208","gasPrice":"0x3b9aca00","hash":"%s","input":"%s","nonce":"0x%x","to":"%s","transactionIndex":null,"value":"0x0","type":"0x0","v":"0x25","r":"0x1","s":"0x1"}`,
+		from.Hex(), txHash(id).Hex(), input, nonce, to.Hex())
+}
+
+func txHash(id int) common.Hash {
+	return common.BigToHash(new(big.Int).Lsh(big.NewInt(1), uint(id+8)))
+}
+
+// decodeTx decodes the JSON of pendingTx
+func decodeTx(t *testing.T, raw string) *RPCTransaction {
+	t.Helper()
+	tx := new(RPCTransaction)
+	if err := json.Unmarshal([]byte(raw), tx); err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+// nonces returns the nonces of txs
+func nonces(txs []*RPCTransaction) string {
+	out := make([]string, len(txs))
+	for i, tx := range txs {
+		out[i] = fmt.Sprint(uint64(tx.Nonce))
+	}
+	return strings.Join(out, ",")
+}
+
+// waitLen waits for the mempool to hold n transactions
+func waitLen(t *testing.T, m *Mempool, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for m.Len() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("mempool holds %d transactions, want %d", m.Len(), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestTxPoolBuilders(t *testing.T) {
+	tests := []struct {
+		request *Request
+		want    string
+	}{
+		{BuildRequestTxPoolContent(), `"method":"txpool_content","params":[]`},
+		{BuildRequestTxPoolContentFrom(alice), `"method":"txpool_contentFrom","params":["` + alice.Hex() + `"]`},
+		{BuildRequestTxPoolInspect(), `"method":"txpool_inspect","params":[]`},
+		{BuildRequestTxPoolStatus(), `"method":"txpool_status","params":[]`},
+	}
+	for _, tt := range tests {
+		out, err := json.Marshal(tt.request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(out), tt.want) {
+			t.Errorf("request = %s, want %s", out, tt.want)
+		}
+	}
+}
+
+func TestTxPoolMethods(t *testing.T) {
+	node := newStubNode(t, func(request *Request, write func(string)) {
+		switch request.Method {
+		case "txpool_content":
+			write(result(request.ID, `{"pending":{"`+alice.Hex()+`":{"7":`+pendingTx(1, alice, 7, mempoolRouter, "0x")+`}},"queued":{"`+bob.Hex()+`":{"9":`+pendingTx(2, bob, 9, mempoolRouter, "0x")+`}}}`))
+		case "txpool_contentFrom":
+			write(result(request.ID, `{"pending":{"7":`+pendingTx(1, alice, 7, mempoolRouter, "0x")+`},"queued":{}}`))
+		case "txpool_inspect":
+			write(result(request.ID, `{"pending":{"`+alice.Hex()+`":{"7":"`+mempoolRouter.Hex()+`: 0 wei + 21000 gas × 1000000000 wei"}},"queued":{}}`))
+		case "txpool_status":
+			write(result(request.ID, `{"pending":"0x1","queued":"0x1"}`))
+		}
+	})
+	client := node.dial(t)
+	ctx := context.Background()
+
+	content, err := client.TxPoolContent(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx := content.Pending[alice][7]; tx == nil || tx.Hash != txHash(1) || content.Queued[bob][9] == nil {
+		t.Errorf("TxPoolContent = %+v", content)
+	}
+	from, err := client.TxPoolContentFrom(ctx, alice)
+	if err != nil || from.Pending[7] == nil || len(from.Queued) != 0 {
+		t.Errorf("TxPoolContentFrom = %+v, %v", from, err)
+	}
+	inspect, err := client.TxPoolInspect(ctx)
+	if err != nil || !strings.HasPrefix(inspect.Pending[alice][7], mempoolRouter.Hex()) {
+		t.Errorf("TxPoolInspect = %+v, %v", inspect, err)
+	}
+	status, err := client.TxPoolStatus(ctx)
+	if err != nil || status.Pending != 1 || status.Queued != 1 {
+		t.Errorf("TxPoolStatus = %+v, %v", status, err)
+	}
+}
+
+func TestMempoolEviction(t *testing.T) {
+	node := newStubNode(t, func(request *Request, write func(string)) {
+		// alice has 3 executable transactions, bob one waiting for nonce 4
+		write(result(request.ID, `{"pending":{"`+alice.Hex()+`":{"5":`+pendingTx(1, alice, 5, mempoolRouter, "0x")+`,"6":`+pendingTx(2, alice, 6, mempoolPair, "0x")+`,"7":`+pendingTx(3, alice, 7, mempoolRouter, "0x")+`}},`+
+			`"queued":{"`+bob.Hex()+`":{"5":`+pendingTx(4, bob, 5, mempoolRouter, "0x")+`}}}`))
+	})
+	client := node.dial(t)
+
+	m := NewMempool()
+	m.Add(decodeTx(t, pendingTx(99, bob, 1, mempoolRouter, "0x")))
+	if err := m.Seed(context.Background(), client); err != nil {
+		t.Fatal(err)
+	}
+	// the seed replaces the content
+	if m.Len() != 4 || m.Get(txHash(99)) != nil || nonces(m.BySender(alice)) != "5,6,7" {
+		t.Fatalf("seeded mempool holds %d transactions, alice %s", m.Len(), nonces(m.BySender(alice)))
+	}
+
+	// a replacement evicts the transaction with the same sender and nonce
+	m.Add(decodeTx(t, pendingTx(5, alice, 6, mempoolRouter, "0x")))
+	if m.Len() != 4 || m.Get(txHash(2)) != nil || m.Get(txHash(5)) == nil {
+		t.Errorf("replaced mempool holds %d transactions", m.Len())
+	}
+	m.Add(nil)
+
+	if !m.Remove(txHash(4)) || m.Remove(txHash(4)) || len(m.BySender(bob)) != 0 {
+		t.Error("Remove did not drop bob's transaction once")
+	}
+	if n := m.RemoveBelow(alice, 6); n != 1 || nonces(m.BySender(alice)) != "6,7" {
+		t.Errorf("RemoveBelow = %d, alice %s", n, nonces(m.BySender(alice)))
+	}
+
+	// a mined nonce evicts the transactions it made obsolete
+	m.Add(decodeTx(t, pendingTx(6, bob, 2, mempoolRouter, "0x")))
+	m.Add(decodeTx(t, pendingTx(7, bob, 3, mempoolRouter, "0x")))
+	minedBlock := &RPCBlock{Transactions: []*RPCTransaction{decodeTx(t, pendingTx(8, alice, 6, mempoolRouter, "0x"))}}
+	m.RemoveIncluded(minedBlock)
+	if nonces(m.BySender(alice)) != "7" || m.Len() != 3 {
+		t.Errorf("after the mined block alice has %s, %d transactions", nonces(m.BySender(alice)), m.Len())
+	}
+	// a block of hashes only drops the mined ones
+	m.RemoveIncluded(&RPCBlock{TxHashes: []common.Hash{txHash(6), txHash(42)}})
+	if nonces(m.BySender(bob)) != "3" || m.Len() != 2 {
+		t.Errorf("after the hashes block bob has %s, %d transactions", nonces(m.BySender(bob)), m.Len())
+	}
+}
+
+func TestMempoolTouching(t *testing.T) {
+	pair := strings.ToLower(mempoolPair.Hex()[2:])
+	m := NewMempool()
+	m.Add(decodeTx(t, pendingTx(1, alice, 1, mempoolPair, "0x022c0d9f")))                                // sent to the pair
+	m.Add(decodeTx(t, pendingTx(2, alice, 2, mempoolRouter, "0x38ed1739000000000000000000000000"+pair))) // ABI word
+	m.Add(decodeTx(t, pendingTx(3, bob, 1, mempoolRouter, "0xc04b8d59"+pair+"000bb8")))                  // packed path
+	m.Add(decodeTx(t, pendingTx(4, bob, 2, mempoolRouter, "0x38ed1739")))                                // another route
+	m.Add(decodeTx(t, pendingTx(5, bob, 3, alice, "0x")))                                                // a transfer
+
+	hashes := m.TouchingHashes(mempoolPair)
+	if len(hashes) != 3 || hashes[0] != txHash(3) || hashes[1] != txHash(1) || hashes[2] != txHash(2) {
+		t.Errorf("TouchingHashes = %v, want the transactions 3, 1 and 2 by sender", hashes)
+	}
+	if txs := m.Touching(common.HexToAddress("0x01")); len(txs) != 0 {
+		t.Errorf("Touching an unknown pair = %d transactions", len(txs))
+	}
+	if txs := m.Filter(func(tx *RPCTransaction) bool { return tx.From == bob }); nonces(txs) != "1,2,3" {
+		t.Errorf("Filter = %s", nonces(txs))
+	}
+}
+
+// pendingNode streams the transactions of txs to newPendingTransactions subscriptions,
+// as full transactions or, with hashesOnly, as hashes served by eth_getTransactionByHash
+func pendingNode(t *testing.T, hashesOnly bool, txs []string) *Client {
+	byHash := make(map[string]string, len(txs))
+	for _, raw := range txs {
+		var tx struct {
+			Hash string `json:"hash"`
+		}
+		json.Unmarshal([]byte(raw), &tx)
+		byHash[tx.Hash] = raw
+	}
+	return newStubNode(t, func(request *Request, write func(string)) {
+		params, _ := request.Params.([]interface{})
+		switch request.Method {
+		case "eth_subscribe":
+			full := len(params) == 2
+			if full && hashesOnly {
+				write(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"error":{"code":-32602,"message":"invalid argument 1"}}`, request.ID))
+				return
+			}
+			write(result(request.ID, `"0xabc"`))
+			for hash, raw := range byHash {
+				if !full {
+					raw = `"` + hash + `"`
+				}
+				write(`{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0xabc","result":` + raw + `}}`)
+			}
+		case "eth_unsubscribe":
+			write(result(request.ID, "true"))
+		case "eth_getTransactionByHash":
+			raw, ok := byHash[params[0].(string)]
+			if !ok {
+				raw = "null"
+			}
+			write(result(request.ID, raw))
+		}
+	}).dial(t)
+}
+
+func TestMempoolRun(t *testing.T) {
+	mined := strings.Replace(pendingTx(4, bob, 1, mempoolRouter, "0x"), `"blockHash":null`, `"blockHash":"`+common.HexToHash("0xb1").Hex()+`"`, 1)
+	txs := []string{
+		pendingTx(1, alice, 1, mempoolPair, "0x"),
+		pendingTx(2, alice, 2, mempoolRouter, "0x"),
+		pendingTx(3, bob, 0, mempoolRouter, "0x"),
+		mined,
+	}
+	tests := []struct {
+		name       string
+		hashesOnly bool
+		lookup     bool
+		want       int
+	}{
+		{"full transactions", false, false, 4},
+		// the mined transaction is not pending
+		{"hashes", true, true, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := pendingNode(t, tt.hashesOnly, txs)
+			var lookup *Client
+			if tt.lookup {
+				lookup = client
+			}
+			m := NewMempool()
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() { done <- m.Run(ctx, client, lookup) }()
+
+			// readers share the mempool with the subscription
+			var wg sync.WaitGroup
+			for i := 0; i < 4; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for ctx.Err() == nil {
+						m.Touching(mempoolPair)
+						m.BySender(alice)
+						m.Get(txHash(1))
+					}
+				}()
+			}
+			waitLen(t, m, tt.want)
+			cancel()
+			wg.Wait()
+			if err := <-done; !errors.Is(err, context.Canceled) {
+				t.Errorf("Run = %v, want context.Canceled", err)
+			}
+			if nonces(m.BySender(alice)) != "1,2" || m.Touching(mempoolPair)[0].Hash != txHash(1) {
+				t.Errorf("alice has %s", nonces(m.BySender(alice)))
+			}
+		})
+	}
+}
+
+func TestMempoolRunIgnoresHashesWithoutLookup(t *testing.T) {
+	node := newStubNode(t, func(request *Request, write func(string)) {
+		switch request.Method {
+		case "eth_subscribe":
+			write(result(request.ID, `"0xabc"`))
+			write(`{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0xabc","result":"` + txHash(1).Hex() + `"}}`)
+			write(`{"jsonrpc":"2.0","method":"eth_subscription","params":{"subscription":"0xabc","result":` + pendingTx(2, alice, 1, mempoolRouter, "0x") + `}}`)
+		case "eth_unsubscribe":
+			write(result(request.ID, "true"))
+		}
+	})
+	client := node.dial(t)
+
+	m := NewMempool()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- m.Run(ctx, client, nil) }()
+	waitLen(t, m, 1)
+	cancel()
+	<-done
+	if m.Get(txHash(2)) == nil || m.Get(txHash(1)) != nil {
+		t.Error("the hash notification was added without lookup")
+	}
+}
+
+func TestMempoolConcurrentAdd(t *testing.T) {
+	m := NewMempool()
+	var wg sync.WaitGroup
+	for sender := 0; sender < 8; sender++ {
+		wg.Add(1)
+		go func(sender int) {
+			defer wg.Done()
+			from := common.BigToAddress(new(big.Int).Lsh(big.NewInt(1), uint(sender+8)))
+			for nonce := uint64(0); nonce < 50; nonce++ {
+				m.Add(&RPCTransaction{From: from, Nonce: 0, Hash: common.BigToHash(new(big.Int).SetUint64(uint64(sender)<<32 | nonce))})
+				m.Add(&RPCTransaction{From: from, Nonce: 1, Hash: common.BigToHash(new(big.Int).SetUint64(uint64(sender)<<32 | nonce | 1<<16))})
+				m.RemoveBelow(from, 1)
+			}
+		}(sender)
+	}
+	wg.Wait()
+	// each sender keeps its last nonce 1 transaction
+	if m.Len() != 8 {
+		t.Errorf("mempool holds %d transactions, want 8", m.Len())
+	}
+}
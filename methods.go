@@ -0,0 +1,177 @@
+package wsClient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Typed call helpers: each one builds its request, waits for the response with Call
+// and decodes the result.
+
+// ChainID returns the chain id
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	var result hexutil.Big
+	if err := c.Call(ctx, BuildRequestChainID(), &result); err != nil {
+		return nil, err
+	}
+	return result.ToInt(), nil
+}
+
+// GasPrice returns the legacy gas price
+func (c *Client) GasPrice(ctx context.Context) (*big.Int, error) {
+	var result hexutil.Big
+	if err := c.Call(ctx, BuildRequestGasPrice(), &result); err != nil {
+		return nil, err
+	}
+	return result.ToInt(), nil
+}
+
+// MaxPriorityFeePerGas returns the suggested EIP-1559 tip
+func (c *Client) MaxPriorityFeePerGas(ctx context.Context) (*big.Int, error) {
+	var result hexutil.Big
+	if err := c.Call(ctx, BuildRequestMaxPriorityFeePerGas(), &result); err != nil {
+		return nil, err
+	}
+	return result.ToInt(), nil
+}
+
+// BlobBaseFee returns the EIP-4844 blob base fee
+func (c *Client) BlobBaseFee(ctx context.Context) (*big.Int, error) {
+	var result hexutil.Big
+	if err := c.Call(ctx, BuildRequestBlobBaseFee(), &result); err != nil {
+		return nil, err
+	}
+	return result.ToInt(), nil
+}
+
+// FeeHistory returns the fee history of blockCount blocks up to newest
+func (c *Client) FeeHistory(ctx context.Context, blockCount uint64, newest BlockRef, rewardPercentiles []float64) (*FeeHistory, error) {
+	request, err := BuildRequestFeeHistory(blockCount, newest, rewardPercentiles)
+	if err != nil {
+		return nil, err
+	}
+	result := new(FeeHistory)
+	if err := c.Call(ctx, request, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// EstimateGas returns the gas estimate of callMsg at block, with optional state overrides
+func (c *Client) EstimateGas(ctx context.Context, callMsg CallMsg, block BlockRef, stateOverrides map[common.Address]StateOverride) (uint64, error) {
+	request, err := BuildRequestEstimateGas(callMsg, block, stateOverrides)
+	if err != nil {
+		return 0, err
+	}
+	var result hexutil.Uint64
+	if err := c.Call(ctx, request, &result); err != nil {
+		return 0, err
+	}
+	return uint64(result), nil
+}
+
+// CodeAt returns the code of a contract at block
+func (c *Client) CodeAt(ctx context.Context, address common.Address, block BlockRef) ([]byte, error) {
+	request, err := BuildRequestGetCode(address, block)
+	if err != nil {
+		return nil, err
+	}
+	var result hexutil.Bytes
+	if err := c.Call(ctx, request, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// StorageAt returns a storage slot of a contract at block
+func (c *Client) StorageAt(ctx context.Context, address common.Address, slot common.Hash, block BlockRef) (common.Hash, error) {
+	request, err := BuildRequestGetStorageAt(address, slot, block)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	var result hexutil.Bytes
+	if err := c.Call(ctx, request, &result); err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(result), nil
+}
+
+// BlockByHash returns the block with the given hash, nil if the node does not know it
+func (c *Client) BlockByHash(ctx context.Context, hash common.Hash, fullTx bool) (*RPCBlock, error) {
+	var result *RPCBlock
+	if err := c.Call(ctx, BuildRequestGetBlockByHash(hash, fullTx), &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BlockByRef returns the block referenced by block, nil if the node does not know it
+func (c *Client) BlockByRef(ctx context.Context, block BlockRef, fullTx bool) (*RPCBlock, error) {
+	request, err := BuildRequestGetBlock(block, fullTx)
+	if err != nil {
+		return nil, err
+	}
+	var result *RPCBlock
+	if err := c.Call(ctx, request, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BlockReceipts returns all receipts of a block
+func (c *Client) BlockReceipts(ctx context.Context, block BlockRef) ([]*types.Receipt, error) {
+	request, err := BuildRequestGetBlockReceipts(block)
+	if err != nil {
+		return nil, err
+	}
+	var result []*types.Receipt
+	if err := c.Call(ctx, request, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// TransactionInBlock returns the transaction at index in block, nil if it does not exist
+func (c *Client) TransactionInBlock(ctx context.Context, block BlockRef, index uint64) (*RPCTransaction, error) {
+	request, err := BuildRequestGetTransactionByBlockNumberAndIndex(block, index)
+	if err != nil {
+		return nil, err
+	}
+	var result *RPCTransaction
+	if err := c.Call(ctx, request, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Syncing returns the sync progress, nil when the node is in sync
+func (c *Client) Syncing(ctx context.Context) (*SyncProgress, error) {
+	var raw json.RawMessage
+	if err := c.Call(ctx, BuildRequestSyncing(), &raw); err != nil {
+		return nil, err
+	}
+	var syncing bool
+	if err := json.Unmarshal(raw, &syncing); err == nil {
+		return nil, nil
+	}
+	progress := new(SyncProgress)
+	if err := json.Unmarshal(raw, progress); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal sync progress: %w", err)
+	}
+	return progress, nil
+}
+
+// NetVersion returns the network id
+func (c *Client) NetVersion(ctx context.Context) (string, error) {
+	var result string
+	if err := c.Call(ctx, BuildRequestNetVersion(), &result); err != nil {
+		return "", err
+	}
+	return result, nil
+}
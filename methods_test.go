@@ -0,0 +1,199 @@
+package wsClient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var (
+	headerJSON = `"parentHash":"0x` + strings.Repeat("11", 32) + `","sha3Uncles":"0x` + strings.Repeat("22", 32) + `","miner":"0x95222290dd7278aa3ddd389cc1e1d165cc4bafe5",` +
+		`"stateRoot":"0x` + strings.Repeat("33", 32) + `","transactionsRoot":"0x` + strings.Repeat("44", 32) + `","receiptsRoot":"0x` + strings.Repeat("55", 32) + `",` +
+		`"logsBloom":"0x` + strings.Repeat("00", 256) + `","difficulty":"0x0","number":"0x12a05f2","gasLimit":"0x1c9c380","gasUsed":"0xe4e1c0","timestamp":"0x6553f100","extraData":"0x","baseFeePerGas":"0x3b9aca00"`
+	receiptJSON = `{"type":"0x2","status":"0x1","cumulativeGasUsed":"0x5208","logsBloom":"0x` + strings.Repeat("00", 256) + `","logs":[],` +
+		`"transactionHash":"0x` + strings.Repeat("aa", 32) + `","gasUsed":"0x5208","effectiveGasPrice":"0x3b9aca00","blockHash":"0x` + strings.Repeat("bb", 32) + `","blockNumber":"0x12a05f2","transactionIndex":"0x0"}`
+	methodsTx = `{"blockHash":"0x` + strings.Repeat("bb", 32) + `","blockNumber":"0x12a05f2","from":"0xd8da6bf26964af9d7eed9e03e53415d37aa96045","gas":"0x5208","gasPrice":"0x3b9aca00",` +
+		`"hash":"0x` + strings.Repeat("aa", 32) + `","input":"0x","nonce":"0x7","to":"0x7a250d5630b4cf539739df2c5dacb4c659f2488d","transactionIndex":"0x3","value":"0x1","type":"0x0","v":"0x25","r":"0x1","s":"0x1"}`
+)
+
+// recordingNode answers each method with its result in results, null for the others,
+// and records the params of the requests
+type recordingNode struct {
+	mu     sync.Mutex
+	params map[string]string
+}
+
+func newRecordingNode(t *testing.T, results map[string]string) (*recordingNode, *Client) {
+	node := &recordingNode{params: make(map[string]string)}
+	client := newStubNode(t, func(request *Request, write func(string)) {
+		params, _ := json.Marshal(request.Params)
+		node.mu.Lock()
+		node.params[request.Method] = string(params)
+		node.mu.Unlock()
+		if value, ok := results[request.Method]; ok {
+			write(result(request.ID, value))
+			return
+		}
+		write(result(request.ID, "null"))
+	}).dial(t)
+	return node, client
+}
+
+func (n *recordingNode) paramsOf(method string) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.params[method]
+}
+
+func TestClientMethods(t *testing.T) {
+	router := common.HexToAddress("0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D")
+	blockHash := common.HexToHash("0x" + strings.Repeat("bb", 32))
+	node, client := newRecordingNode(t, map[string]string{
+		"eth_chainId":                             `"0x1"`,
+		"eth_gasPrice":                            `"0x4a817c800"`,
+		"eth_maxPriorityFeePerGas":                `"0x3b9aca00"`,
+		"eth_blobBaseFee":                         `"0x1"`,
+		"eth_feeHistory":                          `{"oldestBlock":"0x12a05f0","reward":[["0x3b9aca00"],["0x77359400"]],"baseFeePerGas":["0x1","0x2","0x3"],"gasUsedRatio":[0.5,0.25]}`,
+		"eth_estimateGas":                         `"0x30d40"`,
+		"eth_getCode":                             `"0x6080"`,
+		"eth_getStorageAt":                        `"0x00000000000000000000000000000000000000000000000000000000000004d2"`,
+		"eth_getBlockByHash":                      `{"hash":"` + blockHash.Hex() + `",` + headerJSON + `,"size":"0x100","transactions":["0x` + strings.Repeat("aa", 32) + `"],"uncles":[]}`,
+		"eth_getBlockByNumber":                    `{"hash":"` + blockHash.Hex() + `",` + headerJSON + `,"size":"0x100","transactions":[` + methodsTx + `],"uncles":[]}`,
+		"eth_getBlockReceipts":                    `[` + receiptJSON + `]`,
+		"eth_getTransactionByBlockNumberAndIndex": methodsTx,
+		"eth_syncing":                             `{"startingBlock":"0x1","currentBlock":"0x2","highestBlock":"0x3"}`,
+		"net_version":                             `"1"`,
+	})
+	ctx := context.Background()
+	latest := BlockRefFromTag(BlockLatest)
+	byNumber := BlockRefFromNumber(19531250)
+
+	tests := []struct {
+		method string
+		call   func() (string, error)
+		want   string
+		params string
+	}{
+		{"eth_chainId", func() (string, error) { v, err := client.ChainID(ctx); return fmt.Sprint(v), err }, "1", "null"},
+		{"eth_gasPrice", func() (string, error) { v, err := client.GasPrice(ctx); return fmt.Sprint(v), err }, "20000000000", "null"},
+		{"eth_maxPriorityFeePerGas", func() (string, error) { v, err := client.MaxPriorityFeePerGas(ctx); return fmt.Sprint(v), err }, "1000000000", "null"},
+		{"eth_blobBaseFee", func() (string, error) { v, err := client.BlobBaseFee(ctx); return fmt.Sprint(v), err }, "1", "null"},
+		{"eth_feeHistory", func() (string, error) {
+			v, err := client.FeeHistory(ctx, 2, latest, []float64{50})
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprint(v.OldestBlock, v.Reward[1][0], len(v.BaseFee), v.GasUsedRatio), nil
+		}, "0x12a05f0 0x77359400 3 [0.5 0.25]", `["0x2","latest",[50]]`},
+		{"eth_estimateGas", func() (string, error) {
+			v, err := client.EstimateGas(ctx, CallMsg{To: &router}, latest, map[common.Address]StateOverride{router: {Code: hexutil.Bytes{0x60}}})
+			return fmt.Sprint(v), err
+		}, "200000", `[{"to":"` + strings.ToLower(router.Hex()) + `"},"latest",{"` + strings.ToLower(router.Hex()) + `":{"code":"0x60"}}]`},
+		{"eth_getCode", func() (string, error) { v, err := client.CodeAt(ctx, router, byNumber); return hexutil.Encode(v), err }, "0x6080", `["` + router.Hex() + `","0x12a05f2"]`},
+		{"eth_getStorageAt", func() (string, error) {
+			v, err := client.StorageAt(ctx, router, common.HexToHash("0x08"), BlockRefFromHash(blockHash, true))
+			return v.Big().String(), err
+		}, "1234", `["` + router.Hex() + `","` + common.HexToHash("0x08").Hex() + `",{"blockHash":"` + blockHash.Hex() + `","requireCanonical":true}]`},
+		{"eth_getBlockByHash", func() (string, error) {
+			v, err := client.BlockByHash(ctx, blockHash, false)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprint(v.Header.Number, len(v.TxHashes), len(v.Transactions)), nil
+		}, "19531250 1 0", `["` + blockHash.Hex() + `",false]`},
+		{"eth_getBlockByNumber", func() (string, error) {
+			v, err := client.BlockByRef(ctx, byNumber, true)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprint(v.Hash == blockHash, uint64(v.Transactions[0].Nonce)), nil
+		}, "true 7", `["0x12a05f2",true]`},
+		{"eth_getBlockReceipts", func() (string, error) {
+			v, err := client.BlockReceipts(ctx, latest)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprint(len(v), v[0].Status, v[0].GasUsed), nil
+		}, "1 1 21000", `["latest"]`},
+		{"eth_getTransactionByBlockNumberAndIndex", func() (string, error) {
+			v, err := client.TransactionInBlock(ctx, byNumber, 3)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d %s", uint64(*v.TransactionIndex), v.To.Hex()), nil
+		}, "3 " + router.Hex(), `["0x12a05f2","0x3"]`},
+		{"eth_syncing", func() (string, error) {
+			v, err := client.Syncing(ctx)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprint(v.CurrentBlock, v.HighestBlock), nil
+		}, "0x2 0x3", "null"},
+		{"net_version", func() (string, error) { return client.NetVersion(ctx) }, "1", "null"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			got, err := tt.call()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+			if params := node.paramsOf(tt.method); params != tt.params {
+				t.Errorf("params = %s, want %s", params, tt.params)
+			}
+		})
+	}
+}
+
+func TestClientMethodsEmptyResults(t *testing.T) {
+	// null for the missing block and transaction, false for a synced node
+	_, client := newRecordingNode(t, map[string]string{"eth_syncing": "false"})
+	ctx := context.Background()
+
+	if block, err := client.BlockByHash(ctx, common.HexToHash("0x01"), false); err != nil || block != nil {
+		t.Errorf("BlockByHash = %v, %v, want nil", block, err)
+	}
+	if block, err := client.BlockByRef(ctx, BlockRefFromNumber(1<<40), false); err != nil || block != nil {
+		t.Errorf("BlockByRef = %v, %v, want nil", block, err)
+	}
+	if tx, err := client.TransactionInBlock(ctx, BlockRefFromTag(BlockLatest), 1000); err != nil || tx != nil {
+		t.Errorf("TransactionInBlock = %v, %v, want nil", tx, err)
+	}
+	if progress, err := client.Syncing(ctx); err != nil || progress != nil {
+		t.Errorf("Syncing = %v, %v, want nil", progress, err)
+	}
+
+	// arguments the builders reject are not sent
+	hash := BlockRefFromHash(common.HexToHash("0x01"), false)
+	if _, err := client.FeeHistory(ctx, 1, hash, nil); err == nil {
+		t.Error("FeeHistory accepted a block hash")
+	}
+	if _, err := client.FeeHistory(ctx, 1, BlockRefFromTag(BlockLatest), []float64{90, 10}); err == nil {
+		t.Error("FeeHistory accepted decreasing percentiles")
+	}
+	if _, err := client.TransactionInBlock(ctx, hash, 0); err == nil {
+		t.Error("TransactionInBlock accepted a block hash")
+	}
+	if _, err := client.CodeAt(ctx, common.Address{}, BlockRefFromTag("head")); err == nil {
+		t.Error("CodeAt accepted an unknown tag")
+	}
+}
+
+func TestClientMethodsRPCError(t *testing.T) {
+	client := newStubNode(t, func(request *Request, write func(string)) {
+		write(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"error":{"code":3,"message":"execution reverted: ERR_NO_PROFIT"}}`, request.ID))
+	}).dial(t)
+	router := common.HexToAddress("0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D")
+	_, err := client.EstimateGas(context.Background(), CallMsg{To: &router}, BlockRefFromTag(BlockLatest), nil)
+	if revertErr, ok := DecodeRevert(err); !ok || revertErr.Reason != "ERR_NO_PROFIT" {
+		t.Errorf("EstimateGas err = %v, want the revert", err)
+	}
+}
@@ -0,0 +1,202 @@
+package wsClient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// AccountResult is the result of eth_getProof
+type AccountResult struct {
+	Address      common.Address  `json:"address"`
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	StorageProof []StorageResult `json:"storageProof"`
+}
+
+// StorageResult is the proof of one storage slot
+// Key is echoed as requested, it may not be zero padded
+type StorageResult struct {
+	Key   string          `json:"key"`
+	Value *hexutil.Big    `json:"value"`
+	Proof []hexutil.Bytes `json:"proof"`
+}
+
+// Slot returns the storage slot of the result
+func (s *StorageResult) Slot() common.Hash {
+	return common.HexToHash(s.Key)
+}
+
+// Storage returns the value of slot, false if the slot was not part of the proof
+func (r *AccountResult) Storage(slot common.Hash) (*big.Int, bool) {
+	for i := range r.StorageProof {
+		if r.StorageProof[i].Slot() == slot {
+			return r.StorageProof[i].Value.ToInt(), true
+		}
+	}
+	return nil, false
+}
+
+// BuildRequestGetProof creates a request for the account proof of address and the
+// storage proofs of slots at block
+func BuildRequestGetProof(address common.Address, slots []common.Hash, block BlockRef) (*Request, error) {
+	if err := block.Validate(); err != nil {
+		return nil, err
+	}
+	keys := make([]string, len(slots))
+	for i, slot := range slots {
+		keys[i] = slot.Hex()
+	}
+	return NewRequest(0, "eth_getProof", []interface{}{address.Hex(), keys, block.param()}), nil
+}
+
+// proofDB loads proof nodes into a database keyed by node hash, as trie.VerifyProof expects
+func proofDB(proof []hexutil.Bytes) *memorydb.Database {
+	db := memorydb.New()
+	for _, node := range proof {
+		db.Put(crypto.Keccak256(node), node)
+	}
+	return db
+}
+
+// VerifyAccountProof checks the account fields of result against stateRoot
+// A proof of absence is accepted for an empty account.
+func VerifyAccountProof(stateRoot common.Hash, result *AccountResult) error {
+	value, err := trie.VerifyProof(stateRoot, crypto.Keccak256(result.Address.Bytes()), proofDB(result.AccountProof))
+	if err != nil {
+		return fmt.Errorf("invalid account proof for %s: %w", result.Address.Hex(), err)
+	}
+
+	balance := result.Balance.ToInt()
+	if balance == nil {
+		balance = new(big.Int)
+	}
+	if value == nil {
+		// some providers report zero hashes instead of the empty root and code hash
+		emptyStorage := result.StorageHash == types.EmptyRootHash || result.StorageHash == (common.Hash{})
+		emptyCode := result.CodeHash == types.EmptyCodeHash || result.CodeHash == (common.Hash{})
+		if result.Nonce != 0 || balance.Sign() != 0 || !emptyStorage || !emptyCode {
+			return fmt.Errorf("account %s is absent from the state but the result is not empty", result.Address.Hex())
+		}
+		return nil
+	}
+
+	var account types.StateAccount
+	if err := rlp.DecodeBytes(value, &account); err != nil {
+		return fmt.Errorf("failed to decode account %s: %w", result.Address.Hex(), err)
+	}
+	switch {
+	case account.Nonce != uint64(result.Nonce):
+		return fmt.Errorf("account %s: nonce %d, proof has %d", result.Address.Hex(), result.Nonce, account.Nonce)
+	case account.Balance.Cmp(balance) != 0:
+		return fmt.Errorf("account %s: balance %s, proof has %s", result.Address.Hex(), balance, account.Balance)
+	case account.Root != result.StorageHash:
+		return fmt.Errorf("account %s: storage hash %s, proof has %s", result.Address.Hex(), result.StorageHash.Hex(), account.Root.Hex())
+	case !bytes.Equal(account.CodeHash, result.CodeHash.Bytes()):
+		return fmt.Errorf("account %s: code hash %s, proof has %x", result.Address.Hex(), result.CodeHash.Hex(), account.CodeHash)
+	}
+	return nil
+}
+
+// VerifyStorageProof checks a storage slot value against the storage root of its account
+// A proof of absence is accepted for a zero value.
+func VerifyStorageProof(storageRoot common.Hash, result *StorageResult) error {
+	slot := result.Slot()
+	claimed := result.Value.ToInt()
+	if claimed == nil {
+		claimed = new(big.Int)
+	}
+	// some providers report a zero hash instead of the empty root, every slot of an
+	// empty storage trie is zero and there are no nodes to prove it
+	if storageRoot == types.EmptyRootHash || storageRoot == (common.Hash{}) {
+		if len(result.Proof) != 0 {
+			return fmt.Errorf("slot %s: empty storage has a proof of %d nodes", slot.Hex(), len(result.Proof))
+		}
+		if claimed.Sign() != 0 {
+			return fmt.Errorf("slot %s: value %s, storage is empty", slot.Hex(), claimed)
+		}
+		return nil
+	}
+
+	value, err := trie.VerifyProof(storageRoot, crypto.Keccak256(slot.Bytes()), proofDB(result.Proof))
+	if err != nil {
+		return fmt.Errorf("invalid storage proof for slot %s: %w", slot.Hex(), err)
+	}
+
+	proven := new(big.Int)
+	if value != nil {
+		var content []byte
+		if err := rlp.DecodeBytes(value, &content); err != nil {
+			return fmt.Errorf("failed to decode slot %s: %w", slot.Hex(), err)
+		}
+		proven.SetBytes(content)
+	}
+	if proven.Cmp(claimed) != 0 {
+		return fmt.Errorf("slot %s: value %s, proof has %s", slot.Hex(), claimed, proven)
+	}
+	return nil
+}
+
+// VerifyProof checks the account and every storage proof of result against stateRoot
+func VerifyProof(stateRoot common.Hash, result *AccountResult) error {
+	if err := VerifyAccountProof(stateRoot, result); err != nil {
+		return err
+	}
+	for i := range result.StorageProof {
+		if err := VerifyStorageProof(result.StorageHash, &result.StorageProof[i]); err != nil {
+			return fmt.Errorf("account %s: %w", result.Address.Hex(), err)
+		}
+	}
+	return nil
+}
+
+// GetProof returns the account and storage proofs of address at block, unverified
+func (c *Client) GetProof(ctx context.Context, address common.Address, slots []common.Hash, block BlockRef) (*AccountResult, error) {
+	request, err := BuildRequestGetProof(address, slots, block)
+	if err != nil {
+		return nil, err
+	}
+	result := new(AccountResult)
+	if err := c.Call(ctx, request, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetVerifiedProof fetches the proofs of address at the block of header and verifies
+// them against header.Root. The header is the trust anchor: it must come from a trusted
+// source or be checked against a known block hash, since the provider could otherwise
+// serve a forged header matching forged proofs.
+func (c *Client) GetVerifiedProof(ctx context.Context, header *types.Header, address common.Address, slots []common.Hash) (*AccountResult, error) {
+	result, err := c.GetProof(ctx, address, slots, BlockRefFromHash(header.Hash(), false))
+	if err != nil {
+		return nil, err
+	}
+	if result.Address != address {
+		return nil, fmt.Errorf("proof is for %s, requested %s", result.Address.Hex(), address.Hex())
+	}
+	if len(result.StorageProof) != len(slots) {
+		return nil, fmt.Errorf("proof has %d storage slots, requested %d", len(result.StorageProof), len(slots))
+	}
+	for i, slot := range slots {
+		if result.StorageProof[i].Slot() != slot {
+			return nil, fmt.Errorf("storage proof %d is for slot %s, requested %s", i, result.StorageProof[i].Slot().Hex(), slot.Hex())
+		}
+	}
+	if err := VerifyProof(header.Root, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
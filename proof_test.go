@@ -0,0 +1,122 @@
+package wsClient
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// proveKey returns the proof nodes of the secure key of raw in tr
+func proveKey(t *testing.T, tr *trie.Trie, raw []byte) []hexutil.Bytes {
+	t.Helper()
+	db := memorydb.New()
+	if err := tr.Prove(crypto.Keccak256(raw), db); err != nil {
+		t.Fatal(err)
+	}
+	var proof []hexutil.Bytes
+	it := db.NewIterator(nil, nil)
+	defer it.Release()
+	for it.Next() {
+		proof = append(proof, common.CopyBytes(it.Value()))
+	}
+	return proof
+}
+
+// storageTrie builds a storage trie holding values by slot
+func storageTrie(t *testing.T, values map[common.Hash]int64) *trie.Trie {
+	t.Helper()
+	tr := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	for slot, value := range values {
+		encoded, err := rlp.EncodeToBytes(big.NewInt(value).Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		tr.MustUpdate(crypto.Keccak256(slot.Bytes()), encoded)
+	}
+	return tr
+}
+
+func TestVerifyStorageProof(t *testing.T) {
+	set, unset := common.HexToHash("0x08"), common.HexToHash("0x09")
+	tr := storageTrie(t, map[common.Hash]int64{set: 1000, common.HexToHash("0x0a"): 7})
+	root := tr.Hash()
+	setProof, unsetProof := proveKey(t, tr, set.Bytes()), proveKey(t, tr, unset.Bytes())
+
+	tests := []struct {
+		name    string
+		root    common.Hash
+		result  StorageResult
+		wantErr bool
+	}{
+		{"value", root, StorageResult{Key: "0x8", Value: (*hexutil.Big)(big.NewInt(1000)), Proof: setProof}, false},
+		{"wrong value", root, StorageResult{Key: "0x8", Value: (*hexutil.Big)(big.NewInt(999)), Proof: setProof}, true},
+		{"absent zero", root, StorageResult{Key: unset.Hex(), Value: (*hexutil.Big)(new(big.Int)), Proof: unsetProof}, false},
+		{"absent nonzero", root, StorageResult{Key: unset.Hex(), Value: (*hexutil.Big)(big.NewInt(1)), Proof: unsetProof}, true},
+		{"other slot proof", root, StorageResult{Key: unset.Hex(), Value: (*hexutil.Big)(big.NewInt(1000)), Proof: setProof}, true},
+		{"missing nodes", root, StorageResult{Key: "0x8", Value: (*hexutil.Big)(big.NewInt(1000))}, true},
+		{"empty root", types.EmptyRootHash, StorageResult{Key: "0x8", Value: (*hexutil.Big)(new(big.Int))}, false},
+		{"zero root", common.Hash{}, StorageResult{Key: "0x8"}, false},
+		{"empty root nonzero", types.EmptyRootHash, StorageResult{Key: "0x8", Value: (*hexutil.Big)(big.NewInt(1))}, true},
+		{"empty root with proof", types.EmptyRootHash, StorageResult{Key: "0x8", Value: (*hexutil.Big)(new(big.Int)), Proof: setProof}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyStorageProof(tt.root, &tt.result)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyProof(t *testing.T) {
+	contract := common.HexToAddress("0x0000000000000000000000000000000000001234")
+	empty := common.HexToAddress("0x0000000000000000000000000000000000005678")
+	slot := common.HexToHash("0x01")
+	storage := storageTrie(t, map[common.Hash]int64{slot: 42})
+	codeHash := crypto.Keccak256Hash([]byte{0x60, 0x00})
+
+	state := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase(), nil))
+	account, err := rlp.EncodeToBytes(&types.StateAccount{Nonce: 1, Balance: big.NewInt(5), Root: storage.Hash(), CodeHash: codeHash.Bytes()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.MustUpdate(crypto.Keccak256(contract.Bytes()), account)
+	stateRoot := state.Hash()
+
+	result := &AccountResult{
+		Address:      contract,
+		AccountProof: proveKey(t, state, contract.Bytes()),
+		Balance:      (*hexutil.Big)(big.NewInt(5)),
+		CodeHash:     codeHash,
+		Nonce:        1,
+		StorageHash:  storage.Hash(),
+		StorageProof: []StorageResult{{Key: slot.Hex(), Value: (*hexutil.Big)(big.NewInt(42)), Proof: proveKey(t, storage, slot.Bytes())}},
+	}
+	if err := VerifyProof(stateRoot, result); err != nil {
+		t.Fatal(err)
+	}
+	result.Balance = (*hexutil.Big)(big.NewInt(6))
+	if err := VerifyProof(stateRoot, result); err == nil {
+		t.Error("wrong balance accepted")
+	}
+
+	// an absent account reported with a zero storage hash and an unproven zero slot
+	absent := &AccountResult{
+		Address:      empty,
+		AccountProof: proveKey(t, state, empty.Bytes()),
+		Balance:      (*hexutil.Big)(new(big.Int)),
+		StorageProof: []StorageResult{{Key: slot.Hex(), Value: (*hexutil.Big)(new(big.Int))}},
+	}
+	if err := VerifyProof(stateRoot, absent); err != nil {
+		t.Errorf("absent account: %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package wsClient
+
+import (
+	"bytes"
+	"context"
+	"sync"
+)
+
+// frameQueue is an unbounded FIFO of frames, so the reader never waits for a slow consumer
+type frameQueue struct {
+	mu     sync.Mutex
+	frames []*bytes.Buffer
+	ready  chan struct{} // signalled after a push
+}
+
+// newFrameQueue creates an empty queue
+func newFrameQueue() *frameQueue {
+	return &frameQueue{ready: make(chan struct{}, 1)}
+}
+
+// push appends a frame and wakes a waiting consumer
+func (q *frameQueue) push(buf *bytes.Buffer) {
+	q.mu.Lock()
+	q.frames = append(q.frames, buf)
+	q.mu.Unlock()
+
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// pop removes the oldest frame, nil when the queue is empty
+func (q *frameQueue) pop() *bytes.Buffer {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.frames) == 0 {
+		return nil
+	}
+	buf := q.frames[0]
+	q.frames[0] = nil
+	q.frames = q.frames[1:]
+	return buf
+}
+
+// next waits for the oldest frame until ctx is done or the reader exits
+// frames queued before the reader exited are still returned
+func (q *frameQueue) next(ctx context.Context, r *connReader) (*bytes.Buffer, error) {
+	for {
+		if buf := q.pop(); buf != nil {
+			return buf, nil
+		}
+		select {
+		case <-q.ready:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-r.done:
+			if buf := q.pop(); buf != nil {
+				return buf, nil
+			}
+			return nil, r.err
+		}
+	}
+}
+
+// release returns the queued frames to the pool
+func (q *frameQueue) release() {
+	for buf := q.pop(); buf != nil; buf = q.pop() {
+		putBuffer(buf)
+	}
+}
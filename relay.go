@@ -0,0 +1,132 @@
+package wsClient
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Relay is an HTTP JSON-RPC client for builders and relays
+// every request is signed with the searcher key in the X-Flashbots-Signature header
+type Relay struct {
+	url        string
+	key        *ecdsa.PrivateKey
+	httpClient *http.Client
+}
+
+// NewRelay creates a relay client; key signs the payloads, it does not need to hold funds.
+// httpClient may be nil to use http.DefaultClient
+func NewRelay(relayURL string, key *ecdsa.PrivateKey, httpClient *http.Client) *Relay {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Relay{
+		url:        relayURL,
+		key:        key,
+		httpClient: httpClient,
+	}
+}
+
+// Call posts request to the relay and unmarshals the result into result
+// JSON-RPC errors are returned as *RPCError
+func (r *Relay) Call(ctx context.Context, request *Request, result any) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	if r.key != nil {
+		signature, err := FlashbotsSignature(r.key, body)
+		if err != nil {
+			return err
+		}
+		httpRequest.Header.Set(FlashbotsSignatureHeader, signature)
+	}
+
+	httpResponse, err := r.httpClient.Do(httpRequest)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer httpResponse.Body.Close()
+
+	data, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	var response Response
+	if err := json.Unmarshal(data, &response); err != nil {
+		if httpResponse.StatusCode != http.StatusOK {
+			return fmt.Errorf("relay returned %s: %s", httpResponse.Status, bytes.TrimSpace(data))
+		}
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if response.Error != nil {
+		return response.Error
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("relay returned %s", httpResponse.Status)
+	}
+	if result == nil || len(response.Result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("failed to unmarshal result: %w", err)
+	}
+	return nil
+}
+
+// SendBundle submits a bundle with eth_sendBundle
+func (r *Relay) SendBundle(ctx context.Context, args SendBundleArgs) (*SendBundleResult, error) {
+	request, err := Build_eth_sendBundle_request(0, args)
+	if err != nil {
+		return nil, err
+	}
+	result := new(SendBundleResult)
+	if err := r.Call(ctx, request, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CallBundle simulates a bundle with eth_callBundle
+func (r *Relay) CallBundle(ctx context.Context, args CallBundleArgs) (*CallBundleResult, error) {
+	request, err := Build_eth_callBundle_request(0, args)
+	if err != nil {
+		return nil, err
+	}
+	result := new(CallBundleResult)
+	if err := r.Call(ctx, request, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CancelBundle cancels the bundles sent with replacementUUID
+func (r *Relay) CancelBundle(ctx context.Context, replacementUUID string) error {
+	request, err := Build_eth_cancelBundle_request(0, replacementUUID)
+	if err != nil {
+		return err
+	}
+	return r.Call(ctx, request, nil)
+}
+
+// MevSendBundle submits a MEV-Share bundle with mev_sendBundle
+func (r *Relay) MevSendBundle(ctx context.Context, args MevSendBundleArgs) (*SendBundleResult, error) {
+	request, err := Build_mev_sendBundle_request(0, args)
+	if err != nil {
+		return nil, err
+	}
+	result := new(SendBundleResult)
+	if err := r.Call(ctx, request, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,148 @@
+package wsClient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// newStubRelay starts a relay that rejects requests not signed by searcher and
+// answers the others with the result of answer
+func newStubRelay(t *testing.T, searcher common.Address, answer func(request *Request) string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		signer, err := VerifyFlashbotsSignature(r.Header.Get(FlashbotsSignatureHeader), body)
+		if err != nil || signer != searcher {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":0,"error":{"code":-32600,"message":"invalid flashbots signature"}}`)
+			return
+		}
+		var request Request
+		if err := json.Unmarshal(body, &request); err != nil {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,%s}`, request.ID, answer(&request))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRelaySignsRequests(t *testing.T) {
+	key, err := crypto.HexToECDSA("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	if err != nil {
+		t.Fatal(err)
+	}
+	searcher := crypto.PubkeyToAddress(key.PublicKey)
+	bundleHash := common.HexToHash("0x01")
+	server := newStubRelay(t, searcher, func(request *Request) string {
+		switch request.Method {
+		case "eth_sendBundle":
+			return `"result":{"bundleHash":"` + bundleHash.Hex() + `"}`
+		case "eth_callBundle":
+			return `"result":{"bundleGasPrice":"476190476193","bundleHash":"` + bundleHash.Hex() + `","coinbaseDiff":"20000000000126000","ethSentToCoinbase":"20000000000000000","gasFees":"126000","results":[],"stateBlockNumber":5221585,"totalGasUsed":42000}`
+		}
+		return `"error":{"code":-32601,"message":"method not found"}`
+	})
+
+	relay := NewRelay(server.URL, key, nil)
+	sent, err := relay.SendBundle(context.Background(), SendBundleArgs{Txs: []hexutil.Bytes{{0x02}}, BlockNumber: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent.BundleHash != bundleHash {
+		t.Errorf("bundleHash = %s", sent.BundleHash.Hex())
+	}
+
+	simulated, err := relay.CallBundle(context.Background(), CallBundleArgs{Txs: []hexutil.Bytes{{0x02}}, BlockNumber: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := (*hexutil.Big)(simulated.CoinbaseDiff).ToInt().String(); got != "20000000000126000" {
+		t.Errorf("coinbaseDiff = %s", got)
+	}
+	if simulated.TotalGasUsed != 42000 || simulated.StateBlockNumber != 5221585 {
+		t.Errorf("result = %+v", simulated)
+	}
+
+	var rpcErr *RPCError
+	if err := relay.Call(context.Background(), NewRequest(0, "eth_foo", nil), nil); !errors.As(err, &rpcErr) || rpcErr.Code != -32601 {
+		t.Errorf("Call = %v, want method not found", err)
+	}
+}
+
+func TestRelayRejectsSignature(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	other, _ := crypto.GenerateKey()
+	server := newStubRelay(t, crypto.PubkeyToAddress(key.PublicKey), func(*Request) string { return `"result":null` })
+
+	tests := []struct {
+		name    string
+		relay   *Relay
+		wantErr bool
+	}{
+		{"signed", NewRelay(server.URL, key, nil), false},
+		{"unsigned", NewRelay(server.URL, nil, nil), true},
+		{"other key", NewRelay(server.URL, other, nil), true},
+	}
+	for _, tt := range tests {
+		err := tt.relay.CancelBundle(context.Background(), "uuid")
+		var rpcErr *RPCError
+		if tt.wantErr && (!errors.As(err, &rpcErr) || !strings.Contains(rpcErr.Message, "signature")) {
+			t.Errorf("%s: CancelBundle = %v, want signature error", tt.name, err)
+		}
+		if !tt.wantErr && err != nil {
+			t.Errorf("%s: CancelBundle = %v", tt.name, err)
+		}
+	}
+}
+
+func TestVerifyFlashbotsSignature(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_sendBundle","params":[]}`)
+	header, err := FlashbotsSignature(key, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	address, signature, _ := strings.Cut(header, ":")
+
+	tests := []struct {
+		name    string
+		header  string
+		body    []byte
+		wantErr bool
+	}{
+		{"valid", header, body, false},
+		{"lower case address", strings.ToLower(address) + ":" + signature, body, false},
+		{"tampered body", header, append([]byte(" "), body...), true},
+		{"claimed address", common.HexToAddress("0x01").Hex() + ":" + signature, body, true},
+		{"no separator", address, body, true},
+		{"short signature", address + ":0x1234", body, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := VerifyFlashbotsSignature(tt.header, tt.body)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && signer != crypto.PubkeyToAddress(key.PublicKey) {
+				t.Errorf("signer = %s", signer.Hex())
+			}
+		})
+	}
+}
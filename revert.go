@@ -0,0 +1,276 @@
+package wsClient
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Selectors of the builtin Solidity revert payloads
+var (
+	errorStringSelector = []byte{0x08, 0xc3, 0x79, 0xa0} // Error(string)
+	panicSelector       = []byte{0x4e, 0x48, 0x7b, 0x71} // Panic(uint256)
+)
+
+// ErrExecutionReverted matches every RevertError through errors.Is
+var ErrExecutionReverted = errors.New("execution reverted")
+
+// Sentinel errors for the ERR_* revert strings used by the router, swap and calculator modules
+var (
+	ErrAaveDepositFailed           = errors.New("ERR_AAVE_DEPOSIT_FAILED")
+	ErrAaveWithdrawalFailed        = errors.New("ERR_AAVE_WITHDRAWAL_FAILED")
+	ErrAddUsersFailed              = errors.New("ERR_ADD_USERS_FAILED")
+	ErrAlgebraGlobalState          = errors.New("ERR_ALGEBRA_GLOBAL_STATE")
+	ErrCantPayBidAmount            = errors.New("ERR_CANT_PAY_BID_AMOUNT")
+	ErrCanNotLoanFromThisPair      = errors.New("ERR_CAN_NOT_LOAN_FROM_THIS_PAIR")
+	ErrCanNotWithdrawFromAave      = errors.New("ERR_CAN_NOT_WITHDRAW_FROM_AAVE")
+	ErrCurve                       = errors.New("ERR_CURVE")
+	ErrFirstPairNotSupported       = errors.New("ERR_FIRST_PAIR_NOT_SUPPORTED")
+	ErrFlashloanIfCurrencyFailed   = errors.New("ERR_FLASHLOAN_IFCURRENCY_FAILED")
+	ErrFlashloanWithdrawAllFailed  = errors.New("ERR_FLASHLOAN_WITHDRAWALL_FAILED")
+	ErrGetReserves                 = errors.New("ERR_GET_RESERVES")
+	ErrInsufficientInput           = errors.New("ERR_INSUFFICIENT_INPUT")
+	ErrInsufficientInputAmount     = errors.New("ERR_INSUFFICIENT_INPUT_AMOUNT")
+	ErrInsufficientLiquidity       = errors.New("ERR_INSUFFICIENT_LIQUIDITY")
+	ErrInsufficientOutput          = errors.New("ERR_INSUFFICIENT_OUTPUT")
+	ErrLength                      = errors.New("ERR_LENGTH")
+	ErrLoanPoolNotSupported        = errors.New("ERR_LOAN_POOL_NOT_SUPPORTED")
+	ErrMaticPairs                  = errors.New("ERR_MATIC_PAIRS")
+	ErrMiniOut                     = errors.New("ERR_MINI_OUT")
+	ErrNotApproved                 = errors.New("ERR_NOT_APPROVED")
+	ErrNotAuthorized               = errors.New("ERR_NOT_AUTHORIZED")
+	ErrNotOwner                    = errors.New("ERR_NOT_OWNER")
+	ErrNotRouter                   = errors.New("ERR_NOT_ROUTER")
+	ErrNotUser                     = errors.New("ERR_NOT_USER")
+	ErrNoProfit                    = errors.New("ERR_NO_PROFIT")
+	ErrPairNotSupported            = errors.New("ERR_PAIR_NOT_SUPPORTED")
+	ErrRouterNotDeployed           = errors.New("ERR_ROUTER_NOT_DEPLOYED")
+	ErrSolverCallUnsuccessful      = errors.New("ERR_SOLVER_CALL_UNSUCCESSFUL")
+	ErrTokenNotFoundInCurvePool    = errors.New("ERR_TOKEN_NOT_FOUND_IN_CURVE_POOL")
+	ErrTransferFrom                = errors.New("ERR_TRANSFER_FROM")
+	ErrTransferHelperApproveFailed = errors.New("ERR_TRANSFER_HELPER_APPROVE_FAILED")
+	ErrTransferHelperFailed        = errors.New("ERR_TRANSFER_HELPER_FAILED")
+	ErrTransferHelperFromFailed    = errors.New("ERR_TRANSFER_HELPER_FROM_FAILED")
+	ErrWrongFlashloan              = errors.New("ERR_WRONG_FLASHLOAN")
+	ErrWrongRouter                 = errors.New("ERR_WRONG_ROUTER")
+)
+
+// revertReasons maps a revert string to its sentinel error, revertSentinels maps
+// the name of an ERR_* constant to its sentinel whatever string the contract returns
+var (
+	revertReasonsMu sync.RWMutex
+	revertReasons   = map[string]error{}
+	revertSentinels = map[string]error{}
+)
+
+func init() {
+	for _, sentinel := range []error{
+		ErrAaveDepositFailed, ErrAaveWithdrawalFailed, ErrAddUsersFailed, ErrAlgebraGlobalState,
+		ErrCantPayBidAmount, ErrCanNotLoanFromThisPair, ErrCanNotWithdrawFromAave, ErrCurve,
+		ErrFirstPairNotSupported, ErrFlashloanIfCurrencyFailed, ErrFlashloanWithdrawAllFailed,
+		ErrGetReserves, ErrInsufficientInput, ErrInsufficientInputAmount, ErrInsufficientLiquidity,
+		ErrInsufficientOutput, ErrLength, ErrLoanPoolNotSupported, ErrMaticPairs, ErrMiniOut,
+		ErrNotApproved, ErrNotAuthorized, ErrNotOwner, ErrNotRouter, ErrNotUser, ErrNoProfit,
+		ErrPairNotSupported, ErrRouterNotDeployed, ErrSolverCallUnsuccessful,
+		ErrTokenNotFoundInCurvePool, ErrTransferFrom, ErrTransferHelperApproveFailed,
+		ErrTransferHelperFailed, ErrTransferHelperFromFailed, ErrWrongFlashloan, ErrWrongRouter,
+	} {
+		revertReasons[sentinel.Error()] = sentinel
+		revertSentinels[sentinel.Error()] = sentinel
+	}
+}
+
+// RevertSentinel returns the sentinel of the ERR_* constant called name, e.g.
+// ErrNoProfit for "ERR_NO_PROFIT", or nil if there is none
+func RevertSentinel(name string) error {
+	return revertSentinels[name]
+}
+
+// RegisterRevertReason links a revert string to a sentinel error
+// use it when a deployed contract returns a different value for an ERR_* constant
+// than its name, e.g. RegisterRevertReason("NP", ErrNoProfit);
+// helper.LoadRevertReasons registers the values a deployment returns
+func RegisterRevertReason(reason string, sentinel error) {
+	revertReasonsMu.Lock()
+	defer revertReasonsMu.Unlock()
+	revertReasons[reason] = sentinel
+}
+
+// lookupRevertReason returns the sentinel registered for reason, or nil
+func lookupRevertReason(reason string) error {
+	revertReasonsMu.RLock()
+	defer revertReasonsMu.RUnlock()
+	return revertReasons[reason]
+}
+
+// RevertError is a decoded revert of a failed call
+type RevertError struct {
+	Selector  [4]byte       // first 4 bytes of the revert data, zero if the data is empty
+	Data      hexutil.Bytes // raw revert data
+	Reason    string        // decoded Error(string) reason
+	PanicCode *big.Int      // decoded Panic(uint256) code
+	Custom    *abi.Error    // custom error resolved against the given ABIs
+	Args      []interface{} // unpacked arguments of the custom error
+	Sentinel  error         // typed sentinel matching Reason, e.g. ErrNoProfit
+	RPCError  *RPCError     // source RPC error, nil when decoded from raw data
+}
+
+// Error returns a string representation of the revert
+func (e *RevertError) Error() string {
+	switch {
+	case e.Reason != "":
+		return "execution reverted: " + e.Reason
+	case e.PanicCode != nil:
+		return fmt.Sprintf("execution reverted: panic 0x%x (%s)", e.PanicCode, panicReason(e.PanicCode))
+	case e.Custom != nil:
+		return fmt.Sprintf("execution reverted: %s%v", e.Custom.Name, e.Args)
+	case len(e.Data) >= 4:
+		return fmt.Sprintf("execution reverted: unknown error 0x%x", e.Selector)
+	}
+	return "execution reverted"
+}
+
+// Is reports whether target is ErrExecutionReverted
+func (e *RevertError) Is(target error) bool {
+	return target == ErrExecutionReverted
+}
+
+// Unwrap exposes the sentinel and the source RPC error to errors.Is and errors.As
+func (e *RevertError) Unwrap() []error {
+	var errs []error
+	if e.Sentinel != nil {
+		errs = append(errs, e.Sentinel)
+	}
+	if e.RPCError != nil {
+		errs = append(errs, e.RPCError)
+	}
+	return errs
+}
+
+// DecodeRevert turns err into a RevertError when it wraps an RPCError carrying revert data
+// or an "execution reverted" message. Custom errors are resolved against abis.
+func DecodeRevert(err error, abis ...*abi.ABI) (*RevertError, bool) {
+	var revertErr *RevertError
+	if errors.As(err, &revertErr) {
+		return revertErr, true
+	}
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr == nil {
+		return nil, false
+	}
+
+	data, hasData := revertData(rpcErr.Data)
+	if !hasData && rpcErr.Code != CodeExecutionReverted && !strings.Contains(strings.ToLower(rpcErr.Message), "revert") {
+		return nil, false
+	}
+
+	revertErr = DecodeRevertData(data, abis...)
+	revertErr.RPCError = rpcErr
+
+	// some nodes only put the reason in the message
+	if len(data) == 0 {
+		if _, reason, ok := strings.Cut(rpcErr.Message, "execution reverted: "); ok {
+			revertErr.Reason = reason
+			revertErr.Sentinel = lookupRevertReason(reason)
+		}
+	}
+	return revertErr, true
+}
+
+// DecodeRevertData decodes raw revert data, resolving custom errors against abis
+func DecodeRevertData(data []byte, abis ...*abi.ABI) *RevertError {
+	revertErr := &RevertError{Data: data}
+	if len(data) < 4 {
+		return revertErr
+	}
+	copy(revertErr.Selector[:], data[:4])
+
+	switch {
+	case bytes.Equal(data[:4], errorStringSelector):
+		if reason, err := abi.UnpackRevert(data); err == nil {
+			revertErr.Reason = reason
+			revertErr.Sentinel = lookupRevertReason(reason)
+		}
+	case bytes.Equal(data[:4], panicSelector):
+		if len(data) >= 36 {
+			revertErr.PanicCode = new(big.Int).SetBytes(data[4:36])
+		}
+	default:
+		for _, contractABI := range abis {
+			if contractABI == nil {
+				continue
+			}
+			for _, customErr := range contractABI.Errors {
+				if !bytes.Equal(customErr.ID[:4], data[:4]) {
+					continue
+				}
+				args, err := customErr.Inputs.Unpack(data[4:])
+				if err != nil {
+					continue
+				}
+				customErr := customErr
+				revertErr.Custom = &customErr
+				revertErr.Args = args
+				revertErr.Sentinel = lookupRevertReason(customErr.Name)
+				return revertErr
+			}
+		}
+	}
+	return revertErr
+}
+
+// revertData extracts the revert bytes from RPCError.Data
+// geth and erigon send a hex string, nethermind prefixes it with "Reverted "
+func revertData(data interface{}) ([]byte, bool) {
+	switch v := data.(type) {
+	case string:
+		v = strings.TrimSpace(strings.TrimPrefix(v, "Reverted "))
+		raw, err := hexutil.Decode(v)
+		if err != nil {
+			return nil, false
+		}
+		return raw, true
+	case []byte:
+		return v, true
+	case map[string]interface{}:
+		// some providers wrap the payload as {"data": "0x..."}
+		return revertData(v["data"])
+	}
+	return nil, false
+}
+
+// panicReason describes the Solidity panic codes
+func panicReason(code *big.Int) string {
+	if !code.IsUint64() {
+		return "unknown panic"
+	}
+	switch code.Uint64() {
+	case 0x00:
+		return "generic compiler panic"
+	case 0x01:
+		return "assert failed"
+	case 0x11:
+		return "arithmetic overflow or underflow"
+	case 0x12:
+		return "division or modulo by zero"
+	case 0x21:
+		return "invalid enum value"
+	case 0x22:
+		return "invalid storage byte array encoding"
+	case 0x31:
+		return "pop on empty array"
+	case 0x32:
+		return "array index out of bounds"
+	case 0x41:
+		return "out of memory"
+	case 0x51:
+		return "call to zero-initialized function"
+	}
+	return "unknown panic"
+}
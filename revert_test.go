@@ -0,0 +1,92 @@
+package wsClient
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// word returns the 64 hex digits of a uint64 word
+func word(v uint64) string {
+	return fmt.Sprintf("%064x", v)
+}
+
+// errorString is the revert data of Error("ERR_NO_PROFIT")
+var errorString = "0x08c379a0" + word(0x20) + word(13) + fmt.Sprintf("%x", "ERR_NO_PROFIT") + strings.Repeat("0", 64-26)
+
+func TestDecodeRevert(t *testing.T) {
+	customABI, err := abi.JSON(strings.NewReader(`[{"type":"error","name":"TooLittleReceived","inputs":[{"name":"amount","type":"uint256"}]}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := customABI.Errors["TooLittleReceived"].ID
+	custom := fmt.Sprintf("0x%x", id[:4]) + word(5)
+
+	tests := []struct {
+		name     string
+		err      error
+		ok       bool
+		message  string
+		sentinel error
+	}{
+		{"error string", &RPCError{Code: 3, Message: "execution reverted", Data: errorString}, true, "execution reverted: ERR_NO_PROFIT", ErrNoProfit},
+		{"nethermind", &RPCError{Code: -32015, Message: "VM execution error.", Data: "Reverted " + errorString}, true, "execution reverted: ERR_NO_PROFIT", ErrNoProfit},
+		{"wrapped data", &RPCError{Code: 3, Message: "execution reverted", Data: map[string]interface{}{"data": errorString}}, true, "execution reverted: ERR_NO_PROFIT", ErrNoProfit},
+		{"message only", &RPCError{Code: -32000, Message: "execution reverted: ERR_MINI_OUT"}, true, "execution reverted: ERR_MINI_OUT", ErrMiniOut},
+		{"panic", &RPCError{Code: 3, Message: "execution reverted", Data: "0x4e487b71" + word(0x11)}, true, "execution reverted: panic 0x11 (arithmetic overflow or underflow)", nil},
+		{"custom", &RPCError{Code: 3, Message: "execution reverted", Data: custom}, true, "execution reverted: TooLittleReceived[5]", nil},
+		{"unknown selector", &RPCError{Code: 3, Message: "execution reverted", Data: "0xdeadbeef"}, true, "execution reverted: unknown error 0xdeadbeef", nil},
+		{"empty", &RPCError{Code: 3, Message: "execution reverted"}, true, "execution reverted", nil},
+		{"wrapped rpc error", fmt.Errorf("call: %w", &RPCError{Code: 3, Message: "execution reverted", Data: errorString}), true, "execution reverted: ERR_NO_PROFIT", ErrNoProfit},
+		{"not a revert", &RPCError{Code: -32000, Message: "nonce too low"}, false, "", nil},
+		{"not an rpc error", errors.New("boom"), false, "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revertErr, ok := DecodeRevert(tt.err, &customABI)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if revertErr.Error() != tt.message {
+				t.Errorf("Error() = %q, want %q", revertErr.Error(), tt.message)
+			}
+			if !errors.Is(revertErr, ErrExecutionReverted) {
+				t.Error("not ErrExecutionReverted")
+			}
+			if tt.sentinel != nil && !errors.Is(revertErr, tt.sentinel) {
+				t.Errorf("not %v", tt.sentinel)
+			}
+			var rpcErr *RPCError
+			if !errors.As(revertErr, &rpcErr) {
+				t.Error("source RPCError not unwrapped")
+			}
+		})
+	}
+}
+
+func TestRegisterRevertReason(t *testing.T) {
+	sentinel := errors.New("short reason")
+	RegisterRevertReason("SR", sentinel)
+	data := "0x08c379a0" + word(0x20) + word(2) + fmt.Sprintf("%x", "SR") + strings.Repeat("0", 60)
+	revertErr, ok := DecodeRevert(&RPCError{Code: 3, Message: "execution reverted", Data: data})
+	if !ok || revertErr.Reason != "SR" || !errors.Is(revertErr, sentinel) {
+		t.Errorf("DecodeRevert = %v, %v", revertErr, ok)
+	}
+}
+
+func TestRevertSentinel(t *testing.T) {
+	// the sentinel of a constant does not follow the strings registered for it
+	RegisterRevertReason("MO", ErrMiniOut)
+	if sentinel := RevertSentinel("ERR_MINI_OUT"); sentinel != ErrMiniOut {
+		t.Errorf("RevertSentinel(ERR_MINI_OUT) = %v", sentinel)
+	}
+	if sentinel := RevertSentinel("MO"); sentinel != nil {
+		t.Errorf("RevertSentinel(MO) = %v, want nil", sentinel)
+	}
+}
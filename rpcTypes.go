@@ -0,0 +1,110 @@
+package wsClient
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// RPCTransaction is a transaction as returned by eth_getTransactionBy* and eth_getTargetTx
+type RPCTransaction struct {
+	BlockHash           *common.Hash      `json:"blockHash"`
+	BlockNumber         *hexutil.Big      `json:"blockNumber"`
+	From                common.Address    `json:"from"`
+	Gas                 hexutil.Uint64    `json:"gas"`
+	GasPrice            *hexutil.Big      `json:"gasPrice"`
+	GasFeeCap           *hexutil.Big      `json:"maxFeePerGas,omitempty"`
+	GasTipCap           *hexutil.Big      `json:"maxPriorityFeePerGas,omitempty"`
+	MaxFeePerBlobGas    *hexutil.Big      `json:"maxFeePerBlobGas,omitempty"`
+	Hash                common.Hash       `json:"hash"`
+	Input               hexutil.Bytes     `json:"input"`
+	Nonce               hexutil.Uint64    `json:"nonce"`
+	To                  *common.Address   `json:"to"`
+	TransactionIndex    *hexutil.Uint64   `json:"transactionIndex"`
+	Value               *hexutil.Big      `json:"value"`
+	Type                hexutil.Uint64    `json:"type"`
+	Accesses            *types.AccessList `json:"accessList,omitempty"`
+	ChainID             *hexutil.Big      `json:"chainId,omitempty"`
+	BlobVersionedHashes []common.Hash     `json:"blobVersionedHashes,omitempty"`
+	V                   *hexutil.Big      `json:"v"`
+	R                   *hexutil.Big      `json:"r"`
+	S                   *hexutil.Big      `json:"s"`
+	YParity             *hexutil.Uint64   `json:"yParity,omitempty"`
+}
+
+// RPCBlock is a block as returned by eth_getBlockByNumber and eth_getBlockByHash
+// TxHashes is set when the block was requested without full transactions,
+// Transactions otherwise
+type RPCBlock struct {
+	Header       *types.Header
+	Hash         common.Hash
+	Size         hexutil.Uint64
+	TxHashes     []common.Hash
+	Transactions []*RPCTransaction
+	Uncles       []common.Hash
+	Withdrawals  []*types.Withdrawal
+}
+
+// UnmarshalJSON decodes the header and the block body separately since
+// types.Header has its own JSON decoding
+func (b *RPCBlock) UnmarshalJSON(data []byte) error {
+	header := new(types.Header)
+	if err := json.Unmarshal(data, header); err != nil {
+		return fmt.Errorf("failed to unmarshal block header: %w", err)
+	}
+
+	var body struct {
+		Hash         common.Hash         `json:"hash"`
+		Size         hexutil.Uint64      `json:"size"`
+		Transactions []json.RawMessage   `json:"transactions"`
+		Uncles       []common.Hash       `json:"uncles"`
+		Withdrawals  []*types.Withdrawal `json:"withdrawals"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return fmt.Errorf("failed to unmarshal block body: %w", err)
+	}
+
+	*b = RPCBlock{
+		Header:      header,
+		Hash:        body.Hash,
+		Size:        body.Size,
+		Uncles:      body.Uncles,
+		Withdrawals: body.Withdrawals,
+	}
+	for i, raw := range body.Transactions {
+		if len(raw) > 0 && raw[0] == '"' {
+			var hash common.Hash
+			if err := json.Unmarshal(raw, &hash); err != nil {
+				return fmt.Errorf("failed to unmarshal tx hash at index %d: %w", i, err)
+			}
+			b.TxHashes = append(b.TxHashes, hash)
+			continue
+		}
+		tx := new(RPCTransaction)
+		if err := json.Unmarshal(raw, tx); err != nil {
+			return fmt.Errorf("failed to unmarshal tx at index %d: %w", i, err)
+		}
+		b.Transactions = append(b.Transactions, tx)
+	}
+	return nil
+}
+
+// FeeHistory is the result of eth_feeHistory
+type FeeHistory struct {
+	OldestBlock      *hexutil.Big     `json:"oldestBlock"`
+	Reward           [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee          []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio     []float64        `json:"gasUsedRatio"`
+	BlobBaseFee      []*hexutil.Big   `json:"baseFeePerBlobGas,omitempty"`
+	BlobGasUsedRatio []float64        `json:"blobGasUsedRatio,omitempty"`
+}
+
+// SyncProgress is the result of eth_syncing while the node is syncing
+type SyncProgress struct {
+	StartingBlock hexutil.Uint64 `json:"startingBlock"`
+	CurrentBlock  hexutil.Uint64 `json:"currentBlock"`
+	HighestBlock  hexutil.Uint64 `json:"highestBlock"`
+}
@@ -0,0 +1,358 @@
+package wsClient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrSlotNotFound is returned when no candidate slot controls the probed value,
+// e.g. for rebasing tokens whose balanceOf is computed from shares
+var ErrSlotNotFound = errors.New("storage slot not found")
+
+// SlotLayout is the hashing order of a mapping entry
+type SlotLayout uint8
+
+const (
+	LayoutSolidity SlotLayout = iota // keccak256(key . slot)
+	LayoutVyper                      // keccak256(slot . key)
+)
+
+// String returns the layout name
+func (l SlotLayout) String() string {
+	switch l {
+	case LayoutSolidity:
+		return "solidity"
+	case LayoutVyper:
+		return "vyper"
+	}
+	return fmt.Sprintf("SlotLayout(%d)", uint8(l))
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (l SlotLayout) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (l *SlotLayout) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "solidity":
+		*l = LayoutSolidity
+	case "vyper":
+		*l = LayoutVyper
+	default:
+		return fmt.Errorf("unknown slot layout %q", text)
+	}
+	return nil
+}
+
+// MappingSlot locates a mapping in contract storage: its declaration slot and the
+// compiler layout used to hash the keys
+type MappingSlot struct {
+	Slot   uint64     `json:"slot"`
+	Layout SlotLayout `json:"layout"`
+}
+
+// String returns a short representation of the slot
+func (s MappingSlot) String() string {
+	return fmt.Sprintf("%s slot %d", s.Layout, s.Slot)
+}
+
+// Key returns the storage slot of mapping[key], e.g. balanceOf[holder]
+func (s MappingSlot) Key(key common.Address) common.Hash {
+	return MappingElementSlot(SlotOf(s.Slot), AddressKey(key), s.Layout)
+}
+
+// NestedKey returns the storage slot of mapping[key][subKey], e.g. allowance[owner][spender]
+func (s MappingSlot) NestedKey(key, subKey common.Address) common.Hash {
+	return MappingElementSlot(s.Key(key), AddressKey(subKey), s.Layout)
+}
+
+// BuildBalanceOverride overrides the balance of holder in token, slot comes from DiscoverBalanceSlot
+func BuildBalanceOverride(token, holder common.Address, slot MappingSlot, balance *big.Int) (map[common.Address]StateOverride, error) {
+	return buildSlotOverride(token, slot.Key(holder), balance)
+}
+
+// BuildAllowanceOverride overrides the allowance of spender over the tokens of owner,
+// slot comes from DiscoverAllowanceSlot
+func BuildAllowanceOverride(token, owner, spender common.Address, slot MappingSlot, allowance *big.Int) (map[common.Address]StateOverride, error) {
+	return buildSlotOverride(token, slot.NestedKey(owner, spender), allowance)
+}
+
+func buildSlotOverride(contract common.Address, slot common.Hash, value *big.Int) (map[common.Address]StateOverride, error) {
+	if value == nil {
+		return nil, fmt.Errorf("value is nil")
+	}
+	word, err := encodeWord(value, false)
+	if err != nil {
+		return nil, err
+	}
+	return map[common.Address]StateOverride{
+		contract: {StateDiff: map[string]string{slot.Hex(): hexutil.Encode(word)}},
+	}, nil
+}
+
+// MergeStateOverrides merges src into dst, e.g. a balance and an allowance override of
+// the same token; src wins on conflicting fields and slots
+func MergeStateOverrides(dst, src map[common.Address]StateOverride) map[common.Address]StateOverride {
+	if dst == nil {
+		dst = make(map[common.Address]StateOverride, len(src))
+	}
+	for address, override := range src {
+		merged := dst[address]
+		if override.Balance != nil {
+			merged.Balance = override.Balance
+		}
+		if override.Nonce != nil {
+			merged.Nonce = override.Nonce
+		}
+		if override.Code != nil {
+			merged.Code = override.Code
+		}
+		if override.State != nil {
+			merged.State, merged.StateDiff = mergeSlots(merged.State, override.State), nil
+		}
+		if override.StateDiff != nil {
+			if merged.State != nil {
+				merged.State = mergeSlots(merged.State, override.StateDiff)
+			} else {
+				merged.StateDiff = mergeSlots(merged.StateDiff, override.StateDiff)
+			}
+		}
+		dst[address] = merged
+	}
+	return dst
+}
+
+func mergeSlots(dst, src map[string]string) map[string]string {
+	merged := make(map[string]string, len(dst)+len(src))
+	for slot, value := range dst {
+		merged[slot] = value
+	}
+	for slot, value := range src {
+		merged[slot] = value
+	}
+	return merged
+}
+
+// SlotKind names the mapping stored in a SlotStore
+type SlotKind string
+
+const (
+	SlotKindBalance   SlotKind = "balance"
+	SlotKindAllowance SlotKind = "allowance"
+)
+
+// SlotStore caches discovered slots per token
+type SlotStore interface {
+	Load(token common.Address, kind SlotKind) (MappingSlot, bool)
+	Store(token common.Address, kind SlotKind, slot MappingSlot) error
+}
+
+// MemorySlotStore is an in-memory SlotStore
+type MemorySlotStore struct {
+	mu    sync.RWMutex
+	slots map[common.Address]map[SlotKind]MappingSlot
+}
+
+// NewMemorySlotStore creates an empty in-memory store
+func NewMemorySlotStore() *MemorySlotStore {
+	return &MemorySlotStore{slots: make(map[common.Address]map[SlotKind]MappingSlot)}
+}
+
+// Load returns the slot of token, false if it is unknown
+func (s *MemorySlotStore) Load(token common.Address, kind SlotKind) (MappingSlot, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	slot, ok := s.slots[token][kind]
+	return slot, ok
+}
+
+// Store saves the slot of token
+func (s *MemorySlotStore) Store(token common.Address, kind SlotKind, slot MappingSlot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.store(token, kind, slot)
+	return nil
+}
+
+func (s *MemorySlotStore) store(token common.Address, kind SlotKind, slot MappingSlot) {
+	if s.slots[token] == nil {
+		s.slots[token] = make(map[SlotKind]MappingSlot)
+	}
+	s.slots[token][kind] = slot
+}
+
+// FileSlotStore is a SlotStore persisted as a JSON file, rewritten on every Store
+// Token addresses are not chain specific, use one file per chain.
+type FileSlotStore struct {
+	MemorySlotStore
+	path string
+}
+
+// NewFileSlotStore opens the store at path, a missing file is an empty store
+func NewFileSlotStore(path string) (*FileSlotStore, error) {
+	store := &FileSlotStore{
+		MemorySlotStore: MemorySlotStore{slots: make(map[common.Address]map[SlotKind]MappingSlot)},
+		path:            path,
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read slot store: %w", err)
+	}
+	if err := json.Unmarshal(data, &store.slots); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal slot store %s: %w", path, err)
+	}
+	return store, nil
+}
+
+// Store saves the slot of token and writes the file
+func (s *FileSlotStore) Store(token common.Address, kind SlotKind, slot MappingSlot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.store(token, kind, slot)
+
+	data, err := json.MarshalIndent(s.slots, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal slot store: %w", err)
+	}
+	// write then rename so a crash never leaves a truncated file
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write slot store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write slot store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write slot store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write slot store: %w", err)
+	}
+	return nil
+}
+
+// SlotFinder discovers the balance and allowance slots of tokens by overriding candidate
+// slots in eth_call and checking which one balanceOf or allowance returns
+type SlotFinder struct {
+	Store   SlotStore
+	MaxSlot uint64   // highest declaration slot probed, defaults to 100
+	Block   BlockRef // block the probes run at, latest by default
+}
+
+// NewSlotFinder creates a finder caching its results in store
+func NewSlotFinder(store SlotStore) *SlotFinder {
+	return &SlotFinder{Store: store, MaxSlot: 100}
+}
+
+// DefaultSlotFinder backs DiscoverBalanceSlot and DiscoverAllowanceSlot, replace its Store
+// with a FileSlotStore to keep the results across runs
+var DefaultSlotFinder = NewSlotFinder(NewMemorySlotStore())
+
+// DiscoverBalanceSlot returns the balance mapping slot of token using DefaultSlotFinder
+func DiscoverBalanceSlot(ctx context.Context, c *Client, token, holder common.Address) (MappingSlot, error) {
+	return DefaultSlotFinder.BalanceSlot(ctx, c, token, holder)
+}
+
+// DiscoverAllowanceSlot returns the allowance mapping slot of token using DefaultSlotFinder
+func DiscoverAllowanceSlot(ctx context.Context, c *Client, token, owner, spender common.Address) (MappingSlot, error) {
+	return DefaultSlotFinder.AllowanceSlot(ctx, c, token, owner, spender)
+}
+
+var (
+	balanceOfSelector = []byte{0x70, 0xa0, 0x82, 0x31} // balanceOf(address)
+	allowanceSelector = []byte{0xdd, 0x62, 0xed, 0x3e} // allowance(address,address)
+
+	// probeValue is written to the candidate slots, a value no real balance holds
+	probeValue = new(big.Int).SetBytes(common.FromHex("0x5107f1d3c0ffee5107f1d3c0ffee5107f1d3"))
+)
+
+// BalanceSlot returns the balance mapping slot of token, probing with balanceOf(holder)
+// any holder works, holder only has to be accepted by balanceOf
+func (f *SlotFinder) BalanceSlot(ctx context.Context, c *Client, token, holder common.Address) (MappingSlot, error) {
+	data := append(common.CopyBytes(balanceOfSelector), common.LeftPadBytes(holder.Bytes(), 32)...)
+	return f.discover(ctx, c, token, SlotKindBalance, data, func(slot MappingSlot) common.Hash {
+		return slot.Key(holder)
+	})
+}
+
+// AllowanceSlot returns the allowance mapping slot of token, probing with allowance(owner, spender)
+func (f *SlotFinder) AllowanceSlot(ctx context.Context, c *Client, token, owner, spender common.Address) (MappingSlot, error) {
+	data := append(common.CopyBytes(allowanceSelector), common.LeftPadBytes(owner.Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(spender.Bytes(), 32)...)
+	return f.discover(ctx, c, token, SlotKindAllowance, data, func(slot MappingSlot) common.Hash {
+		return slot.NestedKey(owner, spender)
+	})
+}
+
+// discover probes every candidate slot and layout in one pipelined batch
+func (f *SlotFinder) discover(ctx context.Context, c *Client, token common.Address, kind SlotKind, data []byte, key func(MappingSlot) common.Hash) (MappingSlot, error) {
+	if f.Store != nil {
+		if slot, ok := f.Store.Load(token, kind); ok {
+			return slot, nil
+		}
+	}
+	maxSlot := f.MaxSlot
+	if maxSlot == 0 {
+		maxSlot = 100
+	}
+
+	word, _ := encodeWord(probeValue, false)
+	callMsg := CallMsg{To: &token, Data: data}
+	var candidates []MappingSlot
+	var requests []*Request
+	for slot := uint64(0); slot <= maxSlot; slot++ {
+		for _, layout := range []SlotLayout{LayoutSolidity, LayoutVyper} {
+			candidate := MappingSlot{Slot: slot, Layout: layout}
+			overrides := map[common.Address]StateOverride{
+				token: {StateDiff: map[string]string{key(candidate).Hex(): hexutil.Encode(word)}},
+			}
+			request, err := Build_eth_call_request_at(0, callMsg, overrides, f.Block)
+			if err != nil {
+				return MappingSlot{}, err
+			}
+			candidates = append(candidates, candidate)
+			requests = append(requests, request)
+		}
+	}
+
+	responses, err := c.CallBatch(ctx, requests)
+	if err != nil {
+		return MappingSlot{}, fmt.Errorf("failed to probe %s slots of %s: %w", kind, token.Hex(), err)
+	}
+	for i, response := range responses {
+		if response.Error != nil {
+			continue
+		}
+		var result hexutil.Bytes
+		if err := json.Unmarshal(response.Result, &result); err != nil || len(result) < 32 {
+			continue
+		}
+		if new(big.Int).SetBytes(result[:32]).Cmp(probeValue) != 0 {
+			continue
+		}
+		slot := candidates[i]
+		if f.Store != nil {
+			if err := f.Store.Store(token, kind, slot); err != nil {
+				return slot, err
+			}
+		}
+		return slot, nil
+	}
+	return MappingSlot{}, fmt.Errorf("%s slot of %s in [0, %d]: %w", kind, token.Hex(), maxSlot, ErrSlotNotFound)
+}
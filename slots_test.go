@@ -0,0 +1,193 @@
+package wsClient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// stubToken answers balanceOf and allowance from the storage slot of its mappings,
+// reading the eth_call state overrides; other slots hold zero
+func stubToken(t *testing.T, balance, allowance MappingSlot, calls *int32) *Client {
+	return newStubNode(t, func(request *Request, write func(string)) {
+		atomic.AddInt32(calls, 1)
+		var params []json.RawMessage
+		raw, _ := json.Marshal(request.Params)
+		if err := json.Unmarshal(raw, &params); err != nil || len(params) != 3 {
+			write(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"error":{"code":-32602,"message":"want a call with overrides"}}`, request.ID))
+			return
+		}
+		var msg CallMsg
+		var overrides map[common.Address]StateOverride
+		json.Unmarshal(params[0], &msg)
+		json.Unmarshal(params[2], &overrides)
+
+		var slot common.Hash
+		switch data := msg.Data; {
+		case bytes.HasPrefix(data, balanceOfSelector) && len(data) == 36:
+			slot = balance.Key(common.BytesToAddress(data[4:36]))
+		case bytes.HasPrefix(data, allowanceSelector) && len(data) == 68:
+			slot = allowance.NestedKey(common.BytesToAddress(data[4:36]), common.BytesToAddress(data[36:68]))
+		default:
+			write(fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"error":{"code":3,"message":"execution reverted"}}`, request.ID))
+			return
+		}
+		value := common.Hash{}.Hex()
+		if stored, ok := overrides[*msg.To].StateDiff[slot.Hex()]; ok {
+			value = stored
+		}
+		write(result(request.ID, `"`+value+`"`))
+	}).dial(t)
+}
+
+func TestSlotFinder(t *testing.T) {
+	token := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	// holders equal to a slot number hash the same in both layouts
+	owner := common.HexToAddress("0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045")
+	spender := common.HexToAddress("0x7a250d5630B4cF539739dF2C5dAcb4c659F2488D")
+	tests := []struct {
+		name      string
+		balance   MappingSlot
+		allowance MappingSlot
+	}{
+		{"solidity", MappingSlot{Slot: 3, Layout: LayoutSolidity}, MappingSlot{Slot: 4, Layout: LayoutSolidity}},
+		{"vyper", MappingSlot{Slot: 1, Layout: LayoutVyper}, MappingSlot{Slot: 2, Layout: LayoutVyper}},
+		{"high slot", MappingSlot{Slot: 51, Layout: LayoutSolidity}, MappingSlot{Slot: 52, Layout: LayoutVyper}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			client := stubToken(t, tt.balance, tt.allowance, &calls)
+			finder := NewSlotFinder(NewMemorySlotStore())
+			ctx := context.Background()
+
+			balance, err := finder.BalanceSlot(ctx, client, token, owner)
+			if err != nil || balance != tt.balance {
+				t.Errorf("BalanceSlot = %s, %v, want %s", balance, err, tt.balance)
+			}
+			allowance, err := finder.AllowanceSlot(ctx, client, token, owner, spender)
+			if err != nil || allowance != tt.allowance {
+				t.Errorf("AllowanceSlot = %s, %v, want %s", allowance, err, tt.allowance)
+			}
+
+			// the second lookups are served by the store, with any holder
+			probes := atomic.LoadInt32(&calls)
+			if slot, err := finder.BalanceSlot(ctx, client, token, spender); err != nil || slot != tt.balance {
+				t.Errorf("cached BalanceSlot = %s, %v", slot, err)
+			}
+			if slot, err := finder.AllowanceSlot(ctx, client, token, spender, owner); err != nil || slot != tt.allowance {
+				t.Errorf("cached AllowanceSlot = %s, %v", slot, err)
+			}
+			if n := atomic.LoadInt32(&calls); n != probes {
+				t.Errorf("cached lookups sent %d calls", n-probes)
+			}
+		})
+	}
+}
+
+func TestSlotFinderNotFound(t *testing.T) {
+	// a balance past MaxSlot, like a rebasing token the overrides cannot move
+	var calls int32
+	client := stubToken(t, MappingSlot{Slot: 200}, MappingSlot{Slot: 201}, &calls)
+	store := NewMemorySlotStore()
+	finder := NewSlotFinder(store)
+	finder.MaxSlot = 10
+
+	token := common.HexToAddress("0xae7ab96520DE3A18E5e111B5EaAb095312D7fE84")
+	if _, err := finder.BalanceSlot(context.Background(), client, token, common.HexToAddress("0x01")); !errors.Is(err, ErrSlotNotFound) {
+		t.Errorf("BalanceSlot err = %v, want ErrSlotNotFound", err)
+	}
+	// both layouts of slots 0 to 10
+	if n := atomic.LoadInt32(&calls); n != 22 {
+		t.Errorf("probed %d candidates, want 22", n)
+	}
+	if _, ok := store.Load(token, SlotKindBalance); ok {
+		t.Error("a missing slot was stored")
+	}
+}
+
+func TestFileSlotStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slots.json")
+	weth := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	crv := common.HexToAddress("0xD533a949740bb3306d119CC777fa900bA034cd52")
+
+	// a missing file is an empty store
+	store, err := NewFileSlotStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := store.Load(weth, SlotKindBalance); ok {
+		t.Error("empty store loaded a slot")
+	}
+	slots := []struct {
+		token common.Address
+		kind  SlotKind
+		slot  MappingSlot
+	}{
+		{weth, SlotKindBalance, MappingSlot{Slot: 3, Layout: LayoutSolidity}},
+		{weth, SlotKindAllowance, MappingSlot{Slot: 4, Layout: LayoutSolidity}},
+		{crv, SlotKindBalance, MappingSlot{Slot: 3, Layout: LayoutVyper}},
+	}
+	for _, s := range slots {
+		if err := store.Store(s.token, s.kind, s.slot); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// reopened, the store has the slots and the file names the layouts
+	reopened, err := NewFileSlotStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range slots {
+		if slot, ok := reopened.Load(s.token, s.kind); !ok || slot != s.slot {
+			t.Errorf("%s %s = %s, %t, want %s", s.token.Hex(), s.kind, slot, ok, s.slot)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte(`"layout": "vyper"`)) {
+		t.Errorf("slot store file = %s", data)
+	}
+	// the temporary files are renamed over the store
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Errorf("%d files next to the store, want 1", len(entries))
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileSlotStore(path); err == nil {
+		t.Error("a corrupt store was opened")
+	}
+}
+
+func TestSlotOverrides(t *testing.T) {
+	token := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	owner, spender := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+	balance, err := BuildBalanceOverride(token, owner, MappingSlot{Slot: 3}, probeValue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	allowance, err := BuildAllowanceOverride(token, owner, spender, MappingSlot{Slot: 4}, probeValue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	merged := MergeStateOverrides(balance, allowance)
+	word := hexutil.Encode(common.LeftPadBytes(probeValue.Bytes(), 32))
+	diff := merged[token].StateDiff
+	if len(diff) != 2 || diff[MappingSlot{Slot: 3}.Key(owner).Hex()] != word || diff[MappingSlot{Slot: 4}.NestedKey(owner, spender).Hex()] != word {
+		t.Errorf("merged overrides = %v", diff)
+	}
+}
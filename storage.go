@@ -0,0 +1,314 @@
+package wsClient
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Storage slot computation following the Solidity and Vyper storage layouts
+// Solidity packs variables smaller than 32 bytes into one slot starting from the
+// low-order bytes, Vyper gives every variable its own slot.
+
+// SlotOf returns the slot with the given index
+func SlotOf(index uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(index))
+}
+
+// AddSlot returns slot + n, e.g. the n-th slot of a struct stored at slot
+func AddSlot(slot common.Hash, n uint64) common.Hash {
+	sum := new(big.Int).Add(slot.Big(), new(big.Int).SetUint64(n))
+	return common.BigToHash(sum.And(sum, tt256m1))
+}
+
+// tt256m1 is 2^256 - 1, slot arithmetic wraps around
+var tt256m1 = new(big.Int).Sub(two256, big.NewInt(1))
+
+// AddressKey returns a mapping key for an address
+func AddressKey(key common.Address) common.Hash {
+	return common.BytesToHash(key.Bytes())
+}
+
+// UintKey returns a mapping key for an unsigned integer
+func UintKey(key *big.Int) common.Hash {
+	return common.BigToHash(key)
+}
+
+// MappingElementSlot returns the slot of mapping[key] for the mapping declared at slot
+// key is a value type left padded to 32 bytes, see AddressKey and UintKey
+func MappingElementSlot(slot common.Hash, key common.Hash, layout SlotLayout) common.Hash {
+	if layout == LayoutVyper {
+		return crypto.Keccak256Hash(slot.Bytes(), key.Bytes())
+	}
+	return crypto.Keccak256Hash(key.Bytes(), slot.Bytes())
+}
+
+// MappingBytesElementSlot returns the slot of mapping[key] for a Solidity mapping with a
+// string or bytes key, which is hashed unpadded
+func MappingBytesElementSlot(slot common.Hash, key []byte) common.Hash {
+	return crypto.Keccak256Hash(key, slot.Bytes())
+}
+
+// NestedMappingSlot returns the slot of mapping[keys[0]][keys[1]]... for the mapping
+// declared at slot, e.g. allowance[owner][spender]
+func NestedMappingSlot(slot common.Hash, layout SlotLayout, keys ...common.Hash) common.Hash {
+	for _, key := range keys {
+		slot = MappingElementSlot(slot, key, layout)
+	}
+	return slot
+}
+
+// StorageLocation is a value stored in Size bytes of Slot, Offset bytes from the
+// low-order end of the word
+type StorageLocation struct {
+	Slot   common.Hash
+	Offset uint8
+	Size   uint8
+}
+
+// FullSlot returns the location of a value occupying a whole slot
+func FullSlot(slot common.Hash) StorageLocation {
+	return StorageLocation{Slot: slot, Size: 32}
+}
+
+// PackedField returns the location of a packed variable of size bytes at offset in slot,
+// e.g. PackedField(SlotOf(8), 14, 14) for reserve1 of a UniswapV2 pair
+func PackedField(slot common.Hash, offset, size uint8) StorageLocation {
+	return StorageLocation{Slot: slot, Offset: offset, Size: size}
+}
+
+// validate checks the value fits in the slot
+func (l StorageLocation) validate() error {
+	if l.Size == 0 || int(l.Offset)+int(l.Size) > 32 {
+		return fmt.Errorf("invalid storage location: offset %d size %d", l.Offset, l.Size)
+	}
+	return nil
+}
+
+// span returns the byte range of the value in the big-endian word
+func (l StorageLocation) span() (int, int) {
+	end := 32 - int(l.Offset)
+	return end - int(l.Size), end
+}
+
+// Read extracts the value from the word stored at l.Slot
+func (l StorageLocation) Read(word common.Hash) (*big.Int, error) {
+	if err := l.validate(); err != nil {
+		return nil, err
+	}
+	start, end := l.span()
+	return new(big.Int).SetBytes(word[start:end]), nil
+}
+
+// Write returns word with the value written at the location, leaving the other bytes untouched
+func (l StorageLocation) Write(word common.Hash, value *big.Int) (common.Hash, error) {
+	if err := l.validate(); err != nil {
+		return word, err
+	}
+	if value == nil || value.Sign() < 0 || value.BitLen() > int(l.Size)*8 {
+		return word, fmt.Errorf("value %v does not fit in %d bytes", value, l.Size)
+	}
+	start, end := l.span()
+	value.FillBytes(word[start:end])
+	return word, nil
+}
+
+// ArrayElement returns the location of element index of a Solidity dynamic array declared
+// at slot. elementSize is the size in bytes of a packed element (e.g. 20 for address[]),
+// or 32 times the number of slots of a struct element.
+func ArrayElement(slot common.Hash, index uint64, elementSize uint64) (StorageLocation, error) {
+	if elementSize == 0 || (elementSize > 32 && elementSize%32 != 0) {
+		return StorageLocation{}, fmt.Errorf("invalid array element size %d", elementSize)
+	}
+	return arrayElement(crypto.Keccak256Hash(slot.Bytes()), index, elementSize), nil
+}
+
+// FixedArrayElement returns the location of element index of a Solidity fixed size array
+// stored from slot, elementSize as in ArrayElement
+func FixedArrayElement(slot common.Hash, index uint64, elementSize uint64) (StorageLocation, error) {
+	if elementSize == 0 || (elementSize > 32 && elementSize%32 != 0) {
+		return StorageLocation{}, fmt.Errorf("invalid array element size %d", elementSize)
+	}
+	return arrayElement(slot, index, elementSize), nil
+}
+
+func arrayElement(base common.Hash, index uint64, elementSize uint64) StorageLocation {
+	if elementSize > 16 {
+		// elements larger than half a slot are not packed
+		slots := (elementSize + 31) / 32
+		return StorageLocation{Slot: AddSlot(base, index*slots), Size: uint8(min(elementSize, 32))}
+	}
+	perSlot := 32 / elementSize
+	return StorageLocation{
+		Slot:   AddSlot(base, index/perSlot),
+		Offset: uint8((index % perSlot) * elementSize),
+		Size:   uint8(elementSize),
+	}
+}
+
+// VyperDynArrayElement returns the slot of element index of a Vyper DynArray declared at
+// slot; the length is stored at slot and the elements inline after it, elementSlots
+// slots each
+func VyperDynArrayElement(slot common.Hash, index uint64, elementSlots uint64) common.Hash {
+	return AddSlot(slot, 1+index*elementSlots)
+}
+
+// StructField returns the location of a struct member stored at slot, given the slot
+// and byte offset of the member within the struct
+func StructField(slot common.Hash, fieldSlot uint64, offset, size uint8) StorageLocation {
+	return StorageLocation{Slot: AddSlot(slot, fieldSlot), Offset: offset, Size: size}
+}
+
+// UniswapV2 pairs pack reserve0 (uint112), reserve1 (uint112) and blockTimestampLast (uint32) in slot 8
+var (
+	UniswapV2Reserve0           = PackedField(SlotOf(8), 0, 14)
+	UniswapV2Reserve1           = PackedField(SlotOf(8), 14, 14)
+	UniswapV2BlockTimestampLast = PackedField(SlotOf(8), 28, 4)
+)
+
+// PackedOverride builds a state override writing packed fields of a contract without
+// clobbering the other values sharing their slots
+//
+//	override := wsClient.NewPackedOverride(pair).
+//		Set(wsClient.UniswapV2Reserve0, reserve0).
+//		Set(wsClient.UniswapV2Reserve1, reserve1)
+//	stateOverrides, err := override.Build(ctx, client, block)
+type PackedOverride struct {
+	contract common.Address
+	fields   []packedWrite
+	err      error
+}
+
+type packedWrite struct {
+	location StorageLocation
+	value    *big.Int
+}
+
+// NewPackedOverride creates an empty override of contract
+func NewPackedOverride(contract common.Address) *PackedOverride {
+	return &PackedOverride{contract: contract}
+}
+
+// Set writes value at location, later writes win on overlapping bytes
+func (o *PackedOverride) Set(location StorageLocation, value *big.Int) *PackedOverride {
+	if o.err == nil {
+		if _, err := location.Write(common.Hash{}, value); err != nil {
+			o.err = err
+		}
+	}
+	o.fields = append(o.fields, packedWrite{location, value})
+	return o
+}
+
+// Slots returns the slots written by the override
+func (o *PackedOverride) Slots() []common.Hash {
+	var slots []common.Hash
+	seen := make(map[common.Hash]bool)
+	for _, field := range o.fields {
+		if !seen[field.location.Slot] {
+			seen[field.location.Slot] = true
+			slots = append(slots, field.location.Slot)
+		}
+	}
+	return slots
+}
+
+// covers reports whether the fields overwrite every byte of slot, so the current
+// value is not needed
+func (o *PackedOverride) covers(slot common.Hash) bool {
+	var written [32]bool
+	for _, field := range o.fields {
+		if field.location.Slot != slot {
+			continue
+		}
+		start, end := field.location.span()
+		for i := start; i < end; i++ {
+			written[i] = true
+		}
+	}
+	for _, ok := range written {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply writes the fields over the current words of their slots; a slot missing from
+// current starts from zero
+func (o *PackedOverride) Apply(current map[common.Hash]common.Hash) (map[common.Address]StateOverride, error) {
+	if o.err != nil {
+		return nil, o.err
+	}
+	words := make(map[common.Hash]common.Hash)
+	for _, field := range o.fields {
+		word, ok := words[field.location.Slot]
+		if !ok {
+			word = current[field.location.Slot]
+		}
+		word, err := field.location.Write(word, field.value)
+		if err != nil {
+			return nil, err
+		}
+		words[field.location.Slot] = word
+	}
+	stateDiff := make(map[string]string, len(words))
+	for slot, word := range words {
+		stateDiff[slot.Hex()] = hexutil.Encode(word.Bytes())
+	}
+	return map[common.Address]StateOverride{o.contract: {StateDiff: stateDiff}}, nil
+}
+
+// Build reads the current words of the slots that are only partly written at block
+// and returns the override
+func (o *PackedOverride) Build(ctx context.Context, c *Client, block BlockRef) (map[common.Address]StateOverride, error) {
+	if o.err != nil {
+		return nil, o.err
+	}
+	var slots []common.Hash
+	var requests []*Request
+	for _, slot := range o.Slots() {
+		if o.covers(slot) {
+			continue
+		}
+		request, err := BuildRequestGetStorageAt(o.contract, slot, block)
+		if err != nil {
+			return nil, err
+		}
+		slots = append(slots, slot)
+		requests = append(requests, request)
+	}
+
+	current := make(map[common.Hash]common.Hash, len(slots))
+	if len(requests) > 0 {
+		responses, err := c.CallBatch(ctx, requests)
+		if err != nil {
+			return nil, err
+		}
+		for i, response := range responses {
+			if response.Error != nil {
+				return nil, fmt.Errorf("failed to read slot %s: %w", slots[i].Hex(), response.Error)
+			}
+			var word hexutil.Bytes
+			if err := word.UnmarshalJSON(response.Result); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal slot %s: %w", slots[i].Hex(), err)
+			}
+			current[slots[i]] = common.BytesToHash(word)
+		}
+	}
+	return o.Apply(current)
+}
+
+// BuildUniswapV2ReservesOverride overrides the reserves of a UniswapV2 pair; the three
+// fields fill slot 8 so no read is needed
+func BuildUniswapV2ReservesOverride(pair common.Address, reserve0, reserve1 *big.Int, blockTimestampLast uint32) (map[common.Address]StateOverride, error) {
+	return NewPackedOverride(pair).
+		Set(UniswapV2Reserve0, reserve0).
+		Set(UniswapV2Reserve1, reserve1).
+		Set(UniswapV2BlockTimestampLast, new(big.Int).SetUint64(uint64(blockTimestampLast))).
+		Apply(nil)
+}
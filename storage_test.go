@@ -0,0 +1,147 @@
+package wsClient
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// well-known storage hashes
+var (
+	keccakZeroWord = common.HexToHash("0x290decd9548b62a8d60345a988386fc84ba6bc95484008f6362f93160ef3e563") // keccak256(uint256(0))
+	keccakOneWord  = common.HexToHash("0xb10e2d527612073b26eecdfd717e6a320cf44b4afac2b0732d9fcbe2b7fa0cf6") // keccak256(uint256(1))
+	keccakTwoWords = common.HexToHash("0xad3228b676f7d3cd4284a5443f17f1962b36e491b30a40b2405849e597ba5fb5") // keccak256(uint256(0), uint256(0))
+)
+
+func TestSlotMath(t *testing.T) {
+	max := common.HexToHash("0x" + strings.Repeat("f", 64))
+	tests := []struct {
+		name string
+		got  common.Hash
+		want common.Hash
+	}{
+		{"slot", SlotOf(8), common.HexToHash("0x08")},
+		{"add", AddSlot(SlotOf(8), 3), common.HexToHash("0x0b")},
+		{"add wraps", AddSlot(max, 2), common.HexToHash("0x01")},
+		{"mapping zero key", MappingElementSlot(SlotOf(0), AddressKey(common.Address{}), LayoutSolidity), keccakTwoWords},
+		{"vyper zero key", MappingElementSlot(SlotOf(0), UintKey(new(big.Int)), LayoutVyper), keccakTwoWords},
+		{"nested", NestedMappingSlot(SlotOf(0), LayoutSolidity, common.Hash{}, common.Hash{}),
+			MappingElementSlot(keccakTwoWords, common.Hash{}, LayoutSolidity)},
+		{"mapping slot", MappingSlot{Slot: 0}.NestedKey(common.Address{}, common.Address{}),
+			NestedMappingSlot(SlotOf(0), LayoutSolidity, common.Hash{}, common.Hash{})},
+		{"vyper dyn array", VyperDynArrayElement(SlotOf(4), 2, 3), common.HexToHash("0x0b")},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %s, want %s", tt.name, tt.got.Hex(), tt.want.Hex())
+		}
+	}
+
+	// the order of key and slot differs between the layouts
+	key := AddressKey(common.HexToAddress("0x01"))
+	if MappingElementSlot(SlotOf(3), key, LayoutSolidity) == MappingElementSlot(SlotOf(3), key, LayoutVyper) {
+		t.Error("solidity and vyper mapping slots are equal")
+	}
+}
+
+func TestArrayElement(t *testing.T) {
+	tests := []struct {
+		name    string
+		slot    uint64
+		index   uint64
+		size    uint64
+		want    StorageLocation
+		wantErr bool
+	}{
+		{"uint256[]", 0, 0, 32, StorageLocation{Slot: keccakZeroWord, Size: 32}, false},
+		{"uint256[] index", 1, 5, 32, StorageLocation{Slot: AddSlot(keccakOneWord, 5), Size: 32}, false},
+		{"address[] packs one per slot", 0, 3, 20, StorageLocation{Slot: AddSlot(keccakZeroWord, 3), Size: 20}, false},
+		{"uint128[] packs two per slot", 0, 3, 16, StorageLocation{Slot: AddSlot(keccakZeroWord, 1), Offset: 16, Size: 16}, false},
+		{"uint8[] packs 32 per slot", 1, 33, 1, StorageLocation{Slot: AddSlot(keccakOneWord, 1), Offset: 1, Size: 1}, false},
+		{"struct of three slots", 0, 2, 96, StorageLocation{Slot: AddSlot(keccakZeroWord, 6), Size: 32}, false},
+		{"zero size", 0, 0, 0, StorageLocation{}, true},
+		{"unaligned struct", 0, 0, 40, StorageLocation{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ArrayElement(SlotOf(tt.slot), tt.index, tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	fixed, err := FixedArrayElement(SlotOf(2), 3, 16)
+	if err != nil || fixed != (StorageLocation{Slot: SlotOf(3), Offset: 16, Size: 16}) {
+		t.Errorf("FixedArrayElement = %+v, %v", fixed, err)
+	}
+}
+
+func TestStorageLocationReadWrite(t *testing.T) {
+	// reserve0 = 1, reserve1 = 2, blockTimestampLast = 3
+	word := common.HexToHash("0x00000003" + fmt.Sprintf("%028x", 2) + fmt.Sprintf("%028x", 1))
+	for location, want := range map[StorageLocation]int64{
+		UniswapV2Reserve0:           1,
+		UniswapV2Reserve1:           2,
+		UniswapV2BlockTimestampLast: 3,
+	} {
+		got, err := location.Read(word)
+		if err != nil || got.Int64() != want {
+			t.Errorf("Read(%+v) = %v, %v, want %d", location, got, err, want)
+		}
+	}
+
+	written, err := UniswapV2Reserve1.Write(word, big.NewInt(0xabcd))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := common.HexToHash("0x00000003" + fmt.Sprintf("%028x", 0xabcd) + fmt.Sprintf("%028x", 1)); written != want {
+		t.Errorf("Write = %s, want %s", written.Hex(), want.Hex())
+	}
+
+	tooLarge := new(big.Int).Lsh(big.NewInt(1), 112)
+	if _, err := UniswapV2Reserve0.Write(word, tooLarge); err == nil {
+		t.Error("a 113-bit value fits in uint112")
+	}
+	if _, err := PackedField(SlotOf(0), 20, 13).Read(word); err == nil {
+		t.Error("a field past the end of the slot is valid")
+	}
+}
+
+func TestPackedOverride(t *testing.T) {
+	pair := common.HexToAddress("0x0d4a11d5EEaaC28EC3F61d100daF4d40471f1852")
+	slot := UniswapV2Reserve0.Slot.Hex()
+
+	full, err := BuildUniswapV2ReservesOverride(pair, big.NewInt(1), big.NewInt(2), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "0x00000003" + fmt.Sprintf("%028x", 2) + fmt.Sprintf("%028x", 1)
+	if got := full[pair].StateDiff[slot]; got != want {
+		t.Errorf("reserves = %s, want %s", got, want)
+	}
+
+	// a partial write reads the current word and keeps the other fields
+	var reads int32
+	node := newStubNode(t, func(request *Request, write func(string)) {
+		atomic.AddInt32(&reads, 1)
+		write(result(request.ID, `"0x0000000a`+fmt.Sprintf("%028x", 20)+fmt.Sprintf("%028x", 10)+`"`))
+	})
+	client := node.dial(t)
+	partial, err := NewPackedOverride(pair).Set(UniswapV2Reserve1, big.NewInt(99)).Build(context.Background(), client, BlockRef{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = "0x0000000a" + fmt.Sprintf("%028x", 99) + fmt.Sprintf("%028x", 10)
+	if got := partial[pair].StateDiff[slot]; got != want || atomic.LoadInt32(&reads) != 1 {
+		t.Errorf("reserve1 override = %s after %d reads, want %s", got, reads, want)
+	}
+}
@@ -0,0 +1,147 @@
+package wsClient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// BuildRequestSubscribe creates an eth_subscribe request, e.g.
+// BuildRequestSubscribe("newPendingTransactions", true) for full pending transactions
+func BuildRequestSubscribe(kind string, args ...interface{}) *Request {
+	return NewRequest(0, "eth_subscribe", append([]interface{}{kind}, args...))
+}
+
+// BuildRequestUnsubscribe creates an eth_unsubscribe request for a subscription id
+func BuildRequestUnsubscribe(subscriptionID string) *Request {
+	return NewRequest(0, "eth_unsubscribe", []interface{}{subscriptionID})
+}
+
+var subscriptionMethod = []byte(`"eth_subscription"`)
+
+// subscriptionParams is the params member of a subscription notification
+type subscriptionParams struct {
+	Subscription string          `json:"subscription"`
+	Result       json.RawMessage `json:"result"`
+}
+
+// Subscribe sends an eth_subscribe request and passes the result of every notification
+// to fn until ctx is done, fn returns an error or the connection fails.
+// Notifications are routed by subscription id, so the connection can carry calls and
+// other subscriptions at the same time; the subscription is cancelled with
+// eth_unsubscribe when Subscribe returns.
+// A rejected subscription is returned as *RPCError.
+func (c *Client) Subscribe(ctx context.Context, request *Request, fn func(result json.RawMessage) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	ids := []int64{request.ID}
+	ch := make(chan *bytes.Buffer, 1)
+	notifications := newFrameQueue()
+	reader, err := c.register(ids, ch, notifications)
+	if err != nil {
+		return err
+	}
+	if err := c.Send(request); err != nil {
+		c.abandon(ids, 0, ch)
+		return err
+	}
+	// the reader may register the subscription just before an abandon
+	defer func() {
+		c.unsubscribe(notifications)
+		notifications.release()
+	}()
+
+	var subscriptionID string
+	select {
+	case buf := <-ch:
+		atomic.AddInt32(&c.counter, -1)
+		err = decodeSubscriptionID(buf, &subscriptionID)
+		putBuffer(buf)
+	case <-ctx.Done():
+		err = ctx.Err()
+	case <-reader.done:
+		err = reader.err
+	}
+	if err != nil {
+		c.abandon(ids, 1, ch)
+		return err
+	}
+
+	for {
+		buf, err := notifications.next(ctx, reader)
+		if err != nil {
+			return err
+		}
+		var notification subscriptionParams
+		err = decodeNotification(buf, &notification)
+		putBuffer(buf)
+		if err != nil {
+			return err
+		}
+		if err := fn(notification.Result); err != nil {
+			return err
+		}
+	}
+}
+
+// decodeSubscriptionID decodes the response to an eth_subscribe request
+func decodeSubscriptionID(buf *bytes.Buffer, subscriptionID *string) error {
+	var env Envelope
+	if err := ScanEnvelope(buf.Bytes(), &env); err != nil {
+		return err
+	}
+	if env.Error != nil {
+		return decodeRPCError(env.Error)
+	}
+	if err := json.Unmarshal(env.Result, subscriptionID); err != nil {
+		return fmt.Errorf("failed to unmarshal subscription id: %w", err)
+	}
+	return nil
+}
+
+// decodeNotification decodes the params of a subscription notification
+func decodeNotification(buf *bytes.Buffer, notification *subscriptionParams) error {
+	var env Envelope
+	if err := ScanEnvelope(buf.Bytes(), &env); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(env.Params, notification); err != nil {
+		return fmt.Errorf("failed to unmarshal notification: %w", err)
+	}
+	return nil
+}
+
+// unsubscribeTimeout bounds the wait for the eth_unsubscribe response
+const unsubscribeTimeout = 5 * time.Second
+
+// unsubscribe cancels the subscriptions routed to q with eth_unsubscribe
+// notifications still in flight are dropped until the node confirms, the response
+// is read so it does not stay in PendingCounter
+func (c *Client) unsubscribe(q *frameQueue) {
+	c.mu.Lock()
+	var ids []string
+	for id, queue := range c.subscriptions {
+		if queue == q {
+			c.subscriptions[id] = nil
+			ids = append(ids, id)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, id := range ids {
+		ctx, cancel := context.WithTimeout(context.Background(), unsubscribeTimeout)
+		c.Call(ctx, BuildRequestUnsubscribe(id), nil)
+		cancel()
+
+		c.mu.Lock()
+		if queue, ok := c.subscriptions[id]; ok && queue == nil {
+			delete(c.subscriptions, id)
+		}
+		c.mu.Unlock()
+	}
+}
@@ -0,0 +1,292 @@
+package wsClient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Built-in tracer names
+const (
+	CallTracer     = "callTracer"
+	PrestateTracer = "prestateTracer"
+)
+
+// TraceConfig configures debug_traceTransaction
+// An empty Tracer selects the struct logger, whose options are the Enable*/Disable* fields
+type TraceConfig struct {
+	Tracer       string      `json:"tracer,omitempty"`
+	TracerConfig interface{} `json:"tracerConfig,omitempty"`
+	Timeout      string      `json:"timeout,omitempty"` // e.g. "10s"
+
+	// struct logger options
+	EnableMemory     bool `json:"enableMemory,omitempty"`
+	DisableStack     bool `json:"disableStack,omitempty"`
+	DisableStorage   bool `json:"disableStorage,omitempty"`
+	EnableReturnData bool `json:"enableReturnData,omitempty"`
+	Limit            int  `json:"limit,omitempty"`
+}
+
+// TraceCallConfig configures debug_traceCall
+type TraceCallConfig struct {
+	TraceConfig
+	StateOverrides map[common.Address]StateOverride `json:"stateOverrides,omitempty"`
+	BlockOverrides *BlockOverrides                  `json:"blockOverrides,omitempty"`
+}
+
+// BlockOverrides overrides the block context of a simulated call
+type BlockOverrides struct {
+	Number      *hexutil.Big    `json:"number,omitempty"`
+	Difficulty  *hexutil.Big    `json:"difficulty,omitempty"`
+	Time        *hexutil.Uint64 `json:"time,omitempty"`
+	GasLimit    *hexutil.Uint64 `json:"gasLimit,omitempty"`
+	Coinbase    *common.Address `json:"coinbase,omitempty"`
+	Random      *common.Hash    `json:"random,omitempty"`
+	BaseFee     *hexutil.Big    `json:"baseFee,omitempty"`
+	BlobBaseFee *hexutil.Big    `json:"blobBaseFee,omitempty"`
+}
+
+// CallTracerConfig selects the callTracer
+// withLog records the logs emitted by each frame, onlyTopCall skips the sub calls
+func CallTracerConfig(withLog, onlyTopCall bool) TraceConfig {
+	return TraceConfig{
+		Tracer: CallTracer,
+		TracerConfig: map[string]bool{
+			"withLog":     withLog,
+			"onlyTopCall": onlyTopCall,
+		},
+	}
+}
+
+// PrestateTracerConfig selects the prestateTracer
+// in diffMode the result holds the pre and post state of every touched account
+func PrestateTracerConfig(diffMode bool) TraceConfig {
+	return TraceConfig{
+		Tracer:       PrestateTracer,
+		TracerConfig: map[string]bool{"diffMode": diffMode},
+	}
+}
+
+// Build_debug_traceCall_request creates a debug_traceCall request simulating callMsg at block
+func Build_debug_traceCall_request(id int64, callMsg CallMsg, block BlockRef, config TraceCallConfig) (*Request, error) {
+	if err := block.Validate(); err != nil {
+		return nil, err
+	}
+	return NewRequest(id, "debug_traceCall", []interface{}{callMsg, block.param(), config}), nil
+}
+
+// Build_debug_traceTransaction_request creates a debug_traceTransaction request for a mined transaction
+func Build_debug_traceTransaction_request(id int64, txHash common.Hash, config TraceConfig) *Request {
+	return NewRequest(id, "debug_traceTransaction", []interface{}{txHash, config})
+}
+
+// CallLog is a log recorded by the callTracer with withLog
+type CallLog struct {
+	Address  common.Address `json:"address"`
+	Topics   []common.Hash  `json:"topics"`
+	Data     hexutil.Bytes  `json:"data"`
+	Position hexutil.Uint   `json:"position"` // index of the sub call the log precedes
+}
+
+// CallFrame is a node of the callTracer call tree
+type CallFrame struct {
+	Type         string          `json:"type"` // CALL, STATICCALL, DELEGATECALL, CREATE...
+	From         common.Address  `json:"from"`
+	To           *common.Address `json:"to,omitempty"`
+	Value        *hexutil.Big    `json:"value,omitempty"`
+	Gas          hexutil.Uint64  `json:"gas"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	Input        hexutil.Bytes   `json:"input"`
+	Output       hexutil.Bytes   `json:"output,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Calls        []*CallFrame    `json:"calls,omitempty"`
+	Logs         []CallLog       `json:"logs,omitempty"`
+}
+
+// Failed reports whether the frame reverted or errored
+func (f *CallFrame) Failed() bool {
+	return f.Error != ""
+}
+
+// Walk visits the frame and its sub calls depth first, path holds the sub call
+// indexes leading to each frame; returning false stops the walk
+func (f *CallFrame) Walk(fn func(frame *CallFrame, path []int) bool) {
+	f.walk(nil, fn)
+}
+
+func (f *CallFrame) walk(path []int, fn func(frame *CallFrame, path []int) bool) bool {
+	if !fn(f, path) {
+		return false
+	}
+	for i, call := range f.Calls {
+		if !call.walk(append(path[:len(path):len(path)], i), fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// RevertOrigin returns the deepest failed frame on the failing branch, i.e. the call
+// where the revert started, with its path from the root. It returns nil if f succeeded.
+// For a multiSwap the frame's To is the pair that reverted.
+func (f *CallFrame) RevertOrigin() (*CallFrame, []int) {
+	if !f.Failed() {
+		return nil, nil
+	}
+	origin, path := f, []int{}
+	for {
+		next := -1
+		// the last failed sub call is the one whose revert bubbled up
+		for i := len(origin.Calls) - 1; i >= 0; i-- {
+			if origin.Calls[i].Failed() {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			return origin, path
+		}
+		origin, path = origin.Calls[next], append(path, next)
+	}
+}
+
+// Revert decodes the revert data of the frame against abis, nil if the frame did not revert
+func (f *CallFrame) Revert(abis ...*abi.ABI) *RevertError {
+	if !f.Failed() {
+		return nil
+	}
+	revertErr := DecodeRevertData(f.Output, abis...)
+	if revertErr.Reason == "" && f.RevertReason != "" {
+		revertErr.Reason = f.RevertReason
+		revertErr.Sentinel = lookupRevertReason(f.RevertReason)
+	}
+	return revertErr
+}
+
+// PrestateAccount is an account as recorded by the prestateTracer
+type PrestateAccount struct {
+	Balance *hexutil.Big                `json:"balance,omitempty"`
+	Nonce   uint64                      `json:"nonce,omitempty"`
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+// PrestateResult is the prestateTracer result without diffMode
+type PrestateResult map[common.Address]*PrestateAccount
+
+// PrestateDiff is the prestateTracer result in diffMode
+// Post only holds the fields that changed
+type PrestateDiff struct {
+	Pre  map[common.Address]*PrestateAccount `json:"pre"`
+	Post map[common.Address]*PrestateAccount `json:"post"`
+}
+
+// StateOverrides converts the post state to state overrides, so a follow-up simulation
+// starts from the state the traced call left behind. Slots cleared by the call
+// (present in Pre, missing in Post) are overridden with zero.
+func (d *PrestateDiff) StateOverrides() map[common.Address]StateOverride {
+	overrides := make(map[common.Address]StateOverride, len(d.Post))
+	for address, post := range d.Post {
+		override := StateOverride{
+			Balance: post.Balance,
+			Code:    post.Code,
+		}
+		if post.Nonce != 0 {
+			nonce := hexutil.Uint64(post.Nonce)
+			override.Nonce = &nonce
+		}
+		if len(post.Storage) > 0 {
+			override.StateDiff = make(map[string]string, len(post.Storage))
+			for slot, value := range post.Storage {
+				override.StateDiff[slot.Hex()] = value.Hex()
+			}
+		}
+		if pre, ok := d.Pre[address]; ok {
+			for slot := range pre.Storage {
+				if _, ok := post.Storage[slot]; ok {
+					continue
+				}
+				if override.StateDiff == nil {
+					override.StateDiff = make(map[string]string)
+				}
+				override.StateDiff[slot.Hex()] = common.Hash{}.Hex()
+			}
+		}
+		overrides[address] = override
+	}
+	return overrides
+}
+
+// StructLog is one opcode step recorded by the struct logger
+type StructLog struct {
+	Pc            uint64            `json:"pc"`
+	Op            string            `json:"op"`
+	Gas           uint64            `json:"gas"`
+	GasCost       uint64            `json:"gasCost"`
+	Depth         int               `json:"depth"`
+	Error         string            `json:"error,omitempty"`
+	Stack         []string          `json:"stack,omitempty"`
+	ReturnData    string            `json:"returnData,omitempty"`
+	Memory        []string          `json:"memory,omitempty"`
+	Storage       map[string]string `json:"storage,omitempty"`
+	RefundCounter uint64            `json:"refund,omitempty"`
+}
+
+// StructLogResult is the struct logger result
+type StructLogResult struct {
+	Gas         uint64      `json:"gas"`
+	Failed      bool        `json:"failed"`
+	ReturnValue string      `json:"returnValue"`
+	StructLogs  []StructLog `json:"structLogs"`
+}
+
+// TraceCall runs debug_traceCall and unmarshals the tracer output into result,
+// e.g. a *CallFrame for the callTracer or a *PrestateDiff for the prestateTracer in diffMode
+func (c *Client) TraceCall(ctx context.Context, callMsg CallMsg, block BlockRef, config TraceCallConfig, result any) error {
+	request, err := Build_debug_traceCall_request(0, callMsg, block, config)
+	if err != nil {
+		return err
+	}
+	return c.Call(ctx, request, result)
+}
+
+// TraceTransaction runs debug_traceTransaction and unmarshals the tracer output into result
+func (c *Client) TraceTransaction(ctx context.Context, txHash common.Hash, config TraceConfig, result any) error {
+	return c.Call(ctx, Build_debug_traceTransaction_request(0, txHash, config), result)
+}
+
+// TraceCallTree simulates callMsg with the callTracer and returns its call tree
+func (c *Client) TraceCallTree(ctx context.Context, callMsg CallMsg, block BlockRef, stateOverrides map[common.Address]StateOverride, withLog bool) (*CallFrame, error) {
+	config := TraceCallConfig{TraceConfig: CallTracerConfig(withLog, false), StateOverrides: stateOverrides}
+	frame := new(CallFrame)
+	if err := c.TraceCall(ctx, callMsg, block, config, frame); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+// TraceCallDiff simulates callMsg with the prestateTracer in diffMode and returns the state diff
+func (c *Client) TraceCallDiff(ctx context.Context, callMsg CallMsg, block BlockRef, stateOverrides map[common.Address]StateOverride) (*PrestateDiff, error) {
+	config := TraceCallConfig{TraceConfig: PrestateTracerConfig(true), StateOverrides: stateOverrides}
+	diff := new(PrestateDiff)
+	if err := c.TraceCall(ctx, callMsg, block, config, diff); err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
+// String returns a short representation of the frame
+func (f *CallFrame) String() string {
+	to := "<create>"
+	if f.To != nil {
+		to = f.To.Hex()
+	}
+	if f.Failed() {
+		return fmt.Sprintf("%s %s -> %s (%s)", f.Type, f.From.Hex(), to, f.Error)
+	}
+	return fmt.Sprintf("%s %s -> %s", f.Type, f.From.Hex(), to)
+}
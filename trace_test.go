@@ -0,0 +1,226 @@
+package wsClient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// callTrace is a callTracer result of a multiSwap reverting in its second pair:
+// the router reads the first pair, swaps through it, then the second pair's swap
+// fails a nested transfer and the revert bubbles up to the router
+var callTrace = `{
+	"type": "CALL",
+	"from": "0x7a16ff8270133f063aab6c9977183d9e72835428",
+	"to": "0x5c69bee701ef814a2b6a3edd4b1652cb9cc5aa6f",
+	"value": "0x0",
+	"gas": "0x4c4b40",
+	"gasUsed": "0x2d3a1",
+	"input": "0x8803dbee",
+	"output": "` + errorString + `",
+	"error": "execution reverted",
+	"revertReason": "ERR_NO_PROFIT",
+	"calls": [
+		{
+			"type": "STATICCALL",
+			"from": "0x5c69bee701ef814a2b6a3edd4b1652cb9cc5aa6f",
+			"to": "0xb4e16d0168e52d35cacd2c6185b44281ec28c9dc",
+			"gas": "0x4a8c2b",
+			"gasUsed": "0x9c8",
+			"input": "0x0902f1ac",
+			"output": "0x` + strings.Repeat("0", 192) + `"
+		},
+		{
+			"type": "CALL",
+			"from": "0x5c69bee701ef814a2b6a3edd4b1652cb9cc5aa6f",
+			"to": "0xb4e16d0168e52d35cacd2c6185b44281ec28c9dc",
+			"value": "0x0",
+			"gas": "0x4a7a1e",
+			"gasUsed": "0x1a2b3",
+			"input": "0x022c0d9f",
+			"logs": [
+				{
+					"address": "0xb4e16d0168e52d35cacd2c6185b44281ec28c9dc",
+					"topics": ["0x1c411e9a96e071241c2f21f7726b17ae89e3cab4c78be50e062b03a9fffbbad1"],
+					"data": "0x",
+					"position": "0x0"
+				}
+			]
+		},
+		{
+			"type": "CALL",
+			"from": "0x5c69bee701ef814a2b6a3edd4b1652cb9cc5aa6f",
+			"to": "0x0d4a11d5eeaac28ec3f61d100daf4d40471f1852",
+			"value": "0x0",
+			"gas": "0x48a2b1",
+			"gasUsed": "0x8f3c",
+			"input": "0x022c0d9f",
+			"output": "` + errorString + `",
+			"error": "execution reverted",
+			"calls": [
+				{
+					"type": "CALL",
+					"from": "0x0d4a11d5eeaac28ec3f61d100daf4d40471f1852",
+					"to": "0xdac17f958d2ee523a2206206994597c13d831ec7",
+					"value": "0x0",
+					"gas": "0x46f1c2",
+					"gasUsed": "0x3e8",
+					"input": "0xa9059cbb",
+					"error": "execution reverted"
+				},
+				{
+					"type": "CALL",
+					"from": "0x0d4a11d5eeaac28ec3f61d100daf4d40471f1852",
+					"to": "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2",
+					"value": "0x0",
+					"gas": "0x46e0b1",
+					"gasUsed": "0x2710",
+					"input": "0xa9059cbb",
+					"output": "` + errorString + `",
+					"error": "execution reverted"
+				}
+			]
+		}
+	]
+}`
+
+// prestateDiff is a prestateTracer diffMode result of a swap: the sender pays gas and bumps
+// its nonce, the pair updates its reserves and clears its lock slot
+var prestateDiff = `{
+	"pre": {
+		"0x7a16ff8270133f063aab6c9977183d9e72835428": {"balance": "0xde0b6b3a7640000", "nonce": 7},
+		"0xb4e16d0168e52d35cacd2c6185b44281ec28c9dc": {
+			"balance": "0x0",
+			"storage": {
+				"0x0000000000000000000000000000000000000000000000000000000000000008": "0x6553f1000000000000000000000000000000000000000000000000000000aaaa",
+				"0x000000000000000000000000000000000000000000000000000000000000000c": "0x0000000000000000000000000000000000000000000000000000000000000001"
+			}
+		}
+	},
+	"post": {
+		"0x7a16ff8270133f063aab6c9977183d9e72835428": {"balance": "0xde0b6b3a763f000", "nonce": 8},
+		"0xb4e16d0168e52d35cacd2c6185b44281ec28c9dc": {
+			"storage": {
+				"0x0000000000000000000000000000000000000000000000000000000000000008": "0x6553f1100000000000000000000000000000000000000000000000000000bbbb"
+			}
+		},
+		"0x0d4a11d5eeaac28ec3f61d100daf4d40471f1852": {"code": "0x6080"}
+	}
+}`
+
+func TestCallFrameRevertOrigin(t *testing.T) {
+	var frame CallFrame
+	if err := json.Unmarshal([]byte(callTrace), &frame); err != nil {
+		t.Fatal(err)
+	}
+	if !frame.Failed() || len(frame.Calls) != 3 || len(frame.Calls[1].Logs) != 1 {
+		t.Fatalf("decoded frame = %s with %d calls", &frame, len(frame.Calls))
+	}
+
+	// the last failed sub call of the failed pair, not the first one
+	origin, path := frame.RevertOrigin()
+	if origin == nil || fmt.Sprint(path) != "[2 1]" {
+		t.Fatalf("RevertOrigin = %v, %v, want the frame at [2 1]", origin, path)
+	}
+	if *origin.To != common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2") {
+		t.Errorf("revert origin = %s", origin)
+	}
+	if revertErr := origin.Revert(); revertErr == nil || !errors.Is(revertErr, ErrNoProfit) {
+		t.Errorf("origin Revert = %v, want ErrNoProfit", revertErr)
+	}
+	// a reverted frame without output falls back to the revertReason of the tracer
+	root := frame
+	root.Output = nil
+	if revertErr := root.Revert(); revertErr == nil || revertErr.Reason != "ERR_NO_PROFIT" || !errors.Is(revertErr, ErrNoProfit) {
+		t.Errorf("root Revert = %v, want the revertReason", revertErr)
+	}
+
+	if origin, path := frame.Calls[1].RevertOrigin(); origin != nil || path != nil {
+		t.Errorf("RevertOrigin of a successful frame = %v, %v", origin, path)
+	}
+	if frame.Calls[1].Revert() != nil {
+		t.Error("a successful frame has a revert")
+	}
+	// a failed frame without failed sub calls is its own origin
+	if origin, path := frame.Calls[2].Calls[0].RevertOrigin(); origin != frame.Calls[2].Calls[0] || len(path) != 0 {
+		t.Errorf("RevertOrigin of a leaf = %v, %v", origin, path)
+	}
+}
+
+func TestCallFrameWalk(t *testing.T) {
+	var frame CallFrame
+	if err := json.Unmarshal([]byte(callTrace), &frame); err != nil {
+		t.Fatal(err)
+	}
+	var visited []string
+	frame.Walk(func(f *CallFrame, path []int) bool {
+		visited = append(visited, fmt.Sprintf("%v %s", path, f.Type))
+		return true
+	})
+	want := "[] CALL,[0] STATICCALL,[1] CALL,[2] CALL,[2 0] CALL,[2 1] CALL"
+	if got := strings.Join(visited, ","); got != want {
+		t.Errorf("Walk visited %s, want %s", got, want)
+	}
+
+	// the paths handed to fn are not overwritten by later siblings
+	var paths [][]int
+	frame.Walk(func(f *CallFrame, path []int) bool {
+		paths = append(paths, path)
+		return true
+	})
+	if fmt.Sprint(paths[4]) != "[2 0]" || fmt.Sprint(paths[5]) != "[2 1]" {
+		t.Errorf("kept paths = %v", paths)
+	}
+
+	// returning false stops the whole walk, sub calls included
+	visited = visited[:0]
+	frame.Walk(func(f *CallFrame, path []int) bool {
+		visited = append(visited, fmt.Sprint(path))
+		return len(path) == 0 || path[0] < 2
+	})
+	if got := strings.Join(visited, ","); got != "[],[0],[1],[2]" {
+		t.Errorf("stopped Walk visited %s", got)
+	}
+}
+
+func TestPrestateDiffStateOverrides(t *testing.T) {
+	var diff PrestateDiff
+	if err := json.Unmarshal([]byte(prestateDiff), &diff); err != nil {
+		t.Fatal(err)
+	}
+	overrides := diff.StateOverrides()
+	if len(overrides) != 3 {
+		t.Fatalf("overrides of %d accounts, want 3", len(overrides))
+	}
+
+	sender := overrides[common.HexToAddress("0x7a16ff8270133f063aab6c9977183d9e72835428")]
+	if sender.Balance == nil || sender.Balance.ToInt().String() != "999999999999995904" || sender.Nonce == nil || *sender.Nonce != 8 || sender.StateDiff != nil {
+		t.Errorf("sender override = %+v", sender)
+	}
+
+	// the updated slot takes its post value, the cleared lock slot is zeroed
+	pair := overrides[common.HexToAddress("0xB4e16d0168e52d35CaCD2c6185b44281Ec28C9Dc")]
+	if pair.Balance != nil || pair.Nonce != nil || len(pair.StateDiff) != 2 {
+		t.Fatalf("pair override = %+v", pair)
+	}
+	if got := pair.StateDiff[common.HexToHash("0x08").Hex()]; got != "0x6553f1100000000000000000000000000000000000000000000000000000bbbb" {
+		t.Errorf("reserves slot = %s", got)
+	}
+	if got := pair.StateDiff[common.HexToHash("0x0c").Hex()]; got != (common.Hash{}).Hex() {
+		t.Errorf("cleared slot = %s, want zero", got)
+	}
+
+	deployed := overrides[common.HexToAddress("0x0d4a11d5eeaac28ec3f61d100daf4d40471f1852")]
+	if deployed.Code.String() != "0x6080" || deployed.Balance != nil || deployed.StateDiff != nil {
+		t.Errorf("deployed override = %+v", deployed)
+	}
+
+	// the overrides are valid eth_call parameters
+	if _, err := json.Marshal(overrides); err != nil {
+		t.Error(err)
+	}
+}
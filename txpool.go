@@ -0,0 +1,90 @@
+package wsClient
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TxPoolContent is the result of txpool_content: transactions by sender and nonce
+// Pending transactions are executable, queued ones wait for a nonce gap to close
+type TxPoolContent struct {
+	Pending map[common.Address]map[uint64]*RPCTransaction `json:"pending"`
+	Queued  map[common.Address]map[uint64]*RPCTransaction `json:"queued"`
+}
+
+// TxPoolContentFrom is the result of txpool_contentFrom: transactions of one sender by nonce
+type TxPoolContentFrom struct {
+	Pending map[uint64]*RPCTransaction `json:"pending"`
+	Queued  map[uint64]*RPCTransaction `json:"queued"`
+}
+
+// TxPoolInspect is the result of txpool_inspect: a text summary of each transaction,
+// e.g. "0x7a25...: 0 wei + 250000 gas × 30000000000 wei"
+type TxPoolInspect struct {
+	Pending map[common.Address]map[uint64]string `json:"pending"`
+	Queued  map[common.Address]map[uint64]string `json:"queued"`
+}
+
+// TxPoolStatus is the result of txpool_status
+type TxPoolStatus struct {
+	Pending hexutil.Uint `json:"pending"`
+	Queued  hexutil.Uint `json:"queued"`
+}
+
+// BuildRequestTxPoolContent creates a request to get all transactions of the pool
+func BuildRequestTxPoolContent() *Request {
+	return NewRequest(0, "txpool_content", []interface{}{})
+}
+
+// BuildRequestTxPoolContentFrom creates a request to get the pool transactions of address
+func BuildRequestTxPoolContentFrom(address common.Address) *Request {
+	return NewRequest(0, "txpool_contentFrom", []interface{}{address.Hex()})
+}
+
+// BuildRequestTxPoolInspect creates a request to get a summary of the pool transactions
+func BuildRequestTxPoolInspect() *Request {
+	return NewRequest(0, "txpool_inspect", []interface{}{})
+}
+
+// BuildRequestTxPoolStatus creates a request to get the number of pool transactions
+func BuildRequestTxPoolStatus() *Request {
+	return NewRequest(0, "txpool_status", []interface{}{})
+}
+
+// TxPoolContent returns all transactions of the pool
+func (c *Client) TxPoolContent(ctx context.Context) (*TxPoolContent, error) {
+	result := new(TxPoolContent)
+	if err := c.Call(ctx, BuildRequestTxPoolContent(), result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// TxPoolContentFrom returns the pool transactions of address
+func (c *Client) TxPoolContentFrom(ctx context.Context, address common.Address) (*TxPoolContentFrom, error) {
+	result := new(TxPoolContentFrom)
+	if err := c.Call(ctx, BuildRequestTxPoolContentFrom(address), result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// TxPoolInspect returns a summary of the pool transactions
+func (c *Client) TxPoolInspect(ctx context.Context) (*TxPoolInspect, error) {
+	result := new(TxPoolInspect)
+	if err := c.Call(ctx, BuildRequestTxPoolInspect(), result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// TxPoolStatus returns the number of pending and queued transactions
+func (c *Client) TxPoolStatus(ctx context.Context) (*TxPoolStatus, error) {
+	result := new(TxPoolStatus)
+	if err := c.Call(ctx, BuildRequestTxPoolStatus(), result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
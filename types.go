@@ -9,6 +9,7 @@ import (
 	// "github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // Global ID counter for all requests
@@ -38,51 +39,105 @@ type ResponseAmounts struct {
 	Error   *RPCError       `json:"error,omitempty"`
 }
 
+// BigInt decodes a 32-byte ABI word such as an eth_call result as a uint256, payloads
+// longer than 32 bytes keep their last word. DecodeRawOptions reads int256 or strict words.
 type BigInt struct{ *big.Int }
 
+// MarshalJSON encodes the value as a 0x-prefixed 32-byte word, negative values as int256
+func (u BigInt) MarshalJSON() ([]byte, error) {
+	word, err := encodeWord(u.Int, u.Int != nil && u.Int.Sign() < 0)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(hexutil.Bytes(word))
+}
+
 func (u *BigInt) UnmarshalJSON(b []byte) error {
-	var raw hexutil.Bytes // parses "0x..." → []byte directly
-	if err := json.Unmarshal(b, &raw); err != nil {
-		return err
+	return u.DecodeRaw(b)
+}
+
+// SignedBigInt decodes a 32-byte ABI word as a two's-complement int256, e.g. the
+// signed deltas of a swap callback
+type SignedBigInt struct{ *big.Int }
+
+// MarshalJSON encodes the value as a 0x-prefixed 32-byte int256 word
+func (u SignedBigInt) MarshalJSON() ([]byte, error) {
+	word, err := encodeWord(u.Int, true)
+	if err != nil {
+		return nil, err
 	}
-	if len(raw) == 0 {
-		u.Int = new(big.Int)
-		return nil
+	return json.Marshal(hexutil.Bytes(word))
+}
+
+func (u *SignedBigInt) UnmarshalJSON(b []byte) error {
+	return u.DecodeRaw(b)
+}
+
+// DecodeOptions selects how the DecodeRawOptions methods read ABI words
+type DecodeOptions struct {
+	Signed  bool // words are two's-complement int256
+	Strict  bool // values longer than 32 bytes are an error instead of keeping the last word
+	Dynamic bool // a hex string is an ABI-encoded dynamic array: offset, length, then the words
+}
+
+// BigIntSlice decodes a list of 32-byte ABI words, accepted as
+//   - a single hex string of concatenated words
+//   - an array of hex strings
+//
+// Use DynamicBigIntSlice or DecodeOptions.Dynamic for an ABI-encoded uint256[]
+type BigIntSlice struct {
+	Values []*big.Int
+}
+
+// MarshalJSON encodes the values as a hex string of concatenated words
+func (a BigIntSlice) MarshalJSON() ([]byte, error) {
+	raw, err := encodeWords(make([]byte, 0, len(a.Values)*32), a.Values)
+	if err != nil {
+		return nil, err
 	}
-	if len(raw) >= 32 {
-		u.Int = new(big.Int).SetBytes(raw[len(raw)-32:])
-	} else {
-		u.Int = new(big.Int).SetBytes(raw)
+	return json.Marshal(hexutil.Bytes(raw))
+}
+
+func (a *BigIntSlice) UnmarshalJSON(b []byte) error {
+	return a.DecodeRaw(b)
+}
+
+// DynamicBigIntSlice decodes an ABI-encoded dynamic uint256[], such as the result of
+// getAmountsOut: the offset 0x20, the length, then the words
+type DynamicBigIntSlice struct {
+	Values []*big.Int
+}
+
+// MarshalJSON encodes the values as an ABI-encoded dynamic uint256[]
+func (a DynamicBigIntSlice) MarshalJSON() ([]byte, error) {
+	raw := make([]byte, 0, (len(a.Values)+2)*32)
+	raw = append(raw, common.LeftPadBytes(big.NewInt(32).Bytes(), 32)...)
+	raw = append(raw, common.LeftPadBytes(big.NewInt(int64(len(a.Values))).Bytes(), 32)...)
+	raw, err := encodeWords(raw, a.Values)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	return json.Marshal(hexutil.Bytes(raw))
 }
 
-type BigIntSlice struct{ Values []*big.Int }
+func (a *DynamicBigIntSlice) UnmarshalJSON(b []byte) error {
+	return a.DecodeRaw(b)
+}
 
-func (a *BigIntSlice) UnmarshalJSON(b []byte) error {
-	// Try single concatenated hex string first
-	var single string
-	if err := json.Unmarshal(b, &single); err == nil {
-		// Parse hex string to bytes
-		raw, err := hexutil.Decode(single)
+// encodeWords appends the words of values to raw, negative values as int256
+func encodeWords(raw []byte, values []*big.Int) ([]byte, error) {
+	for i, value := range values {
+		word, err := encodeWord(value, value != nil && value.Sign() < 0)
 		if err != nil {
-			return fmt.Errorf("decode hex string: %w", err)
-		}
-
-		// Handle concatenated 32-byte values
-		if len(raw)%32 != 0 {
-			return fmt.Errorf("invalid concatenated payload len=%d (not multiple of 32)", len(raw))
-		}
-		n := len(raw) / 32
-		a.Values = make([]*big.Int, n)
-		for i := 0; i < n; i++ {
-			word := raw[i*32 : (i+1)*32]
-			a.Values[i] = new(big.Int).SetBytes(word)
+			return nil, fmt.Errorf("encode value at index %d: %w", i, err)
 		}
-		return nil
+		raw = append(raw, word...)
 	}
+	return raw, nil
+}
 
-	// Fallback to array of hex strings
+// unmarshalArray decodes an array of hex strings, one word each
+func (a *BigIntSlice) unmarshalArray(b []byte, opts DecodeOptions) error {
 	var raws []string
 	if err := json.Unmarshal(b, &raws); err != nil {
 		return err
@@ -93,15 +148,55 @@ func (a *BigIntSlice) UnmarshalJSON(b []byte) error {
 		if err != nil {
 			return fmt.Errorf("decode hex string at index %d: %w", i, err)
 		}
-		if len(raw) >= 32 {
-			a.Values[i] = new(big.Int).SetBytes(raw[len(raw)-32:])
-		} else {
-			a.Values[i] = new(big.Int).SetBytes(raw)
+		if a.Values[i], err = decodeWord(raw, opts.Signed, opts.Strict); err != nil {
+			return fmt.Errorf("decode value at index %d: %w", i, err)
 		}
 	}
 	return nil
 }
 
+// two256 is 2^256, used for two's-complement conversion
+var two256 = new(big.Int).Lsh(big.NewInt(1), 256)
+
+// decodeWord converts an ABI word to a big.Int
+// payloads longer than 32 bytes keep the last word unless strict is set
+func decodeWord(raw []byte, signed, strict bool) (*big.Int, error) {
+	if len(raw) == 0 {
+		return new(big.Int), nil
+	}
+	if len(raw) > 32 {
+		if strict {
+			return nil, fmt.Errorf("payload len=%d exceeds 32 bytes", len(raw))
+		}
+		raw = raw[len(raw)-32:]
+	}
+	value := new(big.Int).SetBytes(raw)
+	if signed && len(raw) == 32 && raw[0]&0x80 != 0 {
+		value.Sub(value, two256)
+	}
+	return value, nil
+}
+
+// encodeWord converts a big.Int to a 32-byte ABI word
+func encodeWord(value *big.Int, signed bool) ([]byte, error) {
+	if value == nil {
+		return make([]byte, 32), nil
+	}
+	if value.Sign() < 0 {
+		if !signed {
+			return nil, fmt.Errorf("negative value %s for unsigned word", value)
+		}
+		if value.BitLen() > 255 && value.Cmp(new(big.Int).Neg(new(big.Int).Rsh(two256, 1))) != 0 {
+			return nil, fmt.Errorf("value %s overflows int256", value)
+		}
+		return new(big.Int).Add(value, two256).FillBytes(make([]byte, 32)), nil
+	}
+	if value.BitLen() > 256 || (signed && value.BitLen() > 255) {
+		return nil, fmt.Errorf("value %s overflows 256 bits", value)
+	}
+	return value.FillBytes(make([]byte, 32)), nil
+}
+
 // Use the official ethereum.CallMsg struct from go-ethereum
 // This is the standard struct used for eth_call parameters
 // type CallMsg = ethereum.CallMsg
@@ -114,6 +209,14 @@ type CallMsg struct {
 	GasFeeCap *hexutil.Big    `json:"gasFeeCap,omitempty"`
 	GasTipCap *hexutil.Big    `json:"gasTipCap,omitempty"`
 	Data      hexutil.Bytes   `json:"data,omitempty"`
+
+	Nonce            *hexutil.Uint64   `json:"nonce,omitempty"`
+	AccessList       *types.AccessList `json:"accessList,omitempty"`          // EIP-2930 access list
+	BlobHashes       []common.Hash     `json:"blobVersionedHashes,omitempty"` // EIP-4844 versioned blob hashes
+	MaxFeePerBlobGas *hexutil.Big      `json:"maxFeePerBlobGas,omitempty"`
+	ChainID          *hexutil.Big      `json:"chainId,omitempty"`
+	Input            hexutil.Bytes     `json:"input,omitempty"` // same payload as Data, for nodes that read "input"
+	Type             *hexutil.Uint64   `json:"type,omitempty"`  // tx type to simulate, e.g. types.DynamicFeeTxType
 }
 
 // String returns a string representation of the request
@@ -167,15 +270,22 @@ type RPCError struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
+// Error returns the code, the category and the message, e.g.
+// "code 3 (revert): execution reverted"
 func (e *RPCError) Error() string {
-	return e.Message
+	category := e.Category()
+	if category == CategoryUnknown {
+		return fmt.Sprintf("code %d: %s", e.Code, e.Message)
+	}
+	return fmt.Sprintf("code %d (%s): %s", e.Code, category, e.Message)
 }
 
 // StateOverride represents a state override for a specific address
+// State replaces the whole storage of the account, StateDiff only the given slots
 type StateOverride struct {
-	// Balance   *string           `json:"balance,omitempty"`   // Override balance
-	// Nonce     *uint64           `json:"nonce,omitempty"`     // Override nonce
-	// Code      *string           `json:"code,omitempty"`      // Override code
-	// State     map[string]string `json:"state,omitempty"`     // Override individual storage slots
+	Balance   *hexutil.Big      `json:"balance,omitempty"`   // Override balance
+	Nonce     *hexutil.Uint64   `json:"nonce,omitempty"`     // Override nonce
+	Code      hexutil.Bytes     `json:"code,omitempty"`      // Override code
+	State     map[string]string `json:"state,omitempty"`     // Override individual storage slots
 	StateDiff map[string]string `json:"stateDiff,omitempty"` // Override storage slots as diff
 }
@@ -0,0 +1,130 @@
+package wsClient
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestBigIntDecode(t *testing.T) {
+	minusOne := `"0x` + strings.Repeat("f", 64) + `"`
+	tests := []struct {
+		name    string
+		raw     string
+		opts    DecodeOptions
+		want    string
+		wantErr bool
+	}{
+		{"uint", `"0x2a"`, DecodeOptions{}, "42", false},
+		{"null", `null`, DecodeOptions{}, "0", false},
+		{"unsigned max", minusOne, DecodeOptions{}, new(big.Int).Sub(two256, big.NewInt(1)).String(), false},
+		{"signed", minusOne, DecodeOptions{Signed: true}, "-1", false},
+		{"truncated", `"0x` + word(1) + word(7) + `"`, DecodeOptions{}, "7", false},
+		{"strict", `"0x` + word(1) + word(7) + `"`, DecodeOptions{Strict: true}, "", true},
+		{"no prefix", `"2a"`, DecodeOptions{}, "", true},
+		{"bad digit", `"0xzz"`, DecodeOptions{}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var u BigInt
+			err := u.DecodeRawOptions([]byte(tt.raw), tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && u.String() != tt.want {
+				t.Errorf("got %s, want %s", u, tt.want)
+			}
+		})
+	}
+}
+
+func TestBigIntJSON(t *testing.T) {
+	var signed SignedBigInt
+	if err := json.Unmarshal([]byte(`"0x`+strings.Repeat("f", 63)+`e"`), &signed); err != nil {
+		t.Fatal(err)
+	}
+	if signed.Int64() != -2 {
+		t.Errorf("signed = %s, want -2", signed)
+	}
+	out, err := json.Marshal(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"0x` + strings.Repeat("f", 63) + `e"`; string(out) != want {
+		t.Errorf("marshal = %s, want %s", out, want)
+	}
+
+	out, err = json.Marshal(BigInt{big.NewInt(42)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var u BigInt
+	if err := json.Unmarshal(out, &u); err != nil || u.Int64() != 42 {
+		t.Errorf("round trip = %v, %v", u, err)
+	}
+}
+
+func TestBigIntSliceDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		opts    DecodeOptions
+		want    []int64
+		wantErr bool
+	}{
+		// two plain results that look like an empty dynamic array stay two words
+		{"concatenated", `"0x` + word(0x20) + word(0) + `"`, DecodeOptions{}, []int64{32, 0}, false},
+		{"dynamic", `"0x` + word(0x20) + word(2) + word(5) + word(6) + `"`, DecodeOptions{Dynamic: true}, []int64{5, 6}, false},
+		{"dynamic empty", `"0x` + word(0x20) + word(0) + `"`, DecodeOptions{Dynamic: true}, []int64{}, false},
+		{"dynamic bad length", `"0x` + word(0x20) + word(3) + word(5) + `"`, DecodeOptions{Dynamic: true}, nil, true},
+		{"dynamic bad offset", `"0x` + word(0x40) + word(1) + word(5) + `"`, DecodeOptions{Dynamic: true}, nil, true},
+		{"dynamic short", `"0x` + word(0x20) + `"`, DecodeOptions{Dynamic: true}, nil, true},
+		{"array", `["0x01","0x02"]`, DecodeOptions{}, []int64{1, 2}, false},
+		{"signed array", `["0x` + strings.Repeat("f", 64) + `"]`, DecodeOptions{Signed: true}, []int64{-1}, false},
+		{"odd words", `"0x` + word(1) + `00"`, DecodeOptions{}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var a BigIntSlice
+			err := a.DecodeRawOptions([]byte(tt.raw), tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(a.Values) != len(tt.want) {
+				t.Fatalf("got %d values, want %d", len(a.Values), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if a.Values[i].Int64() != want {
+					t.Errorf("value %d = %s, want %d", i, a.Values[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestDynamicBigIntSliceJSON(t *testing.T) {
+	in := DynamicBigIntSlice{Values: []*big.Int{big.NewInt(1), big.NewInt(-1)}}
+	out, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back DynamicBigIntSlice
+	if err := back.DecodeRawOptions(out, DecodeOptions{Signed: true}); err != nil {
+		t.Fatal(err)
+	}
+	if len(back.Values) != 2 || back.Values[0].Int64() != 1 || back.Values[1].Int64() != -1 {
+		t.Errorf("round trip = %v", back.Values)
+	}
+
+	var plain BigIntSlice
+	if err := json.Unmarshal(out, &plain); err != nil {
+		t.Fatal(err)
+	}
+	if len(plain.Values) != 4 {
+		t.Errorf("BigIntSlice decoded %d words, want the 4 raw words", len(plain.Values))
+	}
+}